@@ -0,0 +1,116 @@
+package alert
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/google/uuid"
+)
+
+const (
+	ParamID  = "ruleId"
+	DBPrefix = "AlertRule"
+)
+
+type Operator string
+
+const (
+	OperatorGreaterThan        Operator = "gt"
+	OperatorGreaterThanOrEqual Operator = "gte"
+	OperatorLessThan           Operator = "lt"
+	OperatorLessThanOrEqual    Operator = "lte"
+)
+
+// Rule flags a reading of DataPoint on events of Type that crosses Threshold, for example
+// "systolic" on "Blood Pressure" events greater than 180.
+type Rule struct {
+	ReceiverID string   `json:"receiverId" dynamodbav:"receiver_id"`
+	RuleID     string   `json:"ruleId" dynamodbav:"rule_id"`
+	Type       string   `json:"type" dynamodbav:"type"`
+	DataPoint  string   `json:"dataPoint" dynamodbav:"data_point"`
+	Operator   Operator `json:"operator" dynamodbav:"operator"`
+	Threshold  float64  `json:"threshold" dynamodbav:"threshold"`
+	Label      string   `json:"label,omitempty" dynamodbav:"label,omitempty"`
+	CreatedBy  string   `json:"createdBy" dynamodbav:"created_by"`
+	CreatedAt  string   `json:"createdAt" dynamodbav:"created_at"`
+}
+
+// Breach is a single reading that tripped a rule.
+type Breach struct {
+	Rule  Rule    `json:"rule"`
+	Value float64 `json:"value"`
+}
+
+func NewRule(rid, createdBy, eventType, dataPoint string, operator Operator, threshold float64, label string) (*Rule, error) {
+	if !operator.valid() {
+		return nil, fmt.Errorf("unsupported operator %q", operator)
+	}
+	if math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+		return nil, fmt.Errorf("threshold must be a finite number")
+	}
+
+	return &Rule{
+		ReceiverID: rid,
+		RuleID:     fmt.Sprintf("%s#%s", DBPrefix, uuid.NewString()),
+		Type:       eventType,
+		DataPoint:  dataPoint,
+		Operator:   operator,
+		Threshold:  threshold,
+		Label:      label,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func (o Operator) valid() bool {
+	switch o {
+	case OperatorGreaterThan, OperatorGreaterThanOrEqual, OperatorLessThan, OperatorLessThanOrEqual:
+		return true
+	}
+	return false
+}
+
+func (o Operator) compare(value, threshold float64) bool {
+	switch o {
+	case OperatorGreaterThan:
+		return value > threshold
+	case OperatorGreaterThanOrEqual:
+		return value >= threshold
+	case OperatorLessThan:
+		return value < threshold
+	case OperatorLessThanOrEqual:
+		return value <= threshold
+	}
+	return false
+}
+
+// Evaluate returns every rule the event breaches. Data points that are missing or not
+// numeric never breach a rule.
+func Evaluate(rules []Rule, e event.Entry) []Breach {
+	breaches := []Breach{}
+	for _, r := range rules {
+		if r.Type != e.Type {
+			continue
+		}
+
+		for _, dp := range e.Data {
+			if dp.Name != r.DataPoint {
+				continue
+			}
+
+			value, err := strconv.ParseFloat(strings.TrimSpace(dp.Value), 64)
+			if err != nil || math.IsNaN(value) {
+				continue
+			}
+
+			if r.Operator.compare(value, r.Threshold) {
+				breaches = append(breaches, Breach{Rule: r, Value: value})
+			}
+		}
+	}
+	return breaches
+}
@@ -0,0 +1,64 @@
+package alert
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRule(t *testing.T) {
+	r, err := NewRule("Receiver#123", "User#123", "Blood Pressure", "systolic", OperatorGreaterThan, 180, "Hypertensive crisis")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(r.RuleID, DBPrefix+"#"))
+	assert.Equal(t, "Receiver#123", r.ReceiverID)
+	assert.Equal(t, 180.0, r.Threshold)
+
+	_, err = NewRule("Receiver#123", "User#123", "Temperature", "celsius", Operator("eq"), 39, "")
+	assert.NotNil(t, err)
+
+	_, err = NewRule("Receiver#123", "User#123", "Temperature", "celsius", OperatorGreaterThan, math.Inf(1), "")
+	assert.NotNil(t, err)
+}
+
+func TestEvaluate(t *testing.T) {
+	rules := []Rule{
+		{RuleID: "AlertRule#High", Type: "Blood Pressure", DataPoint: "systolic", Operator: OperatorGreaterThan, Threshold: 180},
+		{RuleID: "AlertRule#Low", Type: "Blood Pressure", DataPoint: "systolic", Operator: OperatorLessThanOrEqual, Threshold: 90},
+		{RuleID: "AlertRule#Fever", Type: "Temperature", DataPoint: "celsius", Operator: OperatorGreaterThanOrEqual, Threshold: 39},
+	}
+
+	tests := map[string]struct {
+		entry    event.Entry
+		expected []Breach
+	}{
+		"High Reading": {
+			entry:    event.Entry{Type: "Blood Pressure", Data: []event.DataPoint{{Name: "systolic", Value: "185"}, {Name: "diastolic", Value: "110"}}},
+			expected: []Breach{{Rule: rules[0], Value: 185}},
+		},
+		"Boundary Reading": {
+			entry:    event.Entry{Type: "Blood Pressure", Data: []event.DataPoint{{Name: "systolic", Value: " 90 "}}},
+			expected: []Breach{{Rule: rules[1], Value: 90}},
+		},
+		"Normal Reading": {
+			entry:    event.Entry{Type: "Blood Pressure", Data: []event.DataPoint{{Name: "systolic", Value: "120"}}},
+			expected: []Breach{},
+		},
+		"Other Type": {
+			entry:    event.Entry{Type: "Temperature", Data: []event.DataPoint{{Name: "systolic", Value: "200"}}},
+			expected: []Breach{},
+		},
+		"Not Numeric": {
+			entry:    event.Entry{Type: "Temperature", Data: []event.DataPoint{{Name: "celsius", Value: "hot"}}},
+			expected: []Breach{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Evaluate(rules, tc.entry))
+		})
+	}
+}
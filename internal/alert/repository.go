@@ -0,0 +1,101 @@
+package alert
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"go.uber.org/zap"
+)
+
+var ErrNotFound = errors.New("alert rule not found")
+
+type RepositoryProvider interface {
+	AddRule(rule *Rule) error
+	GetRules(rid string) ([]Rule, error)
+	DeleteRule(rid, ruleID string) error
+}
+
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+func (r *Repository) AddRule(rule *Rule) error {
+	item, err := attributevalue.MarshalMap(rule)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.PutItem(r.Ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.TableName),
+		Item:      item,
+	})
+	if err != nil {
+		r.logger.Error("error adding alert rule", zap.String(log.ReceiverIDLogKey, rule.ReceiverID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *Repository) GetRules(rid string) ([]Rule, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.TableName),
+		KeyConditionExpression: aws.String("receiver_id = :rid"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":rid": &types.AttributeValueMemberS{Value: rid},
+		},
+	}
+
+	rules := []Rule{}
+	paginator := dynamodb.NewQueryPaginator(r.Client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(r.Ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageRules []Rule
+		err = attributevalue.UnmarshalListOfMaps(page.Items, &pageRules)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, pageRules...)
+	}
+	return rules, nil
+}
+
+func (r *Repository) DeleteRule(rid, ruleID string) error {
+	_, err := r.Client.DeleteItem(r.Ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.TableName),
+		Key: map[string]types.AttributeValue{
+			"receiver_id": &types.AttributeValueMemberS{Value: rid},
+			"rule_id":     &types.AttributeValueMemberS{Value: ruleID},
+		},
+		ConditionExpression: aws.String("attribute_exists(rule_id)"),
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrNotFound
+		}
+		r.logger.Error("error deleting alert rule", zap.String(log.ReceiverIDLogKey, rid), zap.Error(err))
+		return err
+	}
+	return nil
+}
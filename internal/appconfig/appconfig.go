@@ -3,6 +3,9 @@ package appconfig
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
@@ -11,6 +14,11 @@ import (
 
 const (
 	LocalEnv = "local"
+
+	defaultEventRetentionDays  = 30
+	defaultMaxBatchEvents      = 100
+	defaultRateLimitPerMinute  = 120
+	defaultCompressionMinBytes = 1024
 )
 
 type AppConfig struct {
@@ -21,7 +29,30 @@ type AppConfig struct {
 	ReceiverTableName     string
 	EventTableName        string
 	RelationshipTableName string
-	FeedbackQueueURL      string
+	TombstoneTableName    string
+	AuditTableName        string
+	EmailClaimTableName   string
+	ChangeLogTableName    string
+	ScheduleTableName     string
+	MedicationTableName   string
+	AlertRuleTableName    string
+	PreferenceTableName   string
+	DigestTableName       string
+	FeedbackTableName     string
+	RateLimitTableName    string
+	CalendarFeedTableName string
+	EventRetentionDays    int
+	MaxBatchEvents        int
+	NotificationQueueURL  string
+	FeedbackRecipients    []string
+	AdminEmails           []string
+	RateLimitPerMinute    int
+	RateLimitEndpoints    map[string]int
+	CORSAllowedOrigins    []string
+	CompressionMinBytes   int
+	// FHIRObservationMappings is a JSON array of fhir.Mapping, parsed once at startup. Empty
+	// uses the built in vital sign mappings.
+	FHIRObservationMappings string
 }
 
 func NewAppConfig() *AppConfig {
@@ -41,7 +72,51 @@ func (a *AppConfig) ReadEnvVars() {
 	a.ReceiverTableName = getEnvVarStringOrDefault("RECEIVER_TABLE_NAME", fmt.Sprintf("%s-%s", "receiver-table", LocalEnv))
 	a.EventTableName = getEnvVarStringOrDefault("EVENT_TABLE_NAME", fmt.Sprintf("%s-%s", "event-table", LocalEnv))
 	a.RelationshipTableName = getEnvVarStringOrDefault("RELATIONSHIP_TABLE_NAME", fmt.Sprintf("%s-%s", "relationship-table", LocalEnv))
-	a.FeedbackQueueURL = getEnvVarStringOrDefault("FEEDBACK_QUEUE_URL", "")
+	a.TombstoneTableName = getEnvVarStringOrDefault("TOMBSTONE_TABLE_NAME", fmt.Sprintf("%s-%s", "tombstone-table", LocalEnv))
+	a.AuditTableName = getEnvVarStringOrDefault("AUDIT_TABLE_NAME", fmt.Sprintf("%s-%s", "audit-table", LocalEnv))
+	a.EmailClaimTableName = getEnvVarStringOrDefault("EMAIL_CLAIM_TABLE_NAME", fmt.Sprintf("%s-%s", "email-claim-table", LocalEnv))
+	a.ChangeLogTableName = getEnvVarStringOrDefault("CHANGE_LOG_TABLE_NAME", fmt.Sprintf("%s-%s", "change-log-table", LocalEnv))
+	a.ScheduleTableName = getEnvVarStringOrDefault("SCHEDULE_TABLE_NAME", fmt.Sprintf("%s-%s", "schedule-table", LocalEnv))
+	a.MedicationTableName = getEnvVarStringOrDefault("MEDICATION_TABLE_NAME", fmt.Sprintf("%s-%s", "medication-table", LocalEnv))
+	a.AlertRuleTableName = getEnvVarStringOrDefault("ALERT_RULE_TABLE_NAME", fmt.Sprintf("%s-%s", "alert-rule-table", LocalEnv))
+	a.PreferenceTableName = getEnvVarStringOrDefault("PREFERENCE_TABLE_NAME", fmt.Sprintf("%s-%s", "notification-preference-table", LocalEnv))
+	a.DigestTableName = getEnvVarStringOrDefault("DIGEST_TABLE_NAME", fmt.Sprintf("%s-%s", "activity-digest-table", LocalEnv))
+	a.FeedbackTableName = getEnvVarStringOrDefault("FEEDBACK_TABLE_NAME", fmt.Sprintf("%s-%s", "feedback-table", LocalEnv))
+	a.RateLimitTableName = getEnvVarStringOrDefault("RATE_LIMIT_TABLE_NAME", fmt.Sprintf("%s-%s", "rate-limit-table", LocalEnv))
+	a.CalendarFeedTableName = getEnvVarStringOrDefault("CALENDAR_FEED_TABLE_NAME", fmt.Sprintf("%s-%s", "calendar-feed-table", LocalEnv))
+	a.EventRetentionDays = getEnvVarPositiveIntOrDefault("EVENT_RETENTION_DAYS", defaultEventRetentionDays)
+	a.MaxBatchEvents = getEnvVarIntOrDefault("MAX_BATCH_EVENTS", defaultMaxBatchEvents)
+	a.NotificationQueueURL = getEnvVarStringOrDefault("NOTIFICATION_QUEUE_URL", "")
+	a.FeedbackRecipients = getEnvVarListOrDefault("FEEDBACK_RECIPIENTS", nil)
+	a.AdminEmails = getEnvVarListOrDefault("ADMIN_EMAILS", nil)
+	a.RateLimitPerMinute = getEnvVarIntOrDefault("RATE_LIMIT_PER_MINUTE", defaultRateLimitPerMinute)
+	a.RateLimitEndpoints = getEnvVarIntMapOrDefault("RATE_LIMIT_ENDPOINTS", map[string]int{})
+	a.CORSAllowedOrigins = getEnvVarListOrDefault("CORS_ALLOWED_ORIGINS", defaultCORSAllowedOrigins(a.Env))
+	a.CompressionMinBytes = getEnvVarIntOrDefault("COMPRESSION_MIN_BYTES", defaultCompressionMinBytes)
+	a.FHIRObservationMappings = getEnvVarStringOrDefault("FHIR_OBSERVATION_MAPPINGS", "")
+}
+
+// EventRetention is how long a deleted event is kept before it is purged for good.
+func (a *AppConfig) EventRetention() time.Duration {
+	return time.Duration(a.EventRetentionDays) * 24 * time.Hour
+}
+
+// RateLimit is how many requests per minute a single caller may make to the endpoint. Zero
+// means the endpoint is not rate limited.
+func (a *AppConfig) RateLimit(method, path string) int {
+	if limit, ok := a.RateLimitEndpoints[method+" "+path]; ok {
+		return limit
+	}
+	return a.RateLimitPerMinute
+}
+
+// defaultCORSAllowedOrigins lets any origin call a local API. Deployed stacks must list their
+// origins explicitly.
+func defaultCORSAllowedOrigins(env string) []string {
+	if env == LocalEnv {
+		return []string{"*"}
+	}
+	return []string{}
 }
 
 func getEnvVarStringOrDefault(envVar string, defaultValue string) string {
@@ -51,3 +126,66 @@ func getEnvVarStringOrDefault(envVar string, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvVarIntOrDefault(envVar string, defaultValue int) int {
+	env, present := os.LookupEnv(envVar)
+	if !present {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(env)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvVarPositiveIntOrDefault is getEnvVarIntOrDefault for settings where zero or a
+// negative value makes no sense, such as a retention window.
+func getEnvVarPositiveIntOrDefault(envVar string, defaultValue int) int {
+	value := getEnvVarIntOrDefault(envVar, defaultValue)
+	if value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvVarListOrDefault reads a comma separated list, dropping blank entries.
+func getEnvVarListOrDefault(envVar string, defaultValue []string) []string {
+	env, present := os.LookupEnv(envVar)
+	if !present {
+		return defaultValue
+	}
+
+	values := []string{}
+	for _, value := range strings.Split(env, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvVarIntMapOrDefault reads a comma separated list of key=value pairs, skipping any
+// pair whose value is not an integer.
+func getEnvVarIntMapOrDefault(envVar string, defaultValue map[string]int) map[string]int {
+	entries := getEnvVarListOrDefault(envVar, nil)
+	if entries == nil {
+		return defaultValue
+	}
+
+	values := map[string]int{}
+	for _, entry := range entries {
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			continue
+		}
+
+		value, err := strconv.Atoi(strings.TrimSpace(entry[i+1:]))
+		if err != nil {
+			continue
+		}
+		values[strings.TrimSpace(entry[:i])] = value
+	}
+	return values
+}
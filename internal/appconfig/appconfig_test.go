@@ -3,6 +3,7 @@ package appconfig
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -14,6 +15,11 @@ var (
 func TestReadEnvVars(t *testing.T) {
 	os.Setenv("ENV", "TEST")
 	os.Setenv("USER_TABLE_NAME", "user-table-test")
+	os.Setenv("EVENT_RETENTION_DAYS", "7")
+	os.Setenv("FEEDBACK_RECIPIENTS", "support@caregiver.app, product@caregiver.app")
+	os.Setenv("ADMIN_EMAILS", "admin@caregiver.app")
+	os.Setenv("RATE_LIMIT_ENDPOINTS", "POST /feedback=5,POST /event=60")
+	os.Setenv("CORS_ALLOWED_ORIGINS", "https://app.caregiver.test")
 	ac.ReadEnvVars()
 
 	assert.Equal(t, "TEST", ac.Env)
@@ -21,4 +27,68 @@ func TestReadEnvVars(t *testing.T) {
 	assert.Equal(t, "receiver-table-local", ac.ReceiverTableName)
 	assert.Equal(t, "event-table-local", ac.EventTableName)
 	assert.Equal(t, "relationship-table-local", ac.RelationshipTableName)
+	assert.Equal(t, "tombstone-table-local", ac.TombstoneTableName)
+	assert.Equal(t, "audit-table-local", ac.AuditTableName)
+	assert.Equal(t, "email-claim-table-local", ac.EmailClaimTableName)
+	assert.Equal(t, "change-log-table-local", ac.ChangeLogTableName)
+	assert.Equal(t, "schedule-table-local", ac.ScheduleTableName)
+	assert.Equal(t, "medication-table-local", ac.MedicationTableName)
+	assert.Equal(t, "alert-rule-table-local", ac.AlertRuleTableName)
+	assert.Equal(t, "notification-preference-table-local", ac.PreferenceTableName)
+	assert.Equal(t, "activity-digest-table-local", ac.DigestTableName)
+	assert.Equal(t, "feedback-table-local", ac.FeedbackTableName)
+	assert.Equal(t, 7, ac.EventRetentionDays)
+	assert.Equal(t, 7*24*time.Hour, ac.EventRetention())
+	assert.Equal(t, 100, ac.MaxBatchEvents)
+	assert.Equal(t, []string{"support@caregiver.app", "product@caregiver.app"}, ac.FeedbackRecipients)
+	assert.Equal(t, []string{"admin@caregiver.app"}, ac.AdminEmails)
+	assert.Equal(t, "rate-limit-table-local", ac.RateLimitTableName)
+	assert.Equal(t, "calendar-feed-table-local", ac.CalendarFeedTableName)
+	assert.Equal(t, 120, ac.RateLimitPerMinute)
+	assert.Equal(t, map[string]int{"POST /feedback": 5, "POST /event": 60}, ac.RateLimitEndpoints)
+	assert.Equal(t, 5, ac.RateLimit("POST", "/feedback"))
+	assert.Equal(t, 120, ac.RateLimit("GET", "/feedback"))
+	assert.Equal(t, []string{"https://app.caregiver.test"}, ac.CORSAllowedOrigins)
+	assert.Equal(t, 1024, ac.CompressionMinBytes)
+	assert.Equal(t, "", ac.FHIRObservationMappings)
+}
+
+func TestGetEnvVarIntOrDefault(t *testing.T) {
+	os.Setenv("TEST_INT_VAR", "12")
+	os.Setenv("TEST_BAD_INT_VAR", "twelve")
+
+	assert.Equal(t, 12, getEnvVarIntOrDefault("TEST_INT_VAR", 1))
+	assert.Equal(t, 1, getEnvVarIntOrDefault("TEST_BAD_INT_VAR", 1))
+	assert.Equal(t, 1, getEnvVarIntOrDefault("TEST_MISSING_INT_VAR", 1))
+}
+
+func TestGetEnvVarPositiveIntOrDefault(t *testing.T) {
+	os.Setenv("TEST_POSITIVE_INT_VAR", "7")
+	os.Setenv("TEST_ZERO_INT_VAR", "0")
+	os.Setenv("TEST_NEGATIVE_INT_VAR", "-3")
+
+	assert.Equal(t, 7, getEnvVarPositiveIntOrDefault("TEST_POSITIVE_INT_VAR", 30))
+	assert.Equal(t, 30, getEnvVarPositiveIntOrDefault("TEST_ZERO_INT_VAR", 30))
+	assert.Equal(t, 30, getEnvVarPositiveIntOrDefault("TEST_NEGATIVE_INT_VAR", 30))
+}
+
+func TestGetEnvVarListOrDefault(t *testing.T) {
+	os.Setenv("TEST_LIST_VAR", " a@test.com,,b@test.com ")
+	os.Setenv("TEST_EMPTY_LIST_VAR", "")
+
+	assert.Equal(t, []string{"a@test.com", "b@test.com"}, getEnvVarListOrDefault("TEST_LIST_VAR", nil))
+	assert.Equal(t, []string{}, getEnvVarListOrDefault("TEST_EMPTY_LIST_VAR", nil))
+	assert.Equal(t, []string{"default@test.com"}, getEnvVarListOrDefault("TEST_MISSING_LIST_VAR", []string{"default@test.com"}))
+}
+
+func TestGetEnvVarIntMapOrDefault(t *testing.T) {
+	os.Setenv("TEST_INT_MAP_VAR", "POST /feedback=5, GET /events/{receiverId} = 30,bad,POST /event=many")
+
+	assert.Equal(t, map[string]int{"POST /feedback": 5, "GET /events/{receiverId}": 30}, getEnvVarIntMapOrDefault("TEST_INT_MAP_VAR", nil))
+	assert.Equal(t, map[string]int{"a": 1}, getEnvVarIntMapOrDefault("TEST_MISSING_INT_MAP_VAR", map[string]int{"a": 1}))
+}
+
+func TestDefaultCORSAllowedOrigins(t *testing.T) {
+	assert.Equal(t, []string{"*"}, defaultCORSAllowedOrigins(LocalEnv))
+	assert.Equal(t, []string{}, defaultCORSAllowedOrigins("prod"))
 }
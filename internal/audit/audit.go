@@ -0,0 +1,130 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Action string
+
+// idTimeFormat is a fixed width timestamp so audit IDs sort in the order they were written.
+// time.RFC3339Nano trims trailing zeros, which breaks lexical ordering within a second.
+const idTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+const (
+	ActionCreateUser           Action = "create_user"
+	ActionUpdateUser           Action = "update_user"
+	ActionDeleteUser           Action = "delete_user"
+	ActionAddPrimaryReceiver   Action = "add_primary_receiver"
+	ActionAddCareGiver         Action = "add_care_giver"
+	ActionAddEvent             Action = "add_event"
+	ActionDeleteEvent          Action = "delete_event"
+	ActionRestoreEvent         Action = "restore_event"
+	ActionSubmitFeedback       Action = "submit_feedback"
+	ActionAddSchedule          Action = "add_schedule"
+	ActionDeleteSchedule       Action = "delete_schedule"
+	ActionAddMedication        Action = "add_medication"
+	ActionUpdateMedication     Action = "update_medication"
+	ActionLogDose              Action = "log_dose"
+	ActionAddAlertRule         Action = "add_alert_rule"
+	ActionDeleteAlertRule      Action = "delete_alert_rule"
+	ActionUpdateFeedbackStatus Action = "update_feedback_status"
+	ActionExportEvents         Action = "export_events"
+	ActionRotateCalendarToken  Action = "rotate_calendar_token"
+
+	ActionUpdateNotificationPreferences Action = "update_notification_preferences"
+)
+
+// Entry is a single append-only audit record. ScopeID is the receiver the change
+// belongs to, or the acting user for changes that are not tied to a receiver.
+type Entry struct {
+	ScopeID   string            `json:"scopeId" dynamodbav:"scope_id"`
+	AuditID   string            `json:"auditId" dynamodbav:"audit_id"`
+	ActorID   string            `json:"actorId" dynamodbav:"actor_id"`
+	Action    Action            `json:"action" dynamodbav:"action"`
+	Targets   map[string]string `json:"targets,omitempty" dynamodbav:"targets,omitempty"`
+	Before    json.RawMessage   `json:"before,omitempty" dynamodbav:"before,omitempty"`
+	After     json.RawMessage   `json:"after,omitempty" dynamodbav:"after,omitempty"`
+	RequestID string            `json:"requestId,omitempty" dynamodbav:"request_id,omitempty"`
+	Timestamp string            `json:"timestamp" dynamodbav:"timestamp"`
+}
+
+type entryConfig struct {
+	targets   map[string]string
+	before    any
+	after     any
+	requestID string
+}
+
+type EntryOption func(*entryConfig)
+
+func WithTarget(name, id string) EntryOption {
+	return func(c *entryConfig) {
+		c.targets[name] = id
+	}
+}
+
+func WithBefore(snapshot any) EntryOption {
+	return func(c *entryConfig) {
+		c.before = snapshot
+	}
+}
+
+func WithAfter(snapshot any) EntryOption {
+	return func(c *entryConfig) {
+		c.after = snapshot
+	}
+}
+
+func WithRequestID(requestID string) EntryOption {
+	return func(c *entryConfig) {
+		c.requestID = requestID
+	}
+}
+
+func NewEntry(scopeID, actorID string, action Action, opts ...EntryOption) (*Entry, error) {
+	cfg := &entryConfig{
+		targets: map[string]string{},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	before, err := marshalSnapshot(cfg.before)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling before snapshot: %w", err)
+	}
+
+	after, err := marshalSnapshot(cfg.after)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling after snapshot: %w", err)
+	}
+
+	now := time.Now().UTC()
+	entry := &Entry{
+		ScopeID: scopeID,
+		// Prefixing with the timestamp keeps entries in chronological order within a scope.
+		AuditID:   fmt.Sprintf("%s#%s", now.Format(idTimeFormat), uuid.NewString()),
+		ActorID:   actorID,
+		Action:    action,
+		Before:    before,
+		After:     after,
+		RequestID: cfg.requestID,
+		Timestamp: now.Format(time.RFC3339),
+	}
+
+	if len(cfg.targets) > 0 {
+		entry.Targets = cfg.targets
+	}
+	return entry, nil
+}
+
+func marshalSnapshot(snapshot any) (json.RawMessage, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	return json.Marshal(snapshot)
+}
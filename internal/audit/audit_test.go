@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEntry(t *testing.T) {
+	before := map[string]string{"note": "old"}
+	after := map[string]string{"note": "new"}
+
+	entry, err := NewEntry("Receiver#123", "User#123", ActionAddEvent,
+		WithTarget("eventId", "Event#123"),
+		WithBefore(before),
+		WithAfter(after),
+		WithRequestID("request-123"),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, "Receiver#123", entry.ScopeID)
+	assert.Equal(t, "User#123", entry.ActorID)
+	assert.Equal(t, ActionAddEvent, entry.Action)
+	assert.Equal(t, map[string]string{"eventId": "Event#123"}, entry.Targets)
+	assert.JSONEq(t, `{"note":"old"}`, string(entry.Before))
+	assert.JSONEq(t, `{"note":"new"}`, string(entry.After))
+	assert.Equal(t, "request-123", entry.RequestID)
+	assert.True(t, strings.HasPrefix(entry.AuditID, entry.Timestamp[:19]))
+}
+
+func TestAuditIDsSortInWriteOrder(t *testing.T) {
+	// 100ms formats shorter than 120ms under RFC3339Nano and would sort after it.
+	first := time.Date(2026, 4, 23, 12, 0, 0, 100000000, time.UTC).Format(idTimeFormat)
+	second := time.Date(2026, 4, 23, 12, 0, 0, 120000000, time.UTC).Format(idTimeFormat)
+	assert.Len(t, first, len(second))
+	assert.Less(t, first, second)
+
+	entry, err := NewEntry("User#123", "User#123", ActionCreateUser)
+	assert.Nil(t, err)
+	assert.Len(t, strings.SplitN(entry.AuditID, "#", 2)[0], len(idTimeFormat))
+}
+
+func TestNewEntryWithoutOptions(t *testing.T) {
+	entry, err := NewEntry("User#123", "User#123", ActionCreateUser)
+	assert.Nil(t, err)
+	assert.Nil(t, entry.Targets)
+	assert.Nil(t, entry.Before)
+	assert.Nil(t, entry.After)
+}
+
+func TestNewEntryBadSnapshot(t *testing.T) {
+	_, err := NewEntry("User#123", "User#123", ActionCreateUser, WithAfter(make(chan int)))
+	assert.NotNil(t, err)
+}
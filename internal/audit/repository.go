@@ -0,0 +1,79 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+// RepositoryProvider only exposes appends and reads; audit entries are never updated or removed.
+type RepositoryProvider interface {
+	AddEntry(e *Entry) error
+	GetEntries(scopeID string) ([]Entry, error)
+}
+
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+func (r *Repository) AddEntry(e *Entry) error {
+	item, err := attributevalue.MarshalMap(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.PutItem(r.Ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(r.TableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(audit_id)"),
+	})
+	if err != nil {
+		r.logger.Error("error adding audit entry", zap.String("scopeId", e.ScopeID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *Repository) GetEntries(scopeID string) ([]Entry, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.TableName),
+		KeyConditionExpression: aws.String("scope_id = :scope"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":scope": &types.AttributeValueMemberS{Value: scopeID},
+		},
+		ScanIndexForward: aws.Bool(false),
+	}
+
+	entries := []Entry{}
+	paginator := dynamodb.NewQueryPaginator(r.Client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(r.Ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageEntries []Entry
+		err = attributevalue.UnmarshalListOfMaps(page.Items, &pageEntries)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, pageEntries...)
+	}
+	return entries, nil
+}
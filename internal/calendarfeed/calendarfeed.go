@@ -0,0 +1,54 @@
+package calendarfeed
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	ParamID = "token"
+
+	tokenBytes = 32
+)
+
+// Feed holds the token that unlocks a user's calendar feed. Only a hash of the token is
+// stored, so it can be checked but not read back; regenerating replaces the hash, which
+// revokes every URL issued before it.
+type Feed struct {
+	UserID    string `json:"userId" dynamodbav:"user_id"`
+	TokenHash string `json:"-" dynamodbav:"token_hash"`
+	CreatedAt string `json:"createdAt" dynamodbav:"created_at"`
+}
+
+// NewFeed issues a fresh random token for uid. The token is returned to hand to the user
+// and is not kept on the Feed.
+func NewFeed(uid string) (*Feed, string, error) {
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	return &Feed{
+		UserID:    uid,
+		TokenHash: hashToken(token),
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}, token, nil
+}
+
+// Matches reports whether token is the feed's current token, in constant time.
+func (f Feed) Matches(token string) bool {
+	if token == "" || f.TokenHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(f.TokenHash)) == 1
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,47 @@
+package calendarfeed
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFeed(t *testing.T) {
+	f, token, err := NewFeed("User#123")
+	assert.Nil(t, err)
+	assert.Equal(t, "User#123", f.UserID)
+	assert.Len(t, token, 43)
+	assert.NotContains(t, f.TokenHash, token)
+
+	_, err = time.Parse(time.RFC3339, f.CreatedAt)
+	assert.Nil(t, err)
+
+	_, other, err := NewFeed("User#123")
+	assert.Nil(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestFeedMatches(t *testing.T) {
+	f, token, err := NewFeed("User#123")
+	assert.Nil(t, err)
+
+	assert.True(t, f.Matches(token))
+	assert.False(t, f.Matches(token+"x"))
+	assert.False(t, f.Matches(""))
+	assert.False(t, Feed{}.Matches(token))
+
+	regenerated, _, err := NewFeed("User#123")
+	assert.Nil(t, err)
+	assert.False(t, regenerated.Matches(token))
+}
+
+func TestFeedJSONHidesHash(t *testing.T) {
+	f, _, err := NewFeed("User#123")
+	assert.Nil(t, err)
+
+	body, err := json.Marshal(f)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(body), f.TokenHash)
+}
@@ -0,0 +1,77 @@
+package calendarfeed
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"go.uber.org/zap"
+)
+
+var ErrNotFound = errors.New("calendar feed not found")
+
+type RepositoryProvider interface {
+	GetFeed(uid string) (Feed, error)
+	PutFeed(f *Feed) error
+}
+
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+func (r *Repository) GetFeed(uid string) (Feed, error) {
+	result, err := r.Client.GetItem(r.Ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.TableName),
+		Key: map[string]types.AttributeValue{
+			"user_id": &types.AttributeValueMemberS{Value: uid},
+		},
+	})
+	if err != nil {
+		return Feed{}, err
+	}
+
+	if result.Item == nil {
+		return Feed{}, ErrNotFound
+	}
+
+	var f Feed
+	err = attributevalue.UnmarshalMap(result.Item, &f)
+	if err != nil {
+		return Feed{}, err
+	}
+	return f, nil
+}
+
+// PutFeed stores f, replacing and so revoking any earlier token for the user.
+func (r *Repository) PutFeed(f *Feed) error {
+	item, err := attributevalue.MarshalMap(f)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.PutItem(r.Ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.TableName),
+		Item:      item,
+	})
+	if err != nil {
+		r.logger.Error("error saving calendar feed", zap.String(log.UserIDLogKey, f.UserID), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package changelog
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/google/uuid"
+)
+
+// SequenceFormat is a fixed width timestamp so change IDs sort in the order they were written.
+// time.RFC3339Nano trims trailing zeros, which breaks lexical ordering.
+const SequenceFormat = "2006-01-02T15:04:05.000000000Z"
+
+type Operation string
+
+const (
+	OperationUpsert Operation = "upsert"
+	OperationDelete Operation = "delete"
+)
+
+var ErrInvalidToken = errors.New("invalid sync token")
+
+// Change records that an event was written or deleted so clients can catch up from a sync token.
+type Change struct {
+	ReceiverID string      `json:"receiverId" dynamodbav:"receiver_id"`
+	ChangeID   string      `json:"changeId" dynamodbav:"change_id"`
+	EventID    string      `json:"eventId" dynamodbav:"event_id"`
+	Operation  Operation   `json:"operation" dynamodbav:"operation"`
+	Event      event.Entry `json:"event" dynamodbav:"event"`
+	ChangedBy  string      `json:"changedBy" dynamodbav:"changed_by"`
+	ChangedAt  string      `json:"changedAt" dynamodbav:"changed_at"`
+	ExpiresAt  int64       `json:"expiresAt" dynamodbav:"expires_at"`
+}
+
+func NewChange(e event.Entry, op Operation, changedBy string, retention time.Duration) *Change {
+	now := time.Now().UTC()
+	return &Change{
+		ReceiverID: e.ReceiverID,
+		ChangeID:   fmt.Sprintf("%s#%s", now.Format(SequenceFormat), uuid.NewString()),
+		EventID:    e.EventID,
+		Operation:  op,
+		Event:      e,
+		ChangedBy:  changedBy,
+		ChangedAt:  now.Format(time.RFC3339),
+		ExpiresAt:  now.Add(retention).Unix(),
+	}
+}
+
+// Latest collapses a change list in write order down to the last change for each event,
+// keeping the order in which each event last changed.
+func Latest(changes []Change) []Change {
+	last := make(map[string]int, len(changes))
+	for i, c := range changes {
+		last[c.EventID] = i
+	}
+
+	latest := make([]Change, 0, len(last))
+	for i, c := range changes {
+		if last[c.EventID] == i {
+			latest = append(latest, c)
+		}
+	}
+	return latest
+}
+
+// Token is handed to clients after a sync and sent back to fetch only what changed since.
+type Token struct {
+	ReceiverID string    `json:"r"`
+	Since      time.Time `json:"s"`
+}
+
+func (t Token) String() string {
+	b, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func ParseToken(s string) (Token, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Token{}, ErrInvalidToken
+	}
+
+	var t Token
+	if err := json.Unmarshal(b, &t); err != nil || t.ReceiverID == "" || t.Since.IsZero() {
+		return Token{}, ErrInvalidToken
+	}
+	return t, nil
+}
@@ -0,0 +1,60 @@
+package changelog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewChange(t *testing.T) {
+	e := event.Entry{
+		EventID:    "Event#123",
+		ReceiverID: "Receiver#123",
+	}
+
+	c := NewChange(e, OperationDelete, "User#123", time.Hour)
+	assert.Equal(t, "Receiver#123", c.ReceiverID)
+	assert.Equal(t, "Event#123", c.EventID)
+	assert.Equal(t, OperationDelete, c.Operation)
+	assert.Equal(t, "User#123", c.ChangedBy)
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{9}Z#`, c.ChangeID)
+
+	changedAt, err := time.Parse(time.RFC3339, c.ChangedAt)
+	assert.Nil(t, err)
+	assert.Equal(t, changedAt.Add(time.Hour).Unix(), c.ExpiresAt)
+}
+
+func TestLatest(t *testing.T) {
+	changes := []Change{
+		{ChangeID: "1", EventID: "Event#1", Operation: OperationUpsert},
+		{ChangeID: "2", EventID: "Event#2", Operation: OperationUpsert},
+		{ChangeID: "3", EventID: "Event#1", Operation: OperationDelete},
+	}
+
+	assert.Equal(t, []Change{changes[1], changes[2]}, Latest(changes))
+}
+
+func TestToken(t *testing.T) {
+	since := time.Date(2026, 4, 23, 12, 0, 0, 5, time.UTC)
+	token := Token{ReceiverID: "Receiver#123", Since: since}
+
+	parsed, err := ParseToken(token.String())
+	assert.Nil(t, err)
+	assert.Equal(t, "Receiver#123", parsed.ReceiverID)
+	assert.True(t, since.Equal(parsed.Since))
+
+	tests := map[string]string{
+		"Not Base64":       "%%%",
+		"Not JSON":         "bm90IGpzb24",
+		"Missing Fields":   Token{}.String(),
+		"Missing Receiver": Token{Since: since}.String(),
+	}
+	for name, bad := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseToken(bad)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
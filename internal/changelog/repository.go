@@ -0,0 +1,80 @@
+package changelog
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"go.uber.org/zap"
+)
+
+type RepositoryProvider interface {
+	AddChange(c *Change) error
+	GetChanges(rid string, since string) ([]Change, error)
+}
+
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+func (r *Repository) AddChange(c *Change) error {
+	item, err := attributevalue.MarshalMap(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.PutItem(r.Ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.TableName),
+		Item:      item,
+	})
+	if err != nil {
+		r.logger.Error("error adding change", zap.String(log.ReceiverIDLogKey, c.ReceiverID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetChanges returns the changes for a receiver written after the since sequence, oldest first.
+func (r *Repository) GetChanges(rid string, since string) ([]Change, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.TableName),
+		KeyConditionExpression: aws.String("receiver_id = :rid AND change_id > :since"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":rid":   &types.AttributeValueMemberS{Value: rid},
+			":since": &types.AttributeValueMemberS{Value: since},
+		},
+		ScanIndexForward: aws.Bool(true),
+	}
+
+	changes := []Change{}
+	paginator := dynamodb.NewQueryPaginator(r.Client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(r.Ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageChanges []Change
+		err = attributevalue.UnmarshalListOfMaps(page.Items, &pageChanges)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, pageChanges...)
+	}
+	return changes, nil
+}
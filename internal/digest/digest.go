@@ -0,0 +1,33 @@
+package digest
+
+import (
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+)
+
+// Item is one logged event waiting to go out in a digest.
+type Item struct {
+	EventID   string `json:"eventId" dynamodbav:"event_id"`
+	Type      string `json:"type" dynamodbav:"type"`
+	StartTime string `json:"startTime" dynamodbav:"start_time"`
+	LoggedBy  string `json:"loggedBy" dynamodbav:"logged_by"`
+}
+
+// Digest collects event activity for one caregiver and receiver until FlushAt, so a burst of
+// logs turns into a single email.
+type Digest struct {
+	UserID     string `json:"userId" dynamodbav:"user_id"`
+	ReceiverID string `json:"receiverId" dynamodbav:"receiver_id"`
+	Email      string `json:"email" dynamodbav:"email"`
+	Items      []Item `json:"items" dynamodbav:"items"`
+	FlushAt    string `json:"flushAt" dynamodbav:"flush_at"`
+	ExpiresAt  int64  `json:"-" dynamodbav:"expires_at"`
+}
+
+func NewItem(e event.Entry, loggedBy string) Item {
+	return Item{
+		EventID:   e.EventID,
+		Type:      e.Type,
+		StartTime: e.StartTime,
+		LoggedBy:  loggedBy,
+	}
+}
@@ -0,0 +1,26 @@
+package digest
+
+import (
+	"testing"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewItem(t *testing.T) {
+	e := event.Entry{
+		EventID:    "Event#123",
+		ReceiverID: "Receiver#123",
+		UserID:     "User#123",
+		Type:       "Shower",
+		StartTime:  "2026-05-06T15:00:00Z",
+		EndTime:    "2026-05-06T15:20:00Z",
+	}
+
+	assert.Equal(t, Item{
+		EventID:   "Event#123",
+		Type:      "Shower",
+		StartTime: "2026-05-06T15:00:00Z",
+		LoggedBy:  "John Doe",
+	}, NewItem(e, "John Doe"))
+}
@@ -0,0 +1,157 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"go.uber.org/zap"
+)
+
+// expiryGrace keeps an unflushed digest around long enough to survive a stalled flusher
+// without leaving it in the table forever.
+const expiryGrace = 7 * 24 * time.Hour
+
+var ErrNotFound = errors.New("digest not found")
+
+type RepositoryProvider interface {
+	AddItem(uid, rid, email string, item Item, flushAt time.Time) error
+	GetDueDigests(now time.Time) ([]Digest, error)
+	Reschedule(uid, rid string, flushAt time.Time) error
+	TakeDigest(uid, rid string) (Digest, error)
+}
+
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+// AddItem appends an item to the caregiver's open digest, opening one that flushes at
+// flushAt if there is none. An open digest keeps its original flush time so a steady
+// trickle of events cannot hold it back indefinitely.
+func (r *Repository) AddItem(uid, rid, email string, item Item, flushAt time.Time) error {
+	av, err := attributevalue.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.UpdateItem(r.Ctx, &dynamodb.UpdateItemInput{
+		TableName:        aws.String(r.TableName),
+		Key:              key(uid, rid),
+		UpdateExpression: aws.String("SET #items = list_append(if_not_exists(#items, :empty), :item), email = :email, flush_at = if_not_exists(flush_at, :flushAt), expires_at = if_not_exists(expires_at, :expiresAt)"),
+		ExpressionAttributeNames: map[string]string{
+			"#items": "items",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":empty":     &types.AttributeValueMemberL{Value: []types.AttributeValue{}},
+			":item":      &types.AttributeValueMemberL{Value: []types.AttributeValue{av}},
+			":email":     &types.AttributeValueMemberS{Value: email},
+			":flushAt":   &types.AttributeValueMemberS{Value: flushAt.UTC().Format(time.RFC3339)},
+			":expiresAt": &types.AttributeValueMemberN{Value: strconv.FormatInt(flushAt.Add(expiryGrace).Unix(), 10)},
+		},
+	})
+	if err != nil {
+		r.logger.Error("error adding digest item", zap.String(log.UserIDLogKey, uid), zap.String(log.ReceiverIDLogKey, rid), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetDueDigests returns every digest whose flush time has passed. The table only holds open
+// digests, so a filtered scan stays small.
+func (r *Repository) GetDueDigests(now time.Time) ([]Digest, error) {
+	input := &dynamodb.ScanInput{
+		TableName:        aws.String(r.TableName),
+		FilterExpression: aws.String("flush_at <= :now"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": &types.AttributeValueMemberS{Value: now.UTC().Format(time.RFC3339)},
+		},
+	}
+
+	digests := []Digest{}
+	paginator := dynamodb.NewScanPaginator(r.Client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(r.Ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageDigests []Digest
+		err = attributevalue.UnmarshalListOfMaps(page.Items, &pageDigests)
+		if err != nil {
+			return nil, err
+		}
+		digests = append(digests, pageDigests...)
+	}
+	return digests, nil
+}
+
+func (r *Repository) Reschedule(uid, rid string, flushAt time.Time) error {
+	_, err := r.Client.UpdateItem(r.Ctx, &dynamodb.UpdateItemInput{
+		TableName:           aws.String(r.TableName),
+		Key:                 key(uid, rid),
+		UpdateExpression:    aws.String("SET flush_at = :flushAt"),
+		ConditionExpression: aws.String("attribute_exists(user_id)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":flushAt": &types.AttributeValueMemberS{Value: flushAt.UTC().Format(time.RFC3339)},
+		},
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrNotFound
+		}
+		r.logger.Error("error rescheduling digest", zap.String(log.UserIDLogKey, uid), zap.String(log.ReceiverIDLogKey, rid), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// TakeDigest deletes the digest and returns what it held, so items appended after the take
+// start a fresh digest rather than being lost.
+func (r *Repository) TakeDigest(uid, rid string) (Digest, error) {
+	result, err := r.Client.DeleteItem(r.Ctx, &dynamodb.DeleteItemInput{
+		TableName:    aws.String(r.TableName),
+		Key:          key(uid, rid),
+		ReturnValues: types.ReturnValueAllOld,
+	})
+	if err != nil {
+		r.logger.Error("error taking digest", zap.String(log.UserIDLogKey, uid), zap.String(log.ReceiverIDLogKey, rid), zap.Error(err))
+		return Digest{}, err
+	}
+
+	if result.Attributes == nil {
+		return Digest{}, ErrNotFound
+	}
+
+	var d Digest
+	err = attributevalue.UnmarshalMap(result.Attributes, &d)
+	if err != nil {
+		return Digest{}, err
+	}
+	return d, nil
+}
+
+func key(uid, rid string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"user_id":     &types.AttributeValueMemberS{Value: uid},
+		"receiver_id": &types.AttributeValueMemberS{Value: rid},
+	}
+}
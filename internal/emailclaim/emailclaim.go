@@ -0,0 +1,21 @@
+package emailclaim
+
+import (
+	"time"
+)
+
+// Claim reserves an email address for a single user. Claims are written with a
+// conditional put, which makes them the source of truth for email uniqueness.
+type Claim struct {
+	Email     string `json:"email" dynamodbav:"email"`
+	UserID    string `json:"userId" dynamodbav:"user_id"`
+	ClaimedAt string `json:"claimedAt" dynamodbav:"claimed_at"`
+}
+
+func NewClaim(email, uid string) *Claim {
+	return &Claim{
+		Email:     email,
+		UserID:    uid,
+		ClaimedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,17 @@
+package emailclaim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClaim(t *testing.T) {
+	c := NewClaim("good@test.com", "User#123")
+	assert.Equal(t, "good@test.com", c.Email)
+	assert.Equal(t, "User#123", c.UserID)
+
+	_, err := time.Parse(time.RFC3339, c.ClaimedAt)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,108 @@
+package emailclaim
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrEmailTaken    = errors.New("email is already claimed")
+	ErrClaimNotFound = errors.New("email claim not found")
+)
+
+type RepositoryProvider interface {
+	ClaimEmail(email, uid string) error
+	GetClaim(email string) (*Claim, error)
+	ReleaseEmail(email, uid string) error
+}
+
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+func (r *Repository) ClaimEmail(email, uid string) error {
+	item, err := attributevalue.MarshalMap(NewClaim(email, uid))
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.PutItem(r.Ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(r.TableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(email)"),
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrEmailTaken
+		}
+		r.logger.Error("error claiming email", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *Repository) GetClaim(email string) (*Claim, error) {
+	result, err := r.Client.GetItem(r.Ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.TableName),
+		Key:       key(email),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Item == nil {
+		return nil, ErrClaimNotFound
+	}
+
+	var c Claim
+	err = attributevalue.UnmarshalMap(result.Item, &c)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// ReleaseEmail frees an email, but only if it is still claimed by the given user.
+func (r *Repository) ReleaseEmail(email, uid string) error {
+	_, err := r.Client.DeleteItem(r.Ctx, &dynamodb.DeleteItemInput{
+		TableName:           aws.String(r.TableName),
+		Key:                 key(email),
+		ConditionExpression: aws.String("user_id = :uid"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":uid": &types.AttributeValueMemberS{Value: uid},
+		},
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrClaimNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func key(email string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"email": &types.AttributeValueMemberS{Value: email},
+	}
+}
@@ -0,0 +1,195 @@
+package eventbatch
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxBatchWriteItems is the most items DynamoDB accepts in a single BatchWriteItem call.
+	MaxBatchWriteItems = 25
+
+	maxAttempts    = 3
+	initialBackoff = 50 * time.Millisecond
+)
+
+var (
+	// ErrEventExists is returned by CreateEvents for an entry whose event ID is already taken.
+	ErrEventExists = errors.New("event already exists")
+	// ErrEventNotFound is returned by GetEvent when the receiver has no event with the ID.
+	ErrEventNotFound = errors.New("event not found")
+)
+
+type RepositoryProvider interface {
+	AddEvents(entries []*event.Entry) ([]*event.Entry, error)
+	CreateEvents(entries []*event.Entry) map[string]error
+	GetEvent(rid, eid string) (*event.Entry, error)
+}
+
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+// AddEvents writes the entries to the event table in batches and returns the entries
+// that could not be written. A failing batch does not stop the remaining batches, so
+// the returned error only describes what went wrong with the unwritten entries.
+func (r *Repository) AddEvents(entries []*event.Entry) ([]*event.Entry, error) {
+	var unprocessed []*event.Entry
+	var errs []error
+
+	for _, batch := range chunk(entries, MaxBatchWriteItems) {
+		failed, err := r.writeBatch(batch)
+		if err != nil {
+			r.logger.Error("error batch writing events", zap.Int("count", len(batch)), zap.Error(err))
+			errs = append(errs, err)
+		}
+		unprocessed = append(unprocessed, failed...)
+	}
+	return unprocessed, errors.Join(errs...)
+}
+
+// CreateEvents writes each entry only if no event with its ID exists yet, so an entry
+// checked against the table earlier can never overwrite an event written since. It
+// returns the error for every entry that was not written, keyed by event ID.
+func (r *Repository) CreateEvents(entries []*event.Entry) map[string]error {
+	failed := map[string]error{}
+	for _, e := range entries {
+		item, err := attributevalue.MarshalMap(e)
+		if err != nil {
+			failed[e.EventID] = err
+			continue
+		}
+
+		_, err = r.Client.PutItem(r.Ctx, &dynamodb.PutItemInput{
+			TableName:           aws.String(r.TableName),
+			Item:                item,
+			ConditionExpression: aws.String("attribute_not_exists(event_id)"),
+		})
+		if err != nil {
+			var conditionErr *types.ConditionalCheckFailedException
+			if errors.As(err, &conditionErr) {
+				failed[e.EventID] = ErrEventExists
+				continue
+			}
+			r.logger.Error("error creating event", zap.String(event.ParamID, e.EventID), zap.Error(err))
+			failed[e.EventID] = err
+		}
+	}
+	return failed
+}
+
+// GetEvent reads a single event by key, for callers that would otherwise load the receiver's
+// whole history to find one entry.
+func (r *Repository) GetEvent(rid, eid string) (*event.Entry, error) {
+	result, err := r.Client.GetItem(r.Ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.TableName),
+		Key:       key(rid, eid),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Item == nil {
+		return nil, ErrEventNotFound
+	}
+
+	var e event.Entry
+	err = attributevalue.UnmarshalMap(result.Item, &e)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func key(rid, eid string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"receiver_id": &types.AttributeValueMemberS{Value: rid},
+		"event_id":    &types.AttributeValueMemberS{Value: eid},
+	}
+}
+
+func (r *Repository) writeBatch(batch []*event.Entry) ([]*event.Entry, error) {
+	byEventID := make(map[string]*event.Entry, len(batch))
+	requests := make([]types.WriteRequest, 0, len(batch))
+	for _, e := range batch {
+		item, err := attributevalue.MarshalMap(e)
+		if err != nil {
+			return batch, err
+		}
+		byEventID[e.EventID] = e
+		requests = append(requests, types.WriteRequest{PutRequest: &types.PutRequest{Item: item}})
+	}
+
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		output, err := r.Client.BatchWriteItem(r.Ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]types.WriteRequest{
+				r.TableName: requests,
+			},
+		})
+		if err != nil {
+			return entriesFor(requests, byEventID), err
+		}
+
+		requests = output.UnprocessedItems[r.TableName]
+		if len(requests) == 0 {
+			return nil, nil
+		}
+
+		if attempt == maxAttempts {
+			return entriesFor(requests, byEventID), nil
+		}
+
+		r.logger.Info("retrying unprocessed events", zap.Int("count", len(requests)), zap.Int("attempt", attempt))
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func entriesFor(requests []types.WriteRequest, byEventID map[string]*event.Entry) []*event.Entry {
+	entries := make([]*event.Entry, 0, len(requests))
+	for _, req := range requests {
+		if req.PutRequest == nil {
+			continue
+		}
+
+		eid, ok := req.PutRequest.Item["event_id"].(*types.AttributeValueMemberS)
+		if !ok {
+			continue
+		}
+
+		if e, found := byEventID[eid.Value]; found {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+func chunk(entries []*event.Entry, size int) [][]*event.Entry {
+	var batches [][]*event.Entry
+	for start := 0; start < len(entries); start += size {
+		end := min(start+size, len(entries))
+		batches = append(batches, entries[start:end])
+	}
+	return batches
+}
@@ -0,0 +1,74 @@
+package eventbatch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEntries(count int) []*event.Entry {
+	entries := make([]*event.Entry, 0, count)
+	for i := 0; i < count; i++ {
+		entries = append(entries, &event.Entry{EventID: fmt.Sprintf("Event#%d", i)})
+	}
+	return entries
+}
+
+func TestChunk(t *testing.T) {
+	tests := map[string]struct {
+		count         int
+		expectedSizes []int
+	}{
+		"No Entries": {
+			count: 0,
+		},
+		"Single Batch": {
+			count:         MaxBatchWriteItems,
+			expectedSizes: []int{MaxBatchWriteItems},
+		},
+		"Multiple Batches": {
+			count:         MaxBatchWriteItems*2 + 1,
+			expectedSizes: []int{MaxBatchWriteItems, MaxBatchWriteItems, 1},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var sizes []int
+			for _, batch := range chunk(newEntries(tc.count), MaxBatchWriteItems) {
+				sizes = append(sizes, len(batch))
+			}
+			assert.Equal(t, tc.expectedSizes, sizes)
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, map[string]types.AttributeValue{
+		"receiver_id": &types.AttributeValueMemberS{Value: "Receiver#123"},
+		"event_id":    &types.AttributeValueMemberS{Value: "Event#123"},
+	}, key("Receiver#123", "Event#123"))
+}
+
+func TestEntriesFor(t *testing.T) {
+	entries := newEntries(3)
+	byEventID := map[string]*event.Entry{}
+	for _, e := range entries {
+		byEventID[e.EventID] = e
+	}
+
+	requests := []types.WriteRequest{
+		{PutRequest: &types.PutRequest{Item: map[string]types.AttributeValue{
+			"event_id": &types.AttributeValueMemberS{Value: "Event#2"},
+		}}},
+		{PutRequest: &types.PutRequest{Item: map[string]types.AttributeValue{
+			"event_id": &types.AttributeValueMemberS{Value: "Event#Unknown"},
+		}}},
+		{DeleteRequest: &types.DeleteRequest{}},
+	}
+
+	assert.Equal(t, []*event.Entry{entries[2]}, entriesFor(requests, byEventID))
+}
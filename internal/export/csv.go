@@ -0,0 +1,153 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+)
+
+const (
+	FormatCSV = "csv"
+
+	// TimeLayout is how times are written in exports, in the receiver's timezone.
+	TimeLayout = "2006-01-02 15:04"
+)
+
+// column is one flattened data point, such as "weight" on Weight events.
+type column struct {
+	eventType string
+	dataPoint string
+}
+
+func (c column) header() string {
+	return fmt.Sprintf("%s: %s", c.eventType, c.dataPoint)
+}
+
+// WriteCSV writes one row per event, oldest first. Every data point name seen on an event
+// type becomes its own column, so rows only fill the columns for their own type. names maps
+// user IDs to display names; IDs without a name are written as is.
+func WriteCSV(w io.Writer, events []event.Entry, names map[string]string, loc *time.Location) error {
+	sorted := make([]event.Entry, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime < sorted[j].StartTime
+	})
+
+	columns := dataColumns(sorted)
+	header := []string{
+		"Event ID",
+		"Type",
+		fmt.Sprintf("Start Time (%s)", loc),
+		fmt.Sprintf("End Time (%s)", loc),
+		"Logged By",
+		"Note",
+	}
+	for _, c := range columns {
+		header = append(header, c.header())
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, e := range sorted {
+		row := []string{
+			e.EventID,
+			e.Type,
+			FormatTime(e.StartTime, loc),
+			FormatTime(e.EndTime, loc),
+			nameOrID(names, e.UserID),
+			e.Note,
+		}
+
+		values := dataValues(e)
+		for _, c := range columns {
+			if c.eventType != e.Type {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, values[c.dataPoint])
+		}
+
+		for i := range row {
+			row[i] = sanitizeCell(row[i])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// FormatTime renders an RFC3339 timestamp in loc, leaving anything unparseable untouched.
+func FormatTime(timestamp string, loc *time.Location) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return timestamp
+	}
+	return t.In(loc).Format(TimeLayout)
+}
+
+func dataColumns(events []event.Entry) []column {
+	seen := map[column]bool{}
+	columns := []column{}
+	for _, e := range events {
+		for _, dp := range e.Data {
+			c := column{eventType: e.Type, dataPoint: dp.Name}
+			if dp.Name == "" || seen[c] {
+				continue
+			}
+			seen[c] = true
+			columns = append(columns, c)
+		}
+	}
+
+	sort.SliceStable(columns, func(i, j int) bool {
+		if columns[i].eventType != columns[j].eventType {
+			return columns[i].eventType < columns[j].eventType
+		}
+		return columns[i].dataPoint < columns[j].dataPoint
+	})
+	return columns
+}
+
+func nameOrID(names map[string]string, uid string) string {
+	if name := names[uid]; name != "" {
+		return name
+	}
+	return uid
+}
+
+// dataValues joins repeated data points on one event so none are dropped.
+func dataValues(e event.Entry) map[string]string {
+	values := map[string]string{}
+	for _, dp := range e.Data {
+		if existing, ok := values[dp.Name]; ok {
+			values[dp.Name] = existing + "; " + dp.Value
+			continue
+		}
+		values[dp.Name] = dp.Value
+	}
+	return values
+}
+
+// sanitizeCell stops spreadsheet apps from evaluating free text as a formula. Numbers such
+// as -4 are left alone.
+func sanitizeCell(value string) string {
+	if value == "" || !strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return value
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	return "'" + value
+}
@@ -0,0 +1,80 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteCSV(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	assert.Nil(t, err)
+
+	events := []event.Entry{
+		{
+			EventID:   "Event#2",
+			UserID:    "User#456",
+			Type:      "Weight",
+			StartTime: "2025-03-10T14:00:00Z",
+			EndTime:   "2025-03-10T14:00:00Z",
+			Data:      []event.DataPoint{{Name: "weight", Value: "150.5"}},
+			Note:      "=HYPERLINK(\"http://evil\")",
+		},
+		{
+			EventID:   "Event#1",
+			UserID:    "User#123",
+			Type:      "Medication",
+			StartTime: "2025-03-10T13:00:00Z",
+			EndTime:   "2025-03-10T13:05:00Z",
+			Data: []event.DataPoint{
+				{Name: "name", Value: "Lisinopril"},
+				{Name: "dose", Value: "10mg"},
+				{Name: "dose", Value: "5mg"},
+			},
+		},
+		{
+			EventID:   "Event#3",
+			UserID:    "User#Unknown",
+			Type:      "Temperature",
+			StartTime: "2025-03-10T15:00:00Z",
+			EndTime:   "not a time",
+			Data:      []event.DataPoint{{Name: "change", Value: "-0.5"}},
+			Note:      "Felt warm, checked twice",
+		},
+	}
+	names := map[string]string{
+		"User#123": "John Doe",
+		"User#456": "Jane Smith",
+	}
+
+	var buf bytes.Buffer
+	err = WriteCSV(&buf, events, names, loc)
+	assert.Nil(t, err)
+
+	expected := "Event ID,Type,Start Time (America/Chicago),End Time (America/Chicago),Logged By,Note,Medication: dose,Medication: name,Temperature: change,Weight: weight\n" +
+		"Event#1,Medication,2025-03-10 08:00,2025-03-10 08:05,John Doe,,10mg; 5mg,Lisinopril,,\n" +
+		"Event#2,Weight,2025-03-10 09:00,2025-03-10 09:00,Jane Smith,\"'=HYPERLINK(\"\"http://evil\"\")\",,,,150.5\n" +
+		"Event#3,Temperature,2025-03-10 10:00,not a time,User#Unknown,\"Felt warm, checked twice\",,,-0.5,\n"
+	assert.Equal(t, expected, buf.String())
+	assert.Equal(t, "Event#2", events[0].EventID)
+}
+
+func TestWriteCSVNoEvents(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteCSV(&buf, nil, nil, time.UTC)
+	assert.Nil(t, err)
+	assert.Equal(t, "Event ID,Type,Start Time (UTC),End Time (UTC),Logged By,Note\n", buf.String())
+}
+
+func TestSanitizeCell(t *testing.T) {
+	assert.Equal(t, "", sanitizeCell(""))
+	assert.Equal(t, "Lisinopril", sanitizeCell("Lisinopril"))
+	assert.Equal(t, "-4", sanitizeCell("-4"))
+	assert.Equal(t, "+1.5", sanitizeCell("+1.5"))
+	assert.Equal(t, "'=1+1", sanitizeCell("=1+1"))
+	assert.Equal(t, "'@SUM(A1)", sanitizeCell("@SUM(A1)"))
+	assert.Equal(t, "'-bad", sanitizeCell("-bad"))
+}
@@ -0,0 +1,238 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// US Letter, in points.
+const (
+	pageWidth  = 612.0
+	pageHeight = 792.0
+)
+
+type font string
+
+// Only the standard Helvetica faces are used. Every PDF reader ships them, so nothing has
+// to be embedded.
+const (
+	fontRegular font = "F1"
+	fontBold    font = "F2"
+)
+
+// helveticaWidths are the advance widths of ASCII 32-126 in thousandths of an em.
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// boldWidthFactor approximates Helvetica-Bold from the regular metrics. It errs wide so
+// fitted text never overruns its column.
+const boldWidthFactor = 1.1
+
+// textReplacements maps common typographic characters outside Latin-1 onto ones the
+// standard fonts' WinAnsi encoding can show.
+var textReplacements = map[rune]string{
+	'‘': "'", '’': "'", '“': "\"", '”': "\"", '–': "-", '—': "-", '…': "...", '•': "*",
+}
+
+type pdfPage struct {
+	content bytes.Buffer
+}
+
+func (p *pdfPage) text(x, y, size float64, f font, s string) {
+	if s == "" {
+		return
+	}
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n", f, num(size), num(x), num(y), encodeText(s))
+}
+
+func (p *pdfPage) line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n", num(width), num(x1), num(y1), num(x2), num(y2))
+}
+
+// rect fills a rectangle in a shade of grey, 0 being black and 1 white.
+func (p *pdfPage) rect(x, y, w, h, gray float64) {
+	fmt.Fprintf(&p.content, "q %s g %s %s %s %s re f Q\n", num(gray), num(x), num(y), num(w), num(h))
+}
+
+func (p *pdfPage) polyline(points [][2]float64, width float64) {
+	if len(points) < 2 {
+		return
+	}
+	fmt.Fprintf(&p.content, "%s w %s %s m", num(width), num(points[0][0]), num(points[0][1]))
+	for _, pt := range points[1:] {
+		fmt.Fprintf(&p.content, " %s %s l", num(pt[0]), num(pt[1]))
+	}
+	p.content.WriteString(" S\n")
+}
+
+// pdfDocument is a minimal PDF 1.4 writer. Content streams are left uncompressed and all text
+// is escaped to ASCII, so the file is plain text; the API gzips it on the way out.
+type pdfDocument struct {
+	title   string
+	created time.Time
+	pages   []*pdfPage
+}
+
+func (d *pdfDocument) addPage() *pdfPage {
+	p := &pdfPage{}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+func (d *pdfDocument) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Fixed objects come first so pages can refer to them by number.
+	const (
+		catalogObj = 1
+		pagesObj   = 2
+		regularObj = 3
+		boldObj    = 4
+		infoObj    = 5
+		firstPage  = 6
+	)
+
+	buf.WriteString("%PDF-1.4\n")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	object(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (CareGiver) /CreationDate (D:%s) >>", encodeText(d.title), d.created.UTC().Format("20060102150405Z")))
+
+	for i, p := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /%s %d 0 R /%s %d 0 R >> >> /Contents %d 0 R >>",
+			pagesObj, num(pageWidth), num(pageHeight), fontRegular, regularObj, fontBold, boldObj, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.content.Len(), p.content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, catalogObj, infoObj, xref)
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+// encodeText escapes s for a PDF string literal. Latin-1 characters are written as octal
+// escapes, which WinAnsi shares; anything else the standard fonts cannot show becomes "?".
+func encodeText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if replacement, ok := textReplacements[r]; ok {
+			b.WriteString(replacement)
+			continue
+		}
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r >= 32 && r <= 126:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		case r == '\t':
+			b.WriteRune(' ')
+		default:
+			b.WriteRune('?')
+		}
+	}
+	return b.String()
+}
+
+func textWidth(s string, size float64, f font) float64 {
+	units := 0
+	for _, r := range s {
+		if r >= 32 && r <= 126 {
+			units += helveticaWidths[r-32]
+			continue
+		}
+		units += 556
+	}
+
+	width := float64(units) * size / 1000
+	if f == fontBold {
+		width *= boldWidthFactor
+	}
+	return width
+}
+
+// fitText shortens s with an ellipsis until it fits in width.
+func fitText(s string, width, size float64, f font) string {
+	if textWidth(s, size, f) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "..."
+		if textWidth(candidate, size, f) <= width {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// wrapText breaks s into lines no wider than width, splitting on spaces and hard-breaking
+// words that are too long for a line of their own.
+func wrapText(s string, width, size float64, f font) []string {
+	lines := []string{}
+	for _, paragraph := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		current := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if current != "" {
+				candidate = current + " " + word
+			}
+			if textWidth(candidate, size, f) <= width {
+				current = candidate
+				continue
+			}
+
+			if current != "" {
+				lines = append(lines, current)
+			}
+			for textWidth(word, size, f) > width {
+				cut := len([]rune(fitText(word, width, size, f))) - len("...")
+				if cut < 1 {
+					cut = 1
+				}
+				lines = append(lines, string([]rune(word)[:cut]))
+				word = string([]rune(word)[cut:])
+			}
+			current = word
+		}
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// num formats a coordinate to a hundredth of a point, without trailing zeros.
+func num(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,89 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertWellFormed checks the cross reference table points at each object and the trailer
+// points at the table.
+func assertWellFormed(t *testing.T, pdf []byte) {
+	t.Helper()
+	s := string(pdf)
+	assert.True(t, strings.HasPrefix(s, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(s, "%%EOF\n"))
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(s)
+	assert.Len(t, startxref, 2)
+	xref, err := strconv.Atoi(startxref[1])
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(s[xref:], "xref\n"))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(s[xref:], -1)
+	assert.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(entry[1])
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(s[offset:], fmt.Sprintf("%d 0 obj\n", i+1)), "object %d", i+1)
+	}
+
+	for _, stream := range regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)endstream`).FindAllStringSubmatch(s, -1) {
+		length, err := strconv.Atoi(stream[1])
+		assert.Nil(t, err)
+		assert.Equal(t, length, len(stream[2]))
+	}
+}
+
+func TestPDFDocument(t *testing.T) {
+	doc := &pdfDocument{title: "Report (draft)", created: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	first := doc.addPage()
+	first.text(50, 700, 12, fontBold, "Hello (world)")
+	first.line(50, 690, 562, 690, 1)
+	first.rect(50, 600, 100, 20, 0.9)
+	first.polyline([][2]float64{{50, 500}, {100, 550}, {150, 525.5}}, 1)
+	doc.addPage().text(50, 700, 12, fontRegular, "Second")
+
+	var buf bytes.Buffer
+	_, err := doc.WriteTo(&buf)
+	assert.Nil(t, err)
+	assertWellFormed(t, buf.Bytes())
+
+	s := buf.String()
+	assert.Contains(t, s, "/Count 2")
+	assert.Contains(t, s, "/Title (Report \\(draft\\))")
+	assert.Contains(t, s, "/CreationDate (D:20250310090000Z)")
+	assert.Contains(t, s, "BT /F2 12 Tf 50 700 Td (Hello \\(world\\)) Tj ET")
+	assert.Contains(t, s, "1 w 50 500 m 100 550 l 150 525.5 l S")
+}
+
+func TestEncodeText(t *testing.T) {
+	assert.Equal(t, "plain", encodeText("plain"))
+	assert.Equal(t, "a\\(b\\)\\\\c", encodeText("a(b)\\c"))
+	assert.Equal(t, "Jos\\351", encodeText("José"))
+	assert.Equal(t, "it's - ok...", encodeText("it’s – ok…"))
+	assert.Equal(t, "pill ?", encodeText("pill 💊"))
+}
+
+func TestFitText(t *testing.T) {
+	assert.Equal(t, "short", fitText("short", 100, 10, fontRegular))
+
+	fitted := fitText("a very long caregiver name that will not fit", 60, 10, fontRegular)
+	assert.True(t, strings.HasSuffix(fitted, "..."))
+	assert.LessOrEqual(t, textWidth(fitted, 10, fontRegular), 60.0)
+}
+
+func TestWrapText(t *testing.T) {
+	lines := wrapText("Ate most of lunch and took a short walk\nSlept well", 100, 10, fontRegular)
+	assert.Equal(t, []string{"Ate most of lunch and", "took a short walk", "Slept well"}, lines)
+
+	for _, line := range wrapText("Supercalifragilisticexpialidocious", 60, 10, fontRegular) {
+		assert.LessOrEqual(t, textWidth(line, 10, fontRegular), 60.0)
+	}
+}
@@ -0,0 +1,357 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/care-giver-app/care-giver-api/internal/summary"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+)
+
+const (
+	FormatPDF = "pdf"
+
+	reportTitle = "Care Report"
+	dateLayout  = "Jan 2, 2006"
+	stampLayout = "Jan 2, 2006 15:04"
+
+	margin       = 50.0
+	contentWidth = pageWidth - 2*margin
+	bodySize     = 9.0
+	rowHeight    = 14.0
+	chartHeight  = 110.0
+)
+
+// Report is what a printed care report is drawn from. From and To bound the events shown and
+// are zero when the whole history was requested.
+type Report struct {
+	ReceiverName string
+	From         time.Time
+	To           time.Time
+	GeneratedAt  time.Time
+	Location     *time.Location
+	Events       []event.Entry
+	Names        map[string]string
+}
+
+// WriteReport renders r as a PDF: a header describing the receiver and period, an overview,
+// then a section per event type with a chart for each numeric data point and a table of the
+// events, and finally every caregiver note.
+func WriteReport(w io.Writer, r Report) error {
+	if r.Location == nil {
+		r.Location = time.UTC
+	}
+
+	events := make([]event.Entry, len(r.Events))
+	copy(events, r.Events)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartTime < events[j].StartTime
+	})
+
+	l := &layout{doc: &pdfDocument{title: fmt.Sprintf("%s - %s", reportTitle, r.ReceiverName), created: r.GeneratedAt}}
+	l.newPage()
+
+	l.header(r, events)
+	if len(events) == 0 {
+		l.paragraph("No events were recorded in this period.", bodySize, fontRegular)
+	} else {
+		l.overview(events)
+		for _, eventType := range eventTypes(events) {
+			l.typeSection(r, eventType, events)
+		}
+		l.notes(r, events)
+	}
+
+	l.footers(r)
+	_, err := l.doc.WriteTo(w)
+	return err
+}
+
+// layout flows content down the page, starting a new one whenever the next block won't fit.
+type layout struct {
+	doc  *pdfDocument
+	page *pdfPage
+	y    float64
+}
+
+func (l *layout) newPage() {
+	l.page = l.doc.addPage()
+	l.y = pageHeight - margin
+}
+
+func (l *layout) ensure(height float64) {
+	if l.y-height < margin {
+		l.newPage()
+	}
+}
+
+func (l *layout) heading(s string, size float64) {
+	l.ensure(size + rowHeight*2)
+	l.y -= size
+	l.page.text(margin, l.y, size, fontBold, fitText(s, contentWidth, size, fontBold))
+	l.y -= size * 0.6
+}
+
+func (l *layout) paragraph(s string, size float64, f font) {
+	for _, line := range wrapText(s, contentWidth, size, f) {
+		l.ensure(size * 1.4)
+		l.y -= size * 1.4
+		l.page.text(margin, l.y, size, f, line)
+	}
+}
+
+func (l *layout) header(r Report, events []event.Entry) {
+	l.heading(reportTitle, 20)
+	l.paragraph(r.ReceiverName, 14, fontBold)
+	l.y -= 4
+
+	period := "All recorded events"
+	if !r.From.IsZero() && !r.To.IsZero() {
+		period = fmt.Sprintf("%s - %s", r.From.In(r.Location).Format(dateLayout), r.To.In(r.Location).Format(dateLayout))
+	}
+	l.paragraph(fmt.Sprintf("Period: %s (times in %s)", period, r.Location), bodySize+1, fontRegular)
+	l.paragraph(fmt.Sprintf("Generated: %s", r.GeneratedAt.In(r.Location).Format(stampLayout)), bodySize+1, fontRegular)
+
+	if caregivers := caregiversOf(r, events); len(caregivers) > 0 {
+		l.paragraph(fmt.Sprintf("Caregivers: %s", strings.Join(caregivers, ", ")), bodySize+1, fontRegular)
+	}
+
+	l.y -= 8
+	l.page.line(margin, l.y, pageWidth-margin, l.y, 1)
+	l.y -= 10
+}
+
+func (l *layout) overview(events []event.Entry) {
+	l.heading("Overview", 14)
+
+	rows := [][]string{}
+	for _, ts := range summary.Aggregate(events) {
+		duration := ""
+		if ts.TotalDurationMinutes > 0 {
+			duration = fmt.Sprintf("%s min", formatNumber(ts.AverageDurationMinutes))
+		}
+		rows = append(rows, []string{ts.Type, strconv.Itoa(ts.Count), duration})
+	}
+	l.table([]string{"Type", "Entries", "Average Duration"}, []float64{0.5, 0.2, 0.3}, rows)
+}
+
+func (l *layout) typeSection(r Report, eventType string, events []event.Entry) {
+	ofType := []event.Entry{}
+	for _, e := range events {
+		if e.Type == eventType {
+			ofType = append(ofType, e)
+		}
+	}
+
+	l.y -= 6
+	l.heading(fmt.Sprintf("%s (%d)", eventType, len(ofType)), 14)
+
+	dataPoints := []string{}
+	for _, c := range dataColumns(ofType) {
+		dataPoints = append(dataPoints, c.dataPoint)
+	}
+
+	for _, dataPoint := range dataPoints {
+		if points := numericSeries(ofType, dataPoint); len(points) >= 2 {
+			l.chart(r, dataPoint, points)
+		}
+	}
+
+	header := append([]string{"Time", "Logged By"}, dataPoints...)
+	widths := []float64{0.22, 0.22}
+	for range dataPoints {
+		widths = append(widths, 0.56/float64(len(dataPoints)))
+	}
+	if len(dataPoints) == 0 {
+		widths[1] = 0.78
+	}
+
+	rows := [][]string{}
+	for _, e := range ofType {
+		values := dataValues(e)
+		row := []string{FormatTime(e.StartTime, r.Location), loggedBy(r, e.UserID)}
+		for _, dataPoint := range dataPoints {
+			row = append(row, values[dataPoint])
+		}
+		rows = append(rows, row)
+	}
+	l.table(header, widths, rows)
+}
+
+// table draws rows under a shaded header, repeating the header on each new page. widths are
+// fractions of the content width.
+func (l *layout) table(header []string, widths []float64, rows [][]string) {
+	drawRow := func(cells []string, f font) {
+		x := margin
+		for i, cell := range cells {
+			width := widths[i] * contentWidth
+			l.page.text(x+3, l.y+4, bodySize, f, fitText(cell, width-6, bodySize, f))
+			x += width
+		}
+	}
+	drawHeader := func() {
+		l.y -= rowHeight
+		l.page.rect(margin, l.y, contentWidth, rowHeight, 0.88)
+		drawRow(header, fontBold)
+	}
+
+	l.ensure(rowHeight * 2)
+	drawHeader()
+	for _, row := range rows {
+		if l.y-rowHeight < margin {
+			l.newPage()
+			drawHeader()
+		}
+		l.y -= rowHeight
+		drawRow(row, fontRegular)
+		l.page.line(margin, l.y, pageWidth-margin, l.y, 0.25)
+	}
+	l.y -= 10
+}
+
+type point struct {
+	at    time.Time
+	value float64
+}
+
+// chart plots one numeric data point over time as a line between its minimum and maximum.
+func (l *layout) chart(r Report, dataPoint string, points []point) {
+	l.ensure(chartHeight + 40)
+	l.y -= bodySize + 4
+	l.page.text(margin, l.y, bodySize+1, fontBold, fmt.Sprintf("%s over time", dataPoint))
+
+	low, high := points[0].value, points[0].value
+	for _, p := range points {
+		low = math.Min(low, p.value)
+		high = math.Max(high, p.value)
+	}
+	if low == high {
+		low, high = low-1, high+1
+	}
+	first, last := points[0].at, points[len(points)-1].at
+	span := last.Sub(first).Seconds()
+
+	axisLeft := margin + 40
+	width := contentWidth - 40
+	top := l.y - 8
+	bottom := top - chartHeight
+
+	l.page.line(axisLeft, bottom, axisLeft, top, 0.5)
+	l.page.line(axisLeft, bottom, axisLeft+width, bottom, 0.5)
+	l.page.text(margin, top-bodySize, bodySize-1, fontRegular, fitText(formatNumber(high), 36, bodySize-1, fontRegular))
+	l.page.text(margin, bottom, bodySize-1, fontRegular, fitText(formatNumber(low), 36, bodySize-1, fontRegular))
+
+	coordinates := [][2]float64{}
+	for i, p := range points {
+		x := axisLeft + width/2
+		switch {
+		case span > 0:
+			x = axisLeft + width*p.at.Sub(first).Seconds()/span
+		case len(points) > 1:
+			x = axisLeft + width*float64(i)/float64(len(points)-1)
+		}
+		y := bottom + chartHeight*(p.value-low)/(high-low)
+		coordinates = append(coordinates, [2]float64{x, y})
+		l.page.rect(x-1.5, y-1.5, 3, 3, 0)
+	}
+	l.page.polyline(coordinates, 1)
+
+	firstLabel := first.In(r.Location).Format(dateLayout)
+	lastLabel := last.In(r.Location).Format(dateLayout)
+	l.page.text(axisLeft, bottom-bodySize-2, bodySize-1, fontRegular, firstLabel)
+	l.page.text(axisLeft+width-textWidth(lastLabel, bodySize-1, fontRegular), bottom-bodySize-2, bodySize-1, fontRegular, lastLabel)
+
+	l.y = bottom - bodySize - 12
+}
+
+func (l *layout) notes(r Report, events []event.Entry) {
+	withNotes := []event.Entry{}
+	for _, e := range events {
+		if strings.TrimSpace(e.Note) != "" {
+			withNotes = append(withNotes, e)
+		}
+	}
+	if len(withNotes) == 0 {
+		return
+	}
+
+	l.y -= 6
+	l.heading("Caregiver Notes", 14)
+	for _, e := range withNotes {
+		l.ensure(rowHeight * 2)
+		l.paragraph(fmt.Sprintf("%s - %s - %s", FormatTime(e.StartTime, r.Location), e.Type, loggedBy(r, e.UserID)), bodySize, fontBold)
+		l.paragraph(e.Note, bodySize, fontRegular)
+		l.y -= 6
+	}
+}
+
+// footers number the pages once the total is known.
+func (l *layout) footers(r Report) {
+	for i, p := range l.doc.pages {
+		label := fmt.Sprintf("Page %d of %d", i+1, len(l.doc.pages))
+		p.text(pageWidth-margin-textWidth(label, bodySize-1, fontRegular), margin/2, bodySize-1, fontRegular, label)
+		p.text(margin, margin/2, bodySize-1, fontRegular, fitText(fmt.Sprintf("%s - %s", reportTitle, r.ReceiverName), contentWidth/2, bodySize-1, fontRegular))
+	}
+}
+
+func eventTypes(events []event.Entry) []string {
+	seen := map[string]bool{}
+	types := []string{}
+	for _, e := range events {
+		if !seen[e.Type] {
+			seen[e.Type] = true
+			types = append(types, e.Type)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// numericSeries collects the values of dataPoint that parse as numbers, oldest first.
+func numericSeries(events []event.Entry, dataPoint string) []point {
+	points := []point{}
+	for _, e := range events {
+		at, err := time.Parse(time.RFC3339, e.StartTime)
+		if err != nil {
+			continue
+		}
+		for _, dp := range e.Data {
+			if dp.Name != dataPoint {
+				continue
+			}
+			value, err := strconv.ParseFloat(strings.TrimSpace(dp.Value), 64)
+			if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+			points = append(points, point{at: at, value: value})
+		}
+	}
+	return points
+}
+
+func caregiversOf(r Report, events []event.Entry) []string {
+	seen := map[string]bool{}
+	caregivers := []string{}
+	for _, e := range events {
+		if e.UserID == "" || seen[e.UserID] {
+			continue
+		}
+		seen[e.UserID] = true
+		caregivers = append(caregivers, loggedBy(r, e.UserID))
+	}
+	sort.Strings(caregivers)
+	return caregivers
+}
+
+func loggedBy(r Report, uid string) string {
+	return nameOrID(r.Names, uid)
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteReport(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	assert.Nil(t, err)
+
+	tests := map[string]struct {
+		report        Report
+		expectedPages int
+		expectedText  []string
+		expectedChart bool
+	}{
+		"Happy Path - Tables Charts And Notes": {
+			report: Report{
+				ReceiverName: "Mary Jones",
+				From:         time.Date(2025, 3, 1, 6, 0, 0, 0, time.UTC),
+				To:           time.Date(2025, 3, 31, 5, 0, 0, 0, time.UTC),
+				Location:     loc,
+				Events: []event.Entry{
+					{EventID: "Event#2", UserID: "User#456", Type: "Weight", StartTime: "2025-03-12T14:00:00Z", EndTime: "2025-03-12T14:00:00Z", Data: []event.DataPoint{{Name: "weight", Value: "151"}}},
+					{EventID: "Event#1", UserID: "User#456", Type: "Weight", StartTime: "2025-03-10T14:00:00Z", EndTime: "2025-03-10T14:00:00Z", Data: []event.DataPoint{{Name: "weight", Value: "150.5"}}, Note: "After breakfast (fasting)"},
+					{EventID: "Event#3", UserID: "User#Gone", Type: "Shower", StartTime: "2025-03-11T13:00:00Z", EndTime: "2025-03-11T13:30:00Z"},
+				},
+				Names: map[string]string{"User#456": "Jane Smith"},
+			},
+			expectedPages: 1,
+			expectedText: []string{
+				"(Care Report)",
+				"(Mary Jones)",
+				"(Period: Mar 1, 2025 - Mar 31, 2025 \\(times in America/Chicago\\))",
+				"(Caregivers: Jane Smith, User#Gone)",
+				"(Overview)",
+				"(Shower \\(1\\))",
+				"(Weight \\(2\\))",
+				"(weight over time)",
+				"(2025-03-10 09:00)",
+				"(After breakfast \\(fasting\\))",
+				"(Page 1 of 1)",
+			},
+			expectedChart: true,
+		},
+		"Happy Path - No Events": {
+			report: Report{
+				ReceiverName: "Mary Jones",
+			},
+			expectedPages: 1,
+			expectedText: []string{
+				"(Period: All recorded events \\(times in UTC\\))",
+				"(No events were recorded in this period.)",
+			},
+		},
+		"Happy Path - Long History Spans Pages": {
+			report: Report{
+				ReceiverName: "Mary Jones",
+				Location:     time.UTC,
+				Events:       manyEvents(120),
+			},
+			expectedPages: 4,
+			expectedText: []string{
+				"(Page 4 of 4)",
+			},
+			expectedChart: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.report.GeneratedAt = time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+
+			var buf bytes.Buffer
+			err := WriteReport(&buf, tc.report)
+			assert.Nil(t, err)
+			assertWellFormed(t, buf.Bytes())
+
+			s := buf.String()
+			assert.Contains(t, s, fmt.Sprintf("/Count %d", tc.expectedPages))
+			for _, text := range tc.expectedText {
+				assert.Contains(t, s, text)
+			}
+			assert.Equal(t, tc.expectedChart, strings.Contains(s, " over time)"))
+		})
+	}
+}
+
+func manyEvents(n int) []event.Entry {
+	start := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	events := []event.Entry{}
+	for i := 0; i < n; i++ {
+		at := start.Add(time.Duration(i) * 12 * time.Hour).Format(time.RFC3339)
+		events = append(events, event.Entry{
+			EventID:   fmt.Sprintf("Event#%d", i),
+			UserID:    "User#123",
+			Type:      "Temperature",
+			StartTime: at,
+			EndTime:   at,
+			Data:      []event.DataPoint{{Name: "temperature", Value: fmt.Sprintf("%.1f", 97.5+float64(i%5)*0.4)}},
+		})
+	}
+	return events
+}
@@ -0,0 +1,100 @@
+package feedback
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ParamID  = "feedbackId"
+	DBPrefix = "Feedback"
+)
+
+type Status string
+
+const (
+	StatusNew      Status = "new"
+	StatusTriaged  Status = "triaged"
+	StatusResolved Status = "resolved"
+)
+
+// Feedback is a single submission from the app. UserID and UserEmail are empty when the
+// sender could not be identified.
+type Feedback struct {
+	FeedbackID string `json:"feedbackId" dynamodbav:"feedback_id"`
+	Status     Status `json:"status" dynamodbav:"status"`
+	Message    string `json:"message" dynamodbav:"message"`
+	Category   string `json:"category,omitempty" dynamodbav:"category,omitempty"`
+	Rating     int    `json:"rating,omitempty" dynamodbav:"rating,omitempty"`
+	AppVersion string `json:"appVersion,omitempty" dynamodbav:"app_version,omitempty"`
+	Platform   string `json:"platform,omitempty" dynamodbav:"platform,omitempty"`
+	UserID     string `json:"userId,omitempty" dynamodbav:"user_id,omitempty"`
+	UserEmail  string `json:"userEmail,omitempty" dynamodbav:"user_email,omitempty"`
+	CreatedAt  string `json:"createdAt" dynamodbav:"created_at"`
+	UpdatedAt  string `json:"updatedAt" dynamodbav:"updated_at"`
+	UpdatedBy  string `json:"updatedBy,omitempty" dynamodbav:"updated_by,omitempty"`
+}
+
+func NewFeedback(message, category string, rating int, appVersion, platform, userID, userEmail string) *Feedback {
+	now := time.Now().UTC().Format(time.RFC3339)
+	return &Feedback{
+		FeedbackID: fmt.Sprintf("%s#%s", DBPrefix, uuid.NewString()),
+		Status:     StatusNew,
+		Message:    message,
+		Category:   category,
+		Rating:     rating,
+		AppVersion: appVersion,
+		Platform:   platform,
+		UserID:     userID,
+		UserEmail:  userEmail,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusNew, StatusTriaged, StatusResolved:
+		return true
+	}
+	return false
+}
+
+// Filter narrows a feedback listing. Zero values match everything; Since is inclusive and
+// Until is exclusive.
+type Filter struct {
+	Status   Status
+	Category string
+	Platform string
+	Since    time.Time
+	Until    time.Time
+}
+
+func (f Filter) Matches(fb Feedback) bool {
+	if f.Status != "" && fb.Status != f.Status {
+		return false
+	}
+	if f.Category != "" && fb.Category != f.Category {
+		return false
+	}
+	if f.Platform != "" && fb.Platform != f.Platform {
+		return false
+	}
+
+	if f.Since.IsZero() && f.Until.IsZero() {
+		return true
+	}
+	createdAt, err := time.Parse(time.RFC3339, fb.CreatedAt)
+	if err != nil {
+		return false
+	}
+	if !f.Since.IsZero() && createdAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !createdAt.Before(f.Until) {
+		return false
+	}
+	return true
+}
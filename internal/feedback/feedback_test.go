@@ -0,0 +1,86 @@
+package feedback
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFeedback(t *testing.T) {
+	fb := NewFeedback("Love the calendar", "praise", 5, "1.4.0", "ios", "User#123", "valid@example.com")
+	assert.True(t, strings.HasPrefix(fb.FeedbackID, DBPrefix+"#"))
+	assert.Equal(t, StatusNew, fb.Status)
+	assert.Equal(t, fb.CreatedAt, fb.UpdatedAt)
+	assert.Equal(t, "User#123", fb.UserID)
+}
+
+func TestStatusValid(t *testing.T) {
+	assert.True(t, StatusNew.Valid())
+	assert.True(t, StatusTriaged.Valid())
+	assert.True(t, StatusResolved.Valid())
+	assert.False(t, Status("closed").Valid())
+}
+
+func TestFilterMatches(t *testing.T) {
+	fb := Feedback{
+		Status:    StatusTriaged,
+		Category:  "bug",
+		Platform:  "android",
+		CreatedAt: "2025-03-10T12:00:00Z",
+	}
+
+	tests := map[string]struct {
+		filter   Filter
+		expected bool
+	}{
+		"Empty Filter": {
+			filter:   Filter{},
+			expected: true,
+		},
+		"Matching Fields": {
+			filter:   Filter{Status: StatusTriaged, Category: "bug", Platform: "android"},
+			expected: true,
+		},
+		"Different Status": {
+			filter:   Filter{Status: StatusNew},
+			expected: false,
+		},
+		"Different Category": {
+			filter:   Filter{Category: "idea"},
+			expected: false,
+		},
+		"Inside Range": {
+			filter:   Filter{Since: time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC), Until: time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)},
+			expected: true,
+		},
+		"Until Is Exclusive": {
+			filter:   Filter{Until: time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)},
+			expected: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.filter.Matches(fb))
+		})
+	}
+}
+
+func TestFilterExpression(t *testing.T) {
+	expression, names, values := filterExpression(Filter{})
+	assert.Equal(t, "", expression)
+	assert.Nil(t, names)
+	assert.Nil(t, values)
+
+	expression, names, values = filterExpression(Filter{
+		Status: StatusNew,
+		Since:  time.Date(2025, 3, 10, 7, 0, 0, 0, time.FixedZone("CDT", -5*60*60)),
+	})
+	assert.Equal(t, "#status = :status AND #created_at >= :since", expression)
+	assert.Equal(t, map[string]string{"#status": "status", "#created_at": "created_at"}, names)
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "new"}, values[":status"])
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "2025-03-10T12:00:00Z"}, values[":since"])
+}
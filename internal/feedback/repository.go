@@ -0,0 +1,149 @@
+package feedback
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+var ErrNotFound = errors.New("feedback not found")
+
+type RepositoryProvider interface {
+	AddFeedback(fb *Feedback) error
+	ListFeedback(filter Filter) ([]Feedback, error)
+	UpdateStatus(feedbackID string, status Status, updatedBy string) error
+}
+
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+func (r *Repository) AddFeedback(fb *Feedback) error {
+	item, err := attributevalue.MarshalMap(fb)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.PutItem(r.Ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.TableName),
+		Item:      item,
+	})
+	if err != nil {
+		r.logger.Error("error adding feedback", zap.String(ParamID, fb.FeedbackID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListFeedback scans the table with the filter applied server side. Feedback volume is low
+// and the listing is only used by admins, so a scan is cheaper than maintaining indexes.
+func (r *Repository) ListFeedback(filter Filter) ([]Feedback, error) {
+	input := &dynamodb.ScanInput{
+		TableName: aws.String(r.TableName),
+	}
+	expression, names, values := filterExpression(filter)
+	if expression != "" {
+		input.FilterExpression = aws.String(expression)
+		input.ExpressionAttributeNames = names
+		input.ExpressionAttributeValues = values
+	}
+
+	items := []Feedback{}
+	paginator := dynamodb.NewScanPaginator(r.Client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(r.Ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageItems []Feedback
+		err = attributevalue.UnmarshalListOfMaps(page.Items, &pageItems)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+	}
+	return items, nil
+}
+
+func (r *Repository) UpdateStatus(feedbackID string, status Status, updatedBy string) error {
+	_, err := r.Client.UpdateItem(r.Ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.TableName),
+		Key: map[string]types.AttributeValue{
+			"feedback_id": &types.AttributeValueMemberS{Value: feedbackID},
+		},
+		UpdateExpression:    aws.String("SET #status = :status, updated_at = :updatedAt, updated_by = :updatedBy"),
+		ConditionExpression: aws.String("attribute_exists(feedback_id)"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":status":    &types.AttributeValueMemberS{Value: string(status)},
+			":updatedAt": &types.AttributeValueMemberS{Value: time.Now().UTC().Format(time.RFC3339)},
+			":updatedBy": &types.AttributeValueMemberS{Value: updatedBy},
+		},
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrNotFound
+		}
+		r.logger.Error("error updating feedback status", zap.String(ParamID, feedbackID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// filterExpression translates a Filter into a scan filter. Status is a DynamoDB reserved
+// word, so every attribute goes through a name placeholder.
+func filterExpression(filter Filter) (string, map[string]string, map[string]types.AttributeValue) {
+	conditions := []string{}
+	names := map[string]string{}
+	values := map[string]types.AttributeValue{}
+
+	add := func(attribute, operator, placeholder, value string) {
+		conditions = append(conditions, "#"+attribute+" "+operator+" "+placeholder)
+		names["#"+attribute] = attribute
+		values[placeholder] = &types.AttributeValueMemberS{Value: value}
+	}
+
+	if filter.Status != "" {
+		add("status", "=", ":status", string(filter.Status))
+	}
+	if filter.Category != "" {
+		add("category", "=", ":category", filter.Category)
+	}
+	if filter.Platform != "" {
+		add("platform", "=", ":platform", filter.Platform)
+	}
+	if !filter.Since.IsZero() {
+		add("created_at", ">=", ":since", filter.Since.UTC().Format(time.RFC3339))
+	}
+	if !filter.Until.IsZero() {
+		add("created_at", "<", ":until", filter.Until.UTC().Format(time.RFC3339))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil, nil
+	}
+	return strings.Join(conditions, " AND "), names, values
+}
@@ -0,0 +1,320 @@
+package fhir
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
+	"github.com/google/uuid"
+)
+
+const (
+	Format      = "fhir"
+	ContentType = "application/fhir+json"
+
+	LOINCSystem       = "http://loinc.org"
+	UCUMSystem        = "http://unitsofmeasure.org"
+	CategorySystem    = "http://terminology.hl7.org/CodeSystem/observation-category"
+	CategoryVitalSign = "vital-signs"
+
+	// Receivers and events are identified by their CareGiver IDs under these systems.
+	ReceiverIdentifierSystem = "urn:care-giver-app:receiver-id"
+	EventIdentifierSystem    = "urn:care-giver-app:event-id"
+)
+
+// Mapping turns one data point of an event config type into a LOINC coded Observation, for
+// example "weight" on Weight events into 29463-7 Body weight in [lb_av]. Data points of one
+// event that share a PanelCode become components of a single panel Observation instead, the
+// way FHIR expects blood pressure to be reported.
+type Mapping struct {
+	EventType    string `json:"eventType"`
+	DataPoint    string `json:"dataPoint"`
+	Code         string `json:"code"`
+	Display      string `json:"display"`
+	Unit         string `json:"unit"`
+	UnitCode     string `json:"unitCode"`
+	Category     string `json:"category,omitempty"`
+	PanelCode    string `json:"panelCode,omitempty"`
+	PanelDisplay string `json:"panelDisplay,omitempty"`
+}
+
+const (
+	bloodPressurePanelCode    = "85354-9"
+	bloodPressurePanelDisplay = "Blood pressure panel with all children optional"
+)
+
+// DefaultMappings cover the vital signs the app records. They can be replaced wholesale
+// through configuration; see ParseMappings.
+func DefaultMappings() []Mapping {
+	return []Mapping{
+		{EventType: "Weight", DataPoint: "weight", Code: "29463-7", Display: "Body weight", Unit: "lb", UnitCode: "[lb_av]"},
+		{EventType: "Temperature", DataPoint: "temperature", Code: "8310-5", Display: "Body temperature", Unit: "degF", UnitCode: "[degF]"},
+		{EventType: "Blood Pressure", DataPoint: "systolic", Code: "8480-6", Display: "Systolic blood pressure", Unit: "mmHg", UnitCode: "mm[Hg]", PanelCode: bloodPressurePanelCode, PanelDisplay: bloodPressurePanelDisplay},
+		{EventType: "Blood Pressure", DataPoint: "diastolic", Code: "8462-4", Display: "Diastolic blood pressure", Unit: "mmHg", UnitCode: "mm[Hg]", PanelCode: bloodPressurePanelCode, PanelDisplay: bloodPressurePanelDisplay},
+		{EventType: "Heart Rate", DataPoint: "rate", Code: "8867-4", Display: "Heart rate", Unit: "beats/minute", UnitCode: "/min"},
+		{EventType: "Oxygen Saturation", DataPoint: "saturation", Code: "59408-5", Display: "Oxygen saturation in Arterial blood by Pulse oximetry", Unit: "%", UnitCode: "%"},
+	}
+}
+
+// ParseMappings reads a JSON array of mappings. Every mapping needs an event type, data
+// point and LOINC code, and a data point may only be mapped once.
+func ParseMappings(raw string) ([]Mapping, error) {
+	mappings := []Mapping{}
+	if err := json.Unmarshal([]byte(raw), &mappings); err != nil {
+		return nil, fmt.Errorf("unable to parse observation mappings: %w", err)
+	}
+
+	seen := map[string]bool{}
+	for i, m := range mappings {
+		if m.EventType == "" || m.DataPoint == "" || m.Code == "" {
+			return nil, fmt.Errorf("observation mapping %d needs an eventType, dataPoint and code", i)
+		}
+		key := m.EventType + "/" + m.DataPoint
+		if seen[key] {
+			return nil, fmt.Errorf("%s is mapped more than once", key)
+		}
+		seen[key] = true
+	}
+	return mappings, nil
+}
+
+// Bundle is a FHIR Bundle. Total is only allowed on searchset and history bundles, so
+// NewBundle leaves it unset.
+type Bundle struct {
+	ResourceType string        `json:"resourceType"`
+	ID           string        `json:"id"`
+	Type         string        `json:"type"`
+	Timestamp    string        `json:"timestamp"`
+	Total        *int          `json:"total,omitempty"`
+	Entry        []BundleEntry `json:"entry"`
+}
+
+type BundleEntry struct {
+	FullURL  string      `json:"fullUrl"`
+	Resource interface{} `json:"resource"`
+}
+
+type Identifier struct {
+	System string `json:"system"`
+	Value  string `json:"value"`
+}
+
+type HumanName struct {
+	Use    string   `json:"use,omitempty"`
+	Text   string   `json:"text,omitempty"`
+	Family string   `json:"family,omitempty"`
+	Given  []string `json:"given,omitempty"`
+}
+
+type Patient struct {
+	ResourceType string       `json:"resourceType"`
+	ID           string       `json:"id"`
+	Identifier   []Identifier `json:"identifier"`
+	Name         []HumanName  `json:"name,omitempty"`
+}
+
+type Coding struct {
+	System  string `json:"system"`
+	Code    string `json:"code"`
+	Display string `json:"display,omitempty"`
+}
+
+type CodeableConcept struct {
+	Coding []Coding `json:"coding"`
+	Text   string   `json:"text,omitempty"`
+}
+
+type Reference struct {
+	Reference string `json:"reference"`
+}
+
+type Quantity struct {
+	Value  float64 `json:"value"`
+	Unit   string  `json:"unit,omitempty"`
+	System string  `json:"system,omitempty"`
+	Code   string  `json:"code,omitempty"`
+}
+
+type Annotation struct {
+	Text string `json:"text"`
+}
+
+type ObservationComponent struct {
+	Code          CodeableConcept `json:"code"`
+	ValueQuantity Quantity        `json:"valueQuantity"`
+}
+
+type Observation struct {
+	ResourceType      string                 `json:"resourceType"`
+	ID                string                 `json:"id"`
+	Identifier        []Identifier           `json:"identifier"`
+	Status            string                 `json:"status"`
+	Category          []CodeableConcept      `json:"category,omitempty"`
+	Code              CodeableConcept        `json:"code"`
+	Subject           Reference              `json:"subject"`
+	EffectiveDateTime string                 `json:"effectiveDateTime"`
+	ValueQuantity     *Quantity              `json:"valueQuantity,omitempty"`
+	Note              []Annotation           `json:"note,omitempty"`
+	Component         []ObservationComponent `json:"component,omitempty"`
+}
+
+// NewBundle builds a collection Bundle holding the receiver as a Patient followed by an
+// Observation for every mapped data point with a numeric value, oldest first. Paneled data
+// points of an event share one Observation. Events and data points without a mapping are
+// left out.
+func NewBundle(r receiver.Receiver, events []event.Entry, mappings []Mapping, now time.Time) Bundle {
+	byDataPoint := map[string]Mapping{}
+	for _, m := range mappings {
+		byDataPoint[m.EventType+"/"+m.DataPoint] = m
+	}
+
+	patientID := resourceID(r.ReceiverID)
+	patient := Patient{
+		ResourceType: "Patient",
+		ID:           patientID,
+		Identifier:   []Identifier{{System: ReceiverIdentifierSystem, Value: r.ReceiverID}},
+	}
+	if name := strings.TrimSpace(fmt.Sprintf("%s %s", r.FirstName, r.LastName)); name != "" {
+		patient.Name = []HumanName{{
+			Use:    "official",
+			Text:   name,
+			Family: r.LastName,
+			Given:  nonEmpty(r.FirstName),
+		}}
+	}
+
+	bundle := Bundle{
+		ResourceType: "Bundle",
+		ID:           uuid.NewString(),
+		Type:         "collection",
+		Timestamp:    now.UTC().Format(time.RFC3339),
+		Entry:        []BundleEntry{{FullURL: fullURL(patientID), Resource: patient}},
+	}
+
+	sorted := make([]event.Entry, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime < sorted[j].StartTime
+	})
+
+	for _, e := range sorted {
+		effective, err := time.Parse(time.RFC3339, e.StartTime)
+		if err != nil {
+			continue
+		}
+
+		observations := []*Observation{}
+		panels := map[string]*Observation{}
+		for i, dp := range e.Data {
+			m, ok := byDataPoint[e.Type+"/"+dp.Name]
+			if !ok {
+				continue
+			}
+			value, err := strconv.ParseFloat(strings.TrimSpace(dp.Value), 64)
+			if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+
+			q := quantity(m, value)
+			if m.PanelCode == "" {
+				observation := newObservation(fmt.Sprintf("%s/%s/%d", e.EventID, dp.Name, i), e, m, loinc(m.Code, m.Display), patientID, effective)
+				observation.ValueQuantity = &q
+				observations = append(observations, observation)
+				continue
+			}
+
+			panel, ok := panels[m.PanelCode]
+			if !ok {
+				panel = newObservation(e.EventID+"/"+m.PanelCode, e, m, loinc(m.PanelCode, m.PanelDisplay), patientID, effective)
+				panels[m.PanelCode] = panel
+				observations = append(observations, panel)
+			}
+			panel.Component = append(panel.Component, ObservationComponent{Code: loinc(m.Code, m.Display), ValueQuantity: q})
+		}
+
+		for _, observation := range observations {
+			bundle.Entry = append(bundle.Entry, BundleEntry{FullURL: fullURL(observation.ID), Resource: *observation})
+		}
+	}
+
+	return bundle
+}
+
+// newObservation starts an Observation of e, identified by a stable ID derived from name.
+func newObservation(name string, e event.Entry, m Mapping, code CodeableConcept, patientID string, effective time.Time) *Observation {
+	observation := &Observation{
+		ResourceType:      "Observation",
+		ID:                resourceID(name),
+		Identifier:        []Identifier{{System: EventIdentifierSystem, Value: e.EventID}},
+		Status:            "final",
+		Category:          category(m),
+		Code:              code,
+		Subject:           Reference{Reference: fullURL(patientID)},
+		EffectiveDateTime: effective.Format(time.RFC3339),
+	}
+	if note := strings.TrimSpace(e.Note); note != "" {
+		observation.Note = []Annotation{{Text: note}}
+	}
+	return observation
+}
+
+func loinc(code, display string) CodeableConcept {
+	return CodeableConcept{
+		Coding: []Coding{{System: LOINCSystem, Code: code, Display: display}},
+		Text:   display,
+	}
+}
+
+func quantity(m Mapping, value float64) Quantity {
+	q := Quantity{Value: value, Unit: m.Unit}
+	if m.UnitCode != "" {
+		q.System = UCUMSystem
+		q.Code = m.UnitCode
+	}
+	return q
+}
+
+// WriteBundle writes NewBundle as indented JSON.
+func WriteBundle(w io.Writer, r receiver.Receiver, events []event.Entry, mappings []Mapping, now time.Time) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(NewBundle(r, events, mappings, now))
+}
+
+// resourceID derives a stable UUID from a CareGiver ID, so exporting the same history twice
+// gives the same resource IDs.
+func resourceID(name string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+}
+
+func fullURL(id string) string {
+	return "urn:uuid:" + id
+}
+
+func category(m Mapping) []CodeableConcept {
+	code := m.Category
+	if code == "" {
+		code = CategoryVitalSign
+	}
+	return []CodeableConcept{{Coding: []Coding{{System: CategorySystem, Code: code}}}}
+}
+
+func nonEmpty(values ...string) []string {
+	kept := []string{}
+	for _, v := range values {
+		if v != "" {
+			kept = append(kept, v)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
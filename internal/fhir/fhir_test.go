@@ -0,0 +1,176 @@
+package fhir
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
+	"github.com/stretchr/testify/assert"
+)
+
+var testReceiver = receiver.Receiver{
+	ReceiverID: "Receiver#123",
+	FirstName:  "Mary",
+	LastName:   "Jones",
+}
+
+func TestNewBundle(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	events := []event.Entry{
+		{
+			EventID:   "Event#BP",
+			Type:      "Blood Pressure",
+			StartTime: "2025-03-11T14:00:00Z",
+			Data: []event.DataPoint{
+				{Name: "systolic", Value: "128"},
+				{Name: "diastolic", Value: "84"},
+				{Name: "position", Value: "seated"},
+			},
+			Note: "Left arm",
+		},
+		{
+			EventID:   "Event#Weight",
+			Type:      "Weight",
+			StartTime: "2025-03-10T14:00:00Z",
+			Data:      []event.DataPoint{{Name: "weight", Value: "150.5"}},
+		},
+		{
+			EventID:   "Event#Shower",
+			Type:      "Shower",
+			StartTime: "2025-03-10T13:00:00Z",
+		},
+		{
+			EventID:   "Event#NotANumber",
+			Type:      "Weight",
+			StartTime: "2025-03-12T14:00:00Z",
+			Data:      []event.DataPoint{{Name: "weight", Value: "heavy"}},
+		},
+	}
+
+	bundle := NewBundle(testReceiver, events, DefaultMappings(), now)
+	assert.Equal(t, "Bundle", bundle.ResourceType)
+	assert.Equal(t, "collection", bundle.Type)
+	assert.Equal(t, "2025-03-31T12:00:00Z", bundle.Timestamp)
+	assert.Nil(t, bundle.Total)
+	assert.Len(t, bundle.Entry, 3)
+
+	patient, ok := bundle.Entry[0].Resource.(Patient)
+	assert.True(t, ok)
+	assert.Equal(t, "urn:uuid:"+patient.ID, bundle.Entry[0].FullURL)
+	assert.Equal(t, []Identifier{{System: ReceiverIdentifierSystem, Value: "Receiver#123"}}, patient.Identifier)
+	assert.Equal(t, []HumanName{{Use: "official", Text: "Mary Jones", Family: "Jones", Given: []string{"Mary"}}}, patient.Name)
+
+	codes := []string{}
+	for _, entry := range bundle.Entry[1:] {
+		observation, ok := entry.Resource.(Observation)
+		assert.True(t, ok)
+		assert.Equal(t, "urn:uuid:"+observation.ID, entry.FullURL)
+		assert.Equal(t, bundle.Entry[0].FullURL, observation.Subject.Reference)
+		assert.Equal(t, "final", observation.Status)
+		assert.Equal(t, CategoryVitalSign, observation.Category[0].Coding[0].Code)
+		assert.Equal(t, LOINCSystem, observation.Code.Coding[0].System)
+		codes = append(codes, observation.Code.Coding[0].Code)
+	}
+	assert.Equal(t, []string{"29463-7", "85354-9"}, codes)
+
+	weight := bundle.Entry[1].Resource.(Observation)
+	assert.Equal(t, &Quantity{Value: 150.5, Unit: "lb", System: UCUMSystem, Code: "[lb_av]"}, weight.ValueQuantity)
+	assert.Equal(t, "2025-03-10T14:00:00Z", weight.EffectiveDateTime)
+	assert.Empty(t, weight.Note)
+	assert.Empty(t, weight.Component)
+
+	bloodPressure := bundle.Entry[2].Resource.(Observation)
+	assert.Equal(t, []Identifier{{System: EventIdentifierSystem, Value: "Event#BP"}}, bloodPressure.Identifier)
+	assert.Equal(t, []Annotation{{Text: "Left arm"}}, bloodPressure.Note)
+	assert.Nil(t, bloodPressure.ValueQuantity)
+	assert.Equal(t, []ObservationComponent{
+		{
+			Code:          CodeableConcept{Coding: []Coding{{System: LOINCSystem, Code: "8480-6", Display: "Systolic blood pressure"}}, Text: "Systolic blood pressure"},
+			ValueQuantity: Quantity{Value: 128, Unit: "mmHg", System: UCUMSystem, Code: "mm[Hg]"},
+		},
+		{
+			Code:          CodeableConcept{Coding: []Coding{{System: LOINCSystem, Code: "8462-4", Display: "Diastolic blood pressure"}}, Text: "Diastolic blood pressure"},
+			ValueQuantity: Quantity{Value: 84, Unit: "mmHg", System: UCUMSystem, Code: "mm[Hg]"},
+		},
+	}, bloodPressure.Component)
+
+	again := NewBundle(testReceiver, events, DefaultMappings(), now)
+	assert.Equal(t, bundle.Entry, again.Entry)
+	assert.NotEqual(t, bundle.ID, again.ID)
+}
+
+func TestNewBundleCustomMapping(t *testing.T) {
+	mappings := []Mapping{
+		{EventType: "Weight", DataPoint: "weight", Code: "3141-9", Display: "Body weight Measured", Unit: "kg", Category: "exam"},
+	}
+	events := []event.Entry{
+		{EventID: "Event#Weight", Type: "Weight", StartTime: "2025-03-10T14:00:00Z", Data: []event.DataPoint{{Name: "weight", Value: "68"}}},
+		{EventID: "Event#Temperature", Type: "Temperature", StartTime: "2025-03-10T15:00:00Z", Data: []event.DataPoint{{Name: "temperature", Value: "99.1"}}},
+	}
+
+	bundle := NewBundle(receiver.Receiver{ReceiverID: "Receiver#123"}, events, mappings, time.Now())
+	assert.Len(t, bundle.Entry, 2)
+	assert.Empty(t, bundle.Entry[0].Resource.(Patient).Name)
+
+	observation := bundle.Entry[1].Resource.(Observation)
+	assert.Equal(t, "3141-9", observation.Code.Coding[0].Code)
+	assert.Equal(t, "exam", observation.Category[0].Coding[0].Code)
+	assert.Equal(t, &Quantity{Value: 68, Unit: "kg"}, observation.ValueQuantity)
+}
+
+func TestWriteBundle(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteBundle(&buf, testReceiver, nil, DefaultMappings(), time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+
+	var decoded map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "Bundle", decoded["resourceType"])
+	assert.NotContains(t, decoded, "total")
+
+	entries := decoded["entry"].([]interface{})
+	resource := entries[0].(map[string]interface{})["resource"].(map[string]interface{})
+	assert.Equal(t, "Patient", resource["resourceType"])
+}
+
+func TestParseMappings(t *testing.T) {
+	tests := map[string]struct {
+		raw         string
+		expected    []Mapping
+		expectError bool
+	}{
+		"Happy Path - Mappings Parsed": {
+			raw: `[{"eventType":"Weight","dataPoint":"weight","code":"29463-7","display":"Body weight","unit":"kg","unitCode":"kg"}]`,
+			expected: []Mapping{
+				{EventType: "Weight", DataPoint: "weight", Code: "29463-7", Display: "Body weight", Unit: "kg", UnitCode: "kg"},
+			},
+		},
+		"Happy Path - Empty List": {
+			raw:      `[]`,
+			expected: []Mapping{},
+		},
+		"Sad Path - Not JSON": {
+			raw:         `Weight=29463-7`,
+			expectError: true,
+		},
+		"Sad Path - Missing Code": {
+			raw:         `[{"eventType":"Weight","dataPoint":"weight"}]`,
+			expectError: true,
+		},
+		"Sad Path - Duplicate Data Point": {
+			raw:         `[{"eventType":"Weight","dataPoint":"weight","code":"29463-7"},{"eventType":"Weight","dataPoint":"weight","code":"3141-9"}]`,
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			mappings, err := ParseMappings(tc.raw)
+			assert.Equal(t, tc.expectError, err != nil)
+			assert.Equal(t, tc.expected, mappings)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	awsevents "github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/digest"
+	"github.com/care-giver-app/care-giver-api/internal/notifications"
+	"github.com/care-giver-app/care-giver-api/internal/preference"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/relationship"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
+	"go.uber.org/zap"
+)
+
+const (
+	getNotificationPreferences    = "get notification preferences"
+	updateNotificationPreferences = "update notification preferences"
+	flushActivityDigests          = "flush activity digests"
+
+	preferenceDatabaseError = "error retrieving notification preferences from db"
+)
+
+type NotificationPreferencesRequest struct {
+	EventTypes      []string `json:"eventTypes" validate:"omitempty,max=50,dive,required,max=100"`
+	QuietHoursStart string   `json:"quietHoursStart" validate:"omitempty,len=5"`
+	QuietHoursEnd   string   `json:"quietHoursEnd" validate:"omitempty,len=5"`
+	Timezone        string   `json:"timezone" validate:"omitempty,max=64"`
+	DigestMinutes   int      `json:"digestMinutes" validate:"omitempty,min=1,max=240"`
+}
+
+type NotificationPreferencesResponse struct {
+	Preferences preference.Preferences `json:"preferences"`
+	Status      string                 `json:"status"`
+}
+
+func HandleGetNotificationPreferences(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, getNotificationPreferences)
+
+	rid, uid, errResp, ok := authorizeNotificationPreferences(params)
+	if !ok {
+		return errResp, nil
+	}
+
+	p, err := getPreferences(params, uid, rid)
+	if err != nil {
+		params.AppCfg.Logger.Error(preferenceDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getNotificationPreferences)
+	return response.FormatResponse(NotificationPreferencesResponse{
+		Preferences: p,
+		Status:      response.Success,
+	}, http.StatusOK), nil
+}
+
+func HandleUpdateNotificationPreferences(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, updateNotificationPreferences)
+
+	var npr NotificationPreferencesRequest
+	err := readRequestBody(params.Request.Body, &npr)
+	if err != nil {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		return createRequestBodyErrorResponse(err), nil
+	}
+
+	rid, uid, errResp, ok := authorizeNotificationPreferences(params)
+	if !ok {
+		return errResp, nil
+	}
+
+	p := preference.Preferences{
+		UserID:          uid,
+		ReceiverID:      rid,
+		EventTypes:      npr.EventTypes,
+		QuietHoursStart: npr.QuietHoursStart,
+		QuietHoursEnd:   npr.QuietHoursEnd,
+		Timezone:        npr.Timezone,
+		DigestMinutes:   npr.DigestMinutes,
+		UpdatedAt:       time.Now().UTC().Format(time.RFC3339),
+	}
+	if p.DigestMinutes == 0 {
+		p.DigestMinutes = preference.DefaultDigestMinutes
+	}
+
+	if err := p.Validate(); err != nil {
+		params.AppCfg.Logger.Error("invalid notification preferences", zap.Error(err))
+		var validationErr *preference.ValidationError
+		if errors.As(err, &validationErr) {
+			return response.CreateValidationErrorResponse([]response.FieldError{
+				{Field: validationErr.Field, Message: validationErr.Message},
+			}), nil
+		}
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	err = params.PreferenceRepo.PutPreferences(p)
+	if err != nil {
+		params.AppCfg.Logger.Error("error saving notification preferences to db", zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	recordAudit(params, rid, uid, audit.ActionUpdateNotificationPreferences, audit.WithAfter(npr))
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, updateNotificationPreferences)
+	return response.FormatResponse(NotificationPreferencesResponse{
+		Preferences: p,
+		Status:      response.Success,
+	}, http.StatusOK), nil
+}
+
+// authorizeNotificationPreferences checks that the user is a caregiver for the receiver. When
+// it returns false, the response is ready to send back as is.
+func authorizeNotificationPreferences(params HandlerParams) (string, string, awsevents.APIGatewayProxyResponse, bool) {
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return "", "", response.CreateBadRequestResponse(), false
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return "", "", response.CreateBadRequestResponse(), false
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return "", "", response.CreateInternalServerErrorResponse(), false
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return "", "", response.CreateInternalServerErrorResponse(), false
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return "", "", response.CreateAccessDeniedResponse(), false
+	}
+
+	return rid, u.UserID, awsevents.APIGatewayProxyResponse{}, true
+}
+
+func getPreferences(params HandlerParams, uid, rid string) (preference.Preferences, error) {
+	p, err := params.PreferenceRepo.GetPreferences(uid, rid)
+	if errors.Is(err, preference.ErrNotFound) {
+		return preference.Default(uid, rid), nil
+	}
+	return p, err
+}
+
+// queueActivityNotifications adds a newly logged event to the digest of every other caregiver
+// who wants to hear about it. Digests are sent by FlushActivityDigests once their window
+// closes, so a burst of logs becomes one email. It is best effort like the audit trail.
+func queueActivityNotifications(params HandlerParams, e event.Entry, loggedBy user.User) {
+	relationships, err := params.RelationshipRepo.GetRelationshipsByReceiver(e.ReceiverID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.String(log.ReceiverIDLogKey, e.ReceiverID), zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	item := digest.NewItem(e, displayName(loggedBy))
+	for _, rel := range relationships {
+		if !rel.EmailNotifications || rel.UserID == loggedBy.UserID {
+			continue
+		}
+
+		p, err := getPreferences(params, rel.UserID, e.ReceiverID)
+		if err != nil {
+			params.AppCfg.Logger.Error(preferenceDatabaseError, zap.String(log.UserIDLogKey, rel.UserID), zap.Error(err))
+			continue
+		}
+
+		if !p.Wants(e.Type) {
+			continue
+		}
+
+		recipient, err := params.UserRepo.GetUser(rel.UserID)
+		if err != nil {
+			params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, rel.UserID), zap.Error(err))
+			continue
+		}
+
+		err = params.DigestRepo.AddItem(rel.UserID, e.ReceiverID, recipient.Email, item, p.FlushAt(now))
+		if err != nil {
+			params.AppCfg.Logger.Error("error queueing activity notification", zap.String(log.UserIDLogKey, rel.UserID), zap.Error(err))
+		}
+	}
+}
+
+// FlushActivityDigests sends every digest whose window has closed. It runs on a schedule
+// rather than behind the API. Digests that come due inside the recipient's quiet hours are
+// pushed back to the end of them.
+func FlushActivityDigests(ctx context.Context, params HandlerParams) error {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, flushActivityDigests)
+
+	now := time.Now()
+	digests, err := params.DigestRepo.GetDueDigests(now)
+	if err != nil {
+		params.AppCfg.Logger.Error("error retrieving due digests from db", zap.Error(err))
+		return err
+	}
+
+	for _, d := range digests {
+		p, err := getPreferences(params, d.UserID, d.ReceiverID)
+		if err != nil {
+			params.AppCfg.Logger.Error(preferenceDatabaseError, zap.String(log.UserIDLogKey, d.UserID), zap.Error(err))
+			continue
+		}
+
+		if p.InQuietHours(now) {
+			err = params.DigestRepo.Reschedule(d.UserID, d.ReceiverID, p.QuietHoursEndAfter(now))
+			if err != nil && !errors.Is(err, digest.ErrNotFound) {
+				params.AppCfg.Logger.Error("error rescheduling digest", zap.String(log.UserIDLogKey, d.UserID), zap.Error(err))
+			}
+			continue
+		}
+
+		taken, err := params.DigestRepo.TakeDigest(d.UserID, d.ReceiverID)
+		if errors.Is(err, digest.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			params.AppCfg.Logger.Error("error taking digest", zap.String(log.UserIDLogKey, d.UserID), zap.Error(err))
+			continue
+		}
+
+		err = params.Publisher.Publish(ctx, notifications.NewActivityDigest(notifications.ActivityDigestPayload{
+			Email:      taken.Email,
+			ReceiverID: taken.ReceiverID,
+			Events:     taken.Items,
+		}))
+		if err != nil {
+			params.AppCfg.Logger.Error("error sending activity digest, requeueing", zap.String(log.UserIDLogKey, d.UserID), zap.Error(err))
+			requeueDigest(params, taken, now)
+		}
+	}
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, flushActivityDigests)
+	return nil
+}
+
+func requeueDigest(params HandlerParams, d digest.Digest, now time.Time) {
+	for _, item := range d.Items {
+		err := params.DigestRepo.AddItem(d.UserID, d.ReceiverID, d.Email, item, now)
+		if err != nil {
+			params.AppCfg.Logger.Error("error requeueing digest item", zap.String(log.UserIDLogKey, d.UserID), zap.String(event.ParamID, item.EventID), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/digest"
+	"github.com/care-giver-app/care-giver-api/internal/notifications"
+	"github.com/care-giver-app/care-giver-api/internal/preference"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleGetNotificationPreferences(t *testing.T) {
+	tests := map[string]struct {
+		receiverID       string
+		userID           string
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - Saved Preferences": {
+			receiverID: "Receiver#123",
+			userID:     "User#123",
+			expectedResponse: response.FormatResponse(NotificationPreferencesResponse{
+				Preferences: preference.Preferences{
+					UserID:          "User#123",
+					ReceiverID:      "Receiver#123",
+					EventTypes:      []string{"Medication"},
+					QuietHoursStart: "22:00",
+					QuietHoursEnd:   "07:00",
+					Timezone:        "America/Chicago",
+					DigestMinutes:   30,
+				},
+				Status: response.Success,
+			}, http.StatusOK),
+		},
+		"Happy Path - Defaults When None Saved": {
+			receiverID: "Receiver#Sync",
+			userID:     "User#Syncer",
+			expectedResponse: response.FormatResponse(NotificationPreferencesResponse{
+				Preferences: preference.Default("User#Syncer", "Receiver#Sync"),
+				Status:      response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - User Is Not A Care Giver": {
+			receiverID:       "Receiver#123",
+			userID:           "User#NotACareGiver",
+			expectedResponse: response.CreateAccessDeniedResponse(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodGet,
+					PathParameters:        map[string]string{"receiverId": tc.receiverID},
+					QueryStringParameters: map[string]string{"userId": tc.userID},
+				},
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				PreferenceRepo:   testPreferenceRepo,
+			}
+			resp, err := HandleGetNotificationPreferences(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
+
+func TestHandleUpdateNotificationPreferences(t *testing.T) {
+	tests := map[string]struct {
+		receiverID           string
+		body                 string
+		expectedStatusCode   int
+		expectedResponseBody map[string]interface{}
+	}{
+		"Happy Path - Preferences Saved": {
+			receiverID:         "Receiver#123",
+			body:               "{\"eventTypes\":[\"Medication\",\"Shower\"], \"quietHoursStart\":\"22:00\", \"quietHoursEnd\":\"07:00\", \"timezone\":\"America/Chicago\", \"digestMinutes\":30}",
+			expectedStatusCode: http.StatusOK,
+		},
+		"Happy Path - Empty Body Restores Defaults": {
+			receiverID:         "Receiver#123",
+			body:               "{}",
+			expectedStatusCode: http.StatusOK,
+		},
+		"Sad Path - Quiet Hours Missing End": {
+			receiverID:           "Receiver#123",
+			body:                 "{\"quietHoursStart\":\"22:00\"}",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: map[string]interface{}{"status": "Bad Request", "errors": []interface{}{map[string]interface{}{"field": "quietHoursEnd", "message": "is required when quietHoursStart is set"}}},
+		},
+		"Sad Path - Bad Quiet Hours Clock": {
+			receiverID:           "Receiver#123",
+			body:                 "{\"quietHoursStart\":\"25:00\", \"quietHoursEnd\":\"07:00\"}",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: map[string]interface{}{"status": "Bad Request", "errors": []interface{}{map[string]interface{}{"field": "quietHoursStart", "message": "must be a time of day as HH:MM"}}},
+		},
+		"Sad Path - Unknown Timezone": {
+			receiverID:           "Receiver#123",
+			body:                 "{\"timezone\":\"Mars/Olympus_Mons\"}",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: map[string]interface{}{"status": "Bad Request", "errors": []interface{}{map[string]interface{}{"field": "timezone", "message": "must be an IANA time zone such as America/Chicago"}}},
+		},
+		"Sad Path - Digest Too Long": {
+			receiverID:         "Receiver#123",
+			body:               "{\"digestMinutes\":1000}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Error Saving Preferences": {
+			receiverID:         "Receiver#Error",
+			body:               "{}",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodPut,
+					PathParameters:        map[string]string{"receiverId": tc.receiverID},
+					QueryStringParameters: map[string]string{"userId": "User#123"},
+					Body:                  tc.body,
+				},
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				PreferenceRepo:   testPreferenceRepo,
+				AuditRepo:        testAuditRepo,
+			}
+			resp, err := HandleUpdateNotificationPreferences(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedResponseBody != nil {
+				var body map[string]interface{}
+				assert.Nil(t, json.Unmarshal([]byte(resp.Body), &body))
+				assert.Equal(t, tc.expectedResponseBody, body)
+			}
+		})
+	}
+}
+
+// digestStore is an in memory digest table, so tests can see what a flush leaves behind.
+type digestStore struct {
+	digests map[string]digest.Digest
+}
+
+func newDigestStore(digests ...digest.Digest) *digestStore {
+	ds := &digestStore{digests: map[string]digest.Digest{}}
+	for _, d := range digests {
+		ds.digests[d.UserID+idSeparator+d.ReceiverID] = d
+	}
+	return ds
+}
+
+func (ds *digestStore) AddItem(uid, rid, email string, item digest.Item, flushAt time.Time) error {
+	d, found := ds.digests[uid+idSeparator+rid]
+	if !found {
+		d = digest.Digest{UserID: uid, ReceiverID: rid, FlushAt: flushAt.UTC().Format(time.RFC3339)}
+	}
+	d.Email = email
+	d.Items = append(d.Items, item)
+	ds.digests[uid+idSeparator+rid] = d
+	return nil
+}
+
+func (ds *digestStore) GetDueDigests(now time.Time) ([]digest.Digest, error) {
+	due := []digest.Digest{}
+	for _, d := range ds.digests {
+		if d.FlushAt <= now.UTC().Format(time.RFC3339) {
+			due = append(due, d)
+		}
+	}
+	return due, nil
+}
+
+func (ds *digestStore) Reschedule(uid, rid string, flushAt time.Time) error {
+	d, found := ds.digests[uid+idSeparator+rid]
+	if !found {
+		return digest.ErrNotFound
+	}
+	d.FlushAt = flushAt.UTC().Format(time.RFC3339)
+	ds.digests[uid+idSeparator+rid] = d
+	return nil
+}
+
+func (ds *digestStore) TakeDigest(uid, rid string) (digest.Digest, error) {
+	d, found := ds.digests[uid+idSeparator+rid]
+	if !found {
+		return digest.Digest{}, digest.ErrNotFound
+	}
+	delete(ds.digests, uid+idSeparator+rid)
+	return d, nil
+}
+
+type failingPublisher struct{}
+
+func (fp failingPublisher) Publish(ctx context.Context, n notifications.Notification) error {
+	return errors.New("error sending notification")
+}
+
+func TestFlushActivityDigests(t *testing.T) {
+	now := time.Now().UTC()
+	items := []digest.Item{
+		{EventID: "Event#123", Type: "Shower", StartTime: "2023-10-01T12:00:00Z", LoggedBy: "John Doe"},
+	}
+	due := digest.Digest{
+		UserID:     "User#456",
+		ReceiverID: "Receiver#123",
+		Email:      "jane@test.com",
+		Items:      items,
+		FlushAt:    now.Add(-time.Minute).Format(time.RFC3339),
+	}
+	quiet := due
+	quiet.UserID = "User#123"
+
+	// User#123's quiet hours are moved to surround the test's clock.
+	quietHours := &MockPreferenceRepo{quietHoursAround: now}
+
+	tests := map[string]struct {
+		digest            digest.Digest
+		failPublish       bool
+		expectedPublished []notifications.Notification
+		expectQueued      bool
+		expectRescheduled bool
+	}{
+		"Happy Path - Due Digest Sent And Cleared": {
+			digest: due,
+			expectedPublished: []notifications.Notification{
+				notifications.NewActivityDigest(notifications.ActivityDigestPayload{
+					Email:      "jane@test.com",
+					ReceiverID: "Receiver#123",
+					Events:     items,
+				}),
+			},
+		},
+		"Happy Path - Digest In Quiet Hours Rescheduled": {
+			digest:            quiet,
+			expectQueued:      true,
+			expectRescheduled: true,
+		},
+		"Sad Path - Publish Failure Keeps Digest Queued": {
+			digest:       due,
+			failPublish:  true,
+			expectQueued: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := newDigestStore(tc.digest)
+			memory := notifications.NewMemoryPublisher()
+			params := HandlerParams{
+				AppCfg:         appconfig.NewAppConfig(),
+				PreferenceRepo: quietHours,
+				DigestRepo:     store,
+				Publisher:      memory,
+			}
+			if tc.failPublish {
+				params.Publisher = failingPublisher{}
+			}
+			assert.Nil(t, FlushActivityDigests(context.Background(), params))
+
+			assert.Equal(t, tc.expectedPublished, memory.Notifications())
+
+			queued, found := store.digests[tc.digest.UserID+idSeparator+tc.digest.ReceiverID]
+			assert.Equal(t, tc.expectQueued, found)
+			if !tc.expectQueued {
+				return
+			}
+			assert.Equal(t, items, queued.Items)
+			assert.Equal(t, tc.expectRescheduled, queued.FlushAt > now.Format(time.RFC3339))
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	awsevents "github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/alert"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/notifications"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/relationship"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
+	"go.uber.org/zap"
+)
+
+const (
+	addReceiverAlertRule    = "add receiver alert rule"
+	getReceiverAlertRules   = "get receiver alert rules"
+	deleteReceiverAlertRule = "delete receiver alert rule"
+)
+
+type AlertRuleRequest struct {
+	Type      string   `json:"type" validate:"required,max=100"`
+	DataPoint string   `json:"dataPoint" validate:"required,max=100,freetext"`
+	Operator  string   `json:"operator" validate:"required,oneof=gt gte lt lte"`
+	Threshold *float64 `json:"threshold" validate:"required"`
+	Label     string   `json:"label" validate:"omitempty,max=100,freetext"`
+}
+
+type AlertRuleResponse struct {
+	ReceiverID string `json:"receiverId"`
+	RuleID     string `json:"ruleId"`
+	Status     string `json:"status"`
+}
+
+type GetAlertRulesResponse struct {
+	Rules  []alert.Rule `json:"rules"`
+	Status string       `json:"status"`
+}
+
+func HandleAddReceiverAlertRule(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, addReceiverAlertRule)
+
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	var ar AlertRuleRequest
+	err = readRequestBody(params.Request.Body, &ar)
+	if err != nil {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		return createRequestBodyErrorResponse(err), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	rule, err := alert.NewRule(rid, u.UserID, ar.Type, ar.DataPoint, alert.Operator(ar.Operator), *ar.Threshold, ar.Label)
+	if err != nil {
+		params.AppCfg.Logger.Error("error creating new alert rule", zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	err = params.AlertRuleRepo.AddRule(rule)
+	if err != nil {
+		params.AppCfg.Logger.Error("error adding alert rule to db", zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	recordAudit(params, rid, u.UserID, audit.ActionAddAlertRule,
+		audit.WithTarget(alert.ParamID, rule.RuleID),
+		audit.WithAfter(rule),
+	)
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, addReceiverAlertRule)
+	return response.FormatResponse(AlertRuleResponse{
+		ReceiverID: rid,
+		RuleID:     rule.RuleID,
+		Status:     response.Success,
+	}, http.StatusOK), nil
+}
+
+func HandleGetReceiverAlertRules(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, getReceiverAlertRules)
+
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	rules, err := params.AlertRuleRepo.GetRules(rid)
+	if err != nil {
+		params.AppCfg.Logger.Error(alertRuleDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getReceiverAlertRules)
+	return response.FormatResponse(GetAlertRulesResponse{
+		Rules:  rules,
+		Status: response.Success,
+	}, http.StatusOK), nil
+}
+
+func HandleDeleteReceiverAlertRule(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, deleteReceiverAlertRule)
+
+	ruleID, err := validatePathParameters(params.Request, alert.ParamID, alert.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, alert.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	rid, err := validateQueryParameters(params.Request, receiver.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	err = params.AlertRuleRepo.DeleteRule(rid, ruleID)
+	if errors.Is(err, alert.ErrNotFound) {
+		params.AppCfg.Logger.Error("alert rule not found", zap.String(log.ReceiverIDLogKey, rid), zap.String(alert.ParamID, ruleID))
+		return response.CreateResourceNotFoundResponse(), nil
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error("error deleting alert rule from db", zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	recordAudit(params, rid, u.UserID, audit.ActionDeleteAlertRule,
+		audit.WithTarget(alert.ParamID, ruleID),
+	)
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, deleteReceiverAlertRule)
+	return response.FormatResponse(AlertRuleResponse{
+		ReceiverID: rid,
+		RuleID:     ruleID,
+		Status:     response.Success,
+	}, http.StatusOK), nil
+}
+
+// notifyAlertBreaches checks a newly logged event against the receiver's alert rules and
+// queues an alert for every other caregiver who has email notifications turned on. It is
+// best effort: the event is already saved, so failures are logged rather than returned.
+func notifyAlertBreaches(ctx context.Context, params HandlerParams, e event.Entry, loggedBy user.User) {
+	rules, err := params.AlertRuleRepo.GetRules(e.ReceiverID)
+	if err != nil {
+		params.AppCfg.Logger.Error(alertRuleDatabaseError, zap.String(log.ReceiverIDLogKey, e.ReceiverID), zap.Error(err))
+		return
+	}
+
+	breaches := alert.Evaluate(rules, e)
+	if len(breaches) == 0 {
+		return
+	}
+
+	params.AppCfg.Logger.Warn("event breached alert rules", zap.String(log.ReceiverIDLogKey, e.ReceiverID), zap.String(event.ParamID, e.EventID), zap.Int("breaches", len(breaches)))
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByReceiver(e.ReceiverID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.String(log.ReceiverIDLogKey, e.ReceiverID), zap.Error(err))
+		return
+	}
+
+	for _, rel := range relationships {
+		if !rel.EmailNotifications || rel.UserID == loggedBy.UserID {
+			continue
+		}
+
+		recipient, err := params.UserRepo.GetUser(rel.UserID)
+		if err != nil {
+			params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, rel.UserID), zap.Error(err))
+			continue
+		}
+
+		err = params.Publisher.Publish(ctx, notifications.NewAlert(notifications.AlertPayload{
+			Email:      recipient.Email,
+			ReceiverID: e.ReceiverID,
+			EventID:    e.EventID,
+			EventType:  e.Type,
+			StartTime:  e.StartTime,
+			LoggedBy:   displayName(loggedBy),
+			Breaches:   breaches,
+		}))
+		if err != nil {
+			params.AppCfg.Logger.Error("error sending alert notification", zap.String(log.UserIDLogKey, rel.UserID), zap.Error(err))
+		}
+	}
+}
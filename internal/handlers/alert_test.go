@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/alert"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleAddReceiverAlertRule(t *testing.T) {
+	tests := map[string]struct {
+		receiverID         string
+		userID             string
+		body               string
+		expectedStatusCode int
+	}{
+		"Happy Path - Alert Rule Added": {
+			receiverID:         "Receiver#123",
+			userID:             "User#123",
+			body:               "{\"type\":\"Blood Pressure\", \"dataPoint\":\"systolic\", \"operator\":\"gt\", \"threshold\":180, \"label\":\"Hypertensive crisis\"}",
+			expectedStatusCode: http.StatusOK,
+		},
+		"Happy Path - Zero Threshold": {
+			receiverID:         "Receiver#123",
+			userID:             "User#123",
+			body:               "{\"type\":\"Fluid Intake\", \"dataPoint\":\"ml\", \"operator\":\"lte\", \"threshold\":0}",
+			expectedStatusCode: http.StatusOK,
+		},
+		"Sad Path - Unsupported Operator": {
+			receiverID:         "Receiver#123",
+			userID:             "User#123",
+			body:               "{\"type\":\"Temperature\", \"dataPoint\":\"celsius\", \"operator\":\"eq\", \"threshold\":39}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Missing Threshold": {
+			receiverID:         "Receiver#123",
+			userID:             "User#123",
+			body:               "{\"type\":\"Temperature\", \"dataPoint\":\"celsius\", \"operator\":\"gt\"}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - User Is Not A Care Giver": {
+			receiverID:         "Receiver#123",
+			userID:             "User#NotACareGiver",
+			body:               "{\"type\":\"Temperature\", \"dataPoint\":\"celsius\", \"operator\":\"gt\", \"threshold\":39}",
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - Error Adding Alert Rule": {
+			receiverID:         "Receiver#Error",
+			userID:             "User#123",
+			body:               "{\"type\":\"Temperature\", \"dataPoint\":\"celsius\", \"operator\":\"gt\", \"threshold\":39}",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodPost,
+					PathParameters:        map[string]string{"receiverId": tc.receiverID},
+					QueryStringParameters: map[string]string{"userId": tc.userID},
+					Body:                  tc.body,
+				},
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				AuditRepo:        testAuditRepo,
+				AlertRuleRepo:    testAlertRuleRepo,
+			}
+			resp, err := HandleAddReceiverAlertRule(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandleGetReceiverAlertRules(t *testing.T) {
+	tests := map[string]struct {
+		receiverID       string
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - Alert Rules Returned": {
+			receiverID: "Receiver#123",
+			expectedResponse: response.FormatResponse(GetAlertRulesResponse{
+				Rules: []alert.Rule{
+					{
+						ReceiverID: "Receiver#123",
+						RuleID:     "AlertRule#123",
+						Type:       "Weight",
+						DataPoint:  "weight",
+						Operator:   alert.OperatorGreaterThan,
+						Threshold:  300,
+					},
+				},
+				Status: response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - Error Getting Alert Rules": {
+			receiverID:       "Receiver#Error",
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodGet,
+					PathParameters:        map[string]string{"receiverId": tc.receiverID},
+					QueryStringParameters: map[string]string{"userId": "User#123"},
+				},
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				AlertRuleRepo:    testAlertRuleRepo,
+			}
+			resp, err := HandleGetReceiverAlertRules(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
+
+func TestHandleDeleteReceiverAlertRule(t *testing.T) {
+	tests := map[string]struct {
+		ruleID           string
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - Alert Rule Deleted": {
+			ruleID: "AlertRule#123",
+			expectedResponse: response.FormatResponse(AlertRuleResponse{
+				ReceiverID: "Receiver#123",
+				RuleID:     "AlertRule#123",
+				Status:     response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - Alert Rule Not Found": {
+			ruleID:           "AlertRule#NotFound",
+			expectedResponse: response.CreateResourceNotFoundResponse(),
+		},
+		"Sad Path - Error Deleting Alert Rule": {
+			ruleID:           "AlertRule#Error",
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+		"Sad Path - Bad Path Parameters": {
+			ruleID:           "Schedule#123",
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodDelete,
+					PathParameters:        map[string]string{"ruleId": tc.ruleID},
+					QueryStringParameters: map[string]string{"receiverId": "Receiver#123", "userId": "User#123"},
+				},
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				AuditRepo:        testAuditRepo,
+				AlertRuleRepo:    testAlertRuleRepo,
+			}
+			resp, err := HandleDeleteReceiverAlertRule(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	awsevents "github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/relationship"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
+	"go.uber.org/zap"
+)
+
+const (
+	getReceiverAudit = "get receiver audit"
+)
+
+type GetReceiverAuditResponse struct {
+	Entries []audit.Entry `json:"entries"`
+	Status  string        `json:"status"`
+}
+
+// recordAudit writes an audit entry for a mutation that has already been applied. A
+// failure is logged rather than returned since the change itself cannot be undone.
+func recordAudit(params HandlerParams, scopeID, actorID string, action audit.Action, opts ...audit.EntryOption) {
+	opts = append(opts, audit.WithRequestID(params.Request.RequestContext.RequestID))
+	entry, err := audit.NewEntry(scopeID, actorID, action, opts...)
+	if err != nil {
+		params.AppCfg.Logger.Error("error creating audit entry", zap.String("action", string(action)), zap.Error(err))
+		return
+	}
+
+	err = params.AuditRepo.AddEntry(entry)
+	if err != nil {
+		params.AppCfg.Logger.Error("error adding audit entry to db", zap.String("action", string(action)), zap.Error(err))
+	}
+}
+
+func HandleGetReceiverAudit(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, getReceiverAudit)
+
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsAPrimaryCareGiver(uid, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotPrimaryCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, uid))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	entries, err := params.AuditRepo.GetEntries(rid)
+	if err != nil {
+		params.AppCfg.Logger.Error("error retrieving audit entries from db", zap.String(log.ReceiverIDLogKey, rid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getReceiverAudit)
+	return response.FormatResponse(GetReceiverAuditResponse{
+		Entries: entries,
+		Status:  response.Success,
+	}, http.StatusOK), nil
+}
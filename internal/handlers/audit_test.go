@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleGetReceiverAudit(t *testing.T) {
+	tests := map[string]struct {
+		request          events.APIGatewayProxyRequest
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - Audit Entries Retrieved": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"receiverId": "Receiver#123",
+				},
+				QueryStringParameters: map[string]string{
+					"userId": "User#123",
+				},
+			},
+			expectedResponse: response.FormatResponse(GetReceiverAuditResponse{
+				Entries: []audit.Entry{
+					{
+						ScopeID: "Receiver#123",
+						AuditID: "2026-04-23T12:00:00Z#123",
+						ActorID: "User#123",
+						Action:  audit.ActionAddEvent,
+					},
+				},
+				Status: response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - Bad Path Parameter - receiverId": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"notReceiverId": "Receiver#123",
+				},
+				QueryStringParameters: map[string]string{
+					"userId": "User#123",
+				},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Bad Query Parameter - userId": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"receiverId": "Receiver#123",
+				},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Error Getting Relationships": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"receiverId": "Receiver#123",
+				},
+				QueryStringParameters: map[string]string{
+					"userId": "User#RelationshipError",
+				},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+		"Sad Path - User Is Not A Primary Care Giver": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"receiverId": "Receiver#123",
+				},
+				QueryStringParameters: map[string]string{
+					"userId": "User#NotAPrimaryCareGiver",
+				},
+			},
+			expectedResponse: response.CreateAccessDeniedResponse(),
+		},
+		"Sad Path - Error Getting Audit Entries": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"receiverId": "Receiver#RelationshipError",
+				},
+				QueryStringParameters: map[string]string{
+					"userId": "User#123",
+				},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg:           appconfig.NewAppConfig(),
+				Request:          tc.request,
+				RelationshipRepo: testRelationshipRepo,
+				AuditRepo:        testAuditRepo,
+			}
+
+			resp, err := HandleGetReceiverAudit(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
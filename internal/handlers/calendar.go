@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	awsevents "github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/calendarfeed"
+	"github.com/care-giver-app/care-giver-api/internal/ical"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/schedule"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/repository"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
+	"go.uber.org/zap"
+)
+
+const (
+	regenerateCalendarToken = "regenerate calendar token"
+	getCalendarFeed         = "get calendar feed"
+
+	calendarFeedDatabaseError = "error retrieving calendar feed from db"
+
+	// The feed covers recent history and the schedule ahead rather than everything ever
+	// logged, which keeps it small enough for calendar apps that poll it hourly.
+	calendarFeedLookback  = 90 * 24 * time.Hour
+	calendarFeedLookahead = 30 * 24 * time.Hour
+	calendarFeedRefresh   = time.Hour
+
+	calendarUIDDomain = "caregiver"
+)
+
+type CalendarTokenResponse struct {
+	Token    string `json:"token"`
+	FeedPath string `json:"feedPath"`
+	Status   string `json:"status"`
+}
+
+// HandleRegenerateCalendarToken issues a new token for the user's calendar feed. The old
+// token stops working straight away, so this is also how a leaked feed URL is revoked. Only
+// the user themselves may do it.
+func HandleRegenerateCalendarToken(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, regenerateCalendarToken)
+
+	uid, err := validatePathParameters(params.Request, user.ParamID, user.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	if resp, ok := authorizeSelf(params, uid); !ok {
+		return resp, nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	feed, token, err := calendarfeed.NewFeed(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error("error generating calendar token", zap.String(log.UserIDLogKey, u.UserID), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	err = params.CalendarFeedRepo.PutFeed(feed)
+	if err != nil {
+		params.AppCfg.Logger.Error("error saving calendar feed to db", zap.String(log.UserIDLogKey, u.UserID), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	recordAudit(params, u.UserID, u.UserID, audit.ActionRotateCalendarToken)
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, regenerateCalendarToken)
+	return response.FormatResponse(CalendarTokenResponse{
+		Token:    token,
+		FeedPath: calendarFeedPath(u.UserID, token),
+		Status:   response.Success,
+	}, http.StatusOK), nil
+}
+
+// HandleGetCalendarFeed serves the .ics feed of logged events and upcoming scheduled care for
+// every receiver the user looks after. Calendar apps can't sign in, so the route skips the
+// authorizer and the token in the URL is checked instead.
+func HandleGetCalendarFeed(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, getCalendarFeed)
+
+	uid, err := validatePathParameters(params.Request, user.ParamID, user.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	token, err := validateQueryParameters(params.Request, calendarfeed.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, calendarfeed.ParamID), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	feed, err := params.CalendarFeedRepo.GetFeed(uid)
+	if errors.Is(err, calendarfeed.ErrNotFound) {
+		params.AppCfg.Logger.Error("calendar feed has not been set up", zap.String(log.UserIDLogKey, uid))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error(calendarFeedDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !feed.Matches(token) {
+		params.AppCfg.Logger.Error("calendar feed token does not match", zap.String(log.UserIDLogKey, uid))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	now := time.Now()
+	bound := repository.TimestampBound{
+		Lower: now.Add(-calendarFeedLookback).UTC().Format(time.RFC3339),
+		Upper: now.Add(calendarFeedLookahead).UTC().Format(time.RFC3339),
+	}
+
+	calendarEvents := []ical.Event{}
+	for _, r := range relationships {
+		receiverName := receiverDisplayName(params, r.ReceiverID)
+
+		eventsList, err := params.EventRepo.GetEvents(r.ReceiverID, bound)
+		if err != nil {
+			params.AppCfg.Logger.Error(eventDatabaseError, zap.String(log.ReceiverIDLogKey, r.ReceiverID), zap.Error(err))
+			return response.CreateInternalServerErrorResponse(), nil
+		}
+		names := caregiverNames(params, eventsList)
+		for _, e := range eventsList {
+			if ce, ok := loggedCalendarEvent(e, receiverName, names); ok {
+				calendarEvents = append(calendarEvents, ce)
+			}
+		}
+
+		schedules, err := params.ScheduleRepo.GetSchedules(r.ReceiverID)
+		if err != nil {
+			params.AppCfg.Logger.Error(scheduleDatabaseError, zap.String(log.ReceiverIDLogKey, r.ReceiverID), zap.Error(err))
+			return response.CreateInternalServerErrorResponse(), nil
+		}
+		for _, s := range schedules {
+			occurrences, err := s.Occurrences(now, now.Add(calendarFeedLookahead))
+			if err != nil {
+				params.AppCfg.Logger.Error("error expanding schedule", zap.String(log.ReceiverIDLogKey, r.ReceiverID), zap.Error(err))
+				continue
+			}
+			for _, o := range occurrences {
+				if ce, ok := scheduledCalendarEvent(o, receiverName); ok {
+					calendarEvents = append(calendarEvents, ce)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(calendarEvents, func(i, j int) bool {
+		return calendarEvents[i].Start.Before(calendarEvents[j].Start)
+	})
+
+	var buf bytes.Buffer
+	err = ical.Write(&buf, ical.Calendar{
+		Name:            "Care Calendar",
+		RefreshInterval: calendarFeedRefresh,
+		Stamp:           now,
+		Events:          calendarEvents,
+	})
+	if err != nil {
+		params.AppCfg.Logger.Error("error writing calendar feed", zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getCalendarFeed)
+	return response.FormatAttachmentResponse(buf.Bytes(), ical.ContentType, "care-calendar.ics"), nil
+}
+
+func calendarFeedPath(uid, token string) string {
+	return fmt.Sprintf("/calendar/%s/feed.ics?%s=%s", url.PathEscape(uid), calendarfeed.ParamID, url.QueryEscape(token))
+}
+
+// receiverDisplayName is best effort; the feed falls back to the receiver ID.
+func receiverDisplayName(params HandlerParams, rid string) string {
+	r, err := params.ReceiverRepo.GetReceiver(rid)
+	if err != nil {
+		params.AppCfg.Logger.Warn("unable to look up receiver", zap.String(log.ReceiverIDLogKey, rid), zap.Error(err))
+		return rid
+	}
+
+	if name := strings.TrimSpace(fmt.Sprintf("%s %s", r.FirstName, r.LastName)); name != "" {
+		return name
+	}
+	return rid
+}
+
+func loggedCalendarEvent(e event.Entry, receiverName string, names map[string]string) (ical.Event, bool) {
+	start, err := time.Parse(time.RFC3339, e.StartTime)
+	if err != nil {
+		return ical.Event{}, false
+	}
+	end, _ := time.Parse(time.RFC3339, e.EndTime)
+
+	loggedBy := e.UserID
+	if name := names[e.UserID]; name != "" {
+		loggedBy = name
+	}
+
+	description := []string{
+		fmt.Sprintf("Receiver: %s", receiverName),
+		fmt.Sprintf("Logged by: %s", loggedBy),
+	}
+	for _, dp := range e.Data {
+		description = append(description, fmt.Sprintf("%s: %s", dp.Name, dp.Value))
+	}
+	if e.Note != "" {
+		description = append(description, fmt.Sprintf("Note: %s", e.Note))
+	}
+
+	return ical.Event{
+		UID:         fmt.Sprintf("%s@%s", e.EventID, calendarUIDDomain),
+		Summary:     e.Type,
+		Description: strings.Join(description, "\n"),
+		Categories:  []string{"Logged"},
+		Start:       start,
+		End:         end,
+	}, true
+}
+
+func scheduledCalendarEvent(o schedule.Occurrence, receiverName string) (ical.Event, bool) {
+	start, err := time.Parse(time.RFC3339, o.Time)
+	if err != nil {
+		return ical.Event{}, false
+	}
+
+	description := []string{fmt.Sprintf("Receiver: %s", receiverName)}
+	if o.Title != "" {
+		description = append(description, fmt.Sprintf("Scheduled: %s", o.Title))
+	}
+
+	return ical.Event{
+		UID:         fmt.Sprintf("%s/%s@%s", o.ScheduleID, start.UTC().Format("20060102T150405Z"), calendarUIDDomain),
+		Summary:     o.Type,
+		Description: strings.Join(description, "\n"),
+		Categories:  []string{"Scheduled"},
+		Start:       start,
+	}, true
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/ical"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleRegenerateCalendarToken(t *testing.T) {
+	tests := map[string]struct {
+		userID             string
+		callerEmail        string
+		expectedStatusCode int
+	}{
+		"Happy Path - Token Issued": {
+			userID:             "User#123",
+			callerEmail:        "valid@example.com",
+			expectedStatusCode: http.StatusOK,
+		},
+		"Sad Path - Bad User ID": {
+			userID:             "Receiver#123",
+			callerEmail:        "valid@example.com",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Another User's Token": {
+			userID:             "User#123",
+			callerEmail:        "taken@test.com",
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - No Caller": {
+			userID:             "User#123",
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - Error Resolving Caller": {
+			userID:             "User#123",
+			callerEmail:        "claimerror@example.com",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		"Sad Path - Error Getting User": {
+			userID:             "User#Error",
+			callerEmail:        "usererror@example.com",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		"Sad Path - Error Saving Feed": {
+			userID:             "User#FeedError",
+			callerEmail:        "feederror@example.com",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:     http.MethodPost,
+					PathParameters: map[string]string{"userId": tc.userID},
+					RequestContext: claimsRequest(tc.callerEmail),
+				},
+				UserRepo:         testUserRepo,
+				EmailClaimRepo:   testEmailClaimRepo,
+				AuditRepo:        testAuditRepo,
+				CalendarFeedRepo: testCalendarFeedRepo,
+			}
+			resp, err := HandleRegenerateCalendarToken(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedStatusCode == http.StatusOK {
+				var body CalendarTokenResponse
+				assert.Nil(t, json.Unmarshal([]byte(resp.Body), &body))
+				assert.NotEmpty(t, body.Token)
+				assert.Equal(t, "/calendar/User%23123/feed.ics?token="+body.Token, body.FeedPath)
+			}
+		})
+	}
+}
+
+func TestHandleGetCalendarFeed(t *testing.T) {
+	tests := map[string]struct {
+		userID             string
+		queryParams        map[string]string
+		expectedStatusCode int
+		expectedLines      []string
+	}{
+		"Happy Path - Logged And Scheduled Events": {
+			userID:             "User#Syncer",
+			queryParams:        map[string]string{"token": testCalendarToken},
+			expectedStatusCode: http.StatusOK,
+			expectedLines: []string{
+				"BEGIN:VCALENDAR",
+				"UID:Event#9c1f3a52-5d0e-4d8c-8f6e-0a4b1c2d3e4f@caregiver",
+				"DTSTART:20231001T120000Z",
+				"DTEND:20231001T123000Z",
+				"SUMMARY:Shower",
+				"SUMMARY:Weight",
+				"CATEGORIES:Logged",
+				"CATEGORIES:Scheduled",
+			},
+		},
+		"Sad Path - Wrong Token": {
+			userID:             "User#Syncer",
+			queryParams:        map[string]string{"token": "not-the-token"},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - Feed Not Set Up": {
+			userID:             "User#123",
+			queryParams:        map[string]string{"token": testCalendarToken},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - Missing Token": {
+			userID:             "User#Syncer",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Bad User ID": {
+			userID:             "Receiver#Sync",
+			queryParams:        map[string]string{"token": testCalendarToken},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Error Getting Feed": {
+			userID:             "User#Error",
+			queryParams:        map[string]string{"token": testCalendarToken},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		"Sad Path - Error Getting Relationships": {
+			userID:             "User#RelationshipError",
+			queryParams:        map[string]string{"token": testCalendarToken},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodGet,
+					PathParameters:        map[string]string{"userId": tc.userID},
+					QueryStringParameters: tc.queryParams,
+				},
+				UserRepo:         testUserRepo,
+				ReceiverRepo:     testReceiverRepo,
+				EventRepo:        testEventRepo,
+				RelationshipRepo: testRelationshipRepo,
+				ScheduleRepo:     testScheduleRepo,
+				CalendarFeedRepo: testCalendarFeedRepo,
+			}
+			resp, err := HandleGetCalendarFeed(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedStatusCode != http.StatusOK {
+				return
+			}
+			assert.Equal(t, ical.ContentType, resp.Headers["Content-Type"])
+			lines := strings.Split(resp.Body, "\r\n")
+			for _, line := range tc.expectedLines {
+				assert.Contains(t, lines, line)
+			}
+		})
+	}
+}
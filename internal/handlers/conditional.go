@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"go.uber.org/zap"
+)
+
+// notModified answers a GET whose If-None-Match names the representation the handler just
+// produced with a 304, so polling clients skip the body.
+func notModified(request events.APIGatewayProxyRequest, resp events.APIGatewayProxyResponse) events.APIGatewayProxyResponse {
+	if request.HTTPMethod != http.MethodGet || resp.StatusCode != http.StatusOK {
+		return resp
+	}
+
+	if response.ETagMatches(requestHeader(request, "If-None-Match"), resp.Headers["ETag"], true) {
+		return response.CreateNotModifiedResponse(resp)
+	}
+	return resp
+}
+
+// checkIfMatch rejects a mutation whose If-Match doesn't name the current representation of
+// the resource. The current representation is whatever the GET handler for the same path
+// returns. A path that can't be read has no ETag to compare against, so any If-Match other
+// than "*" fails there rather than being ignored. The check and the write are not atomic;
+// it catches stale clients, not simultaneous writes.
+func (r *Registry) checkIfMatch(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, bool) {
+	ifMatch := requestHeader(request, "If-Match")
+	if ifMatch == "" {
+		return events.APIGatewayProxyResponse{}, false
+	}
+
+	switch request.HTTPMethod {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return events.APIGatewayProxyResponse{}, false
+	}
+
+	endpoint, version, err := resolveEndpoint(request)
+	if err != nil {
+		return events.APIGatewayProxyResponse{}, false
+	}
+	path := endpoint.Path
+	read, _, ok := lookupHandler(Endpoint{Path: path, Method: http.MethodGet}, version)
+	if !ok {
+		if strings.TrimSpace(ifMatch) == "*" {
+			return events.APIGatewayProxyResponse{}, false
+		}
+
+		if r.AppCfg != nil {
+			r.AppCfg.Logger.Warn("if-match precondition cannot be checked", zap.String(log.PathLogKey, path), zap.String(log.MethodLogKey, request.HTTPMethod))
+		}
+		return response.CreatePreconditionFailedResponse(), true
+	}
+
+	readRequest := request
+	readRequest.HTTPMethod = http.MethodGet
+	readRequest.Body = ""
+	current, err := read(ctx, r.params(readRequest))
+	if err == nil && current.StatusCode == http.StatusOK && response.ETagMatches(ifMatch, current.Headers["ETag"], false) {
+		return events.APIGatewayProxyResponse{}, false
+	}
+
+	if r.AppCfg != nil {
+		r.AppCfg.Logger.Warn("if-match precondition failed", zap.String(log.PathLogKey, path), zap.String(log.MethodLogKey, request.HTTPMethod), zap.Int("currentStatus", current.StatusCode))
+	}
+	return response.CreatePreconditionFailedResponse(), true
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+type testDocument struct {
+	Version int    `json:"version"`
+	Status  string `json:"status"`
+}
+
+func TestNotModified(t *testing.T) {
+	current := response.FormatResponse(testDocument{Version: 1, Status: response.Success}, http.StatusOK)
+	etag := current.Headers["ETag"]
+
+	tests := map[string]struct {
+		method         string
+		ifNoneMatch    string
+		resp           events.APIGatewayProxyResponse
+		expectedStatus int
+	}{
+		"Happy Path - Matching ETag": {
+			method:         http.MethodGet,
+			ifNoneMatch:    etag,
+			resp:           current,
+			expectedStatus: http.StatusNotModified,
+		},
+		"Happy Path - Weak Matching ETag": {
+			method:         http.MethodGet,
+			ifNoneMatch:    "W/" + etag,
+			resp:           current,
+			expectedStatus: http.StatusNotModified,
+		},
+		"Sad Path - Stale ETag": {
+			method:         http.MethodGet,
+			ifNoneMatch:    `"stale"`,
+			resp:           current,
+			expectedStatus: http.StatusOK,
+		},
+		"Sad Path - Not A GET": {
+			method:         http.MethodPost,
+			ifNoneMatch:    etag,
+			resp:           current,
+			expectedStatus: http.StatusOK,
+		},
+		"Sad Path - Error Response": {
+			method:         http.MethodGet,
+			ifNoneMatch:    "*",
+			resp:           response.CreateBadRequestResponse(),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := events.APIGatewayProxyRequest{
+				HTTPMethod: tc.method,
+				Headers:    map[string]string{"if-none-match": tc.ifNoneMatch},
+			}
+			resp := notModified(request, tc.resp)
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			if tc.expectedStatus == http.StatusNotModified {
+				assert.Equal(t, "", resp.Body)
+				assert.Equal(t, etag, resp.Headers["ETag"])
+			}
+		})
+	}
+}
+
+func TestRunHandlerConditionalRequests(t *testing.T) {
+	original := handlersMap
+	defer func() { handlersMap = original }()
+
+	read := func(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+		return response.FormatResponse(testDocument{Version: 1, Status: response.Success}, http.StatusOK), nil
+	}
+	write := func(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+		return response.FormatResponse(testDocument{Version: 2, Status: response.Success}, http.StatusOK), nil
+	}
+	handlersMap = map[Endpoint]HandlerFunc{
+		{Path: "/document/{documentId}", Method: http.MethodGet}: read,
+		{Path: "/document/{documentId}", Method: http.MethodPut}: write,
+		{Path: "/archive/{documentId}", Method: http.MethodPut}:  write,
+	}
+	currentETag := response.FormatResponse(testDocument{Version: 1, Status: response.Success}, http.StatusOK).Headers["ETag"]
+
+	tests := map[string]struct {
+		path           string
+		method         string
+		headers        map[string]string
+		expectedStatus int
+	}{
+		"Happy Path - GET With Current ETag": {
+			path:           "/Stage/document/{documentId}",
+			method:         http.MethodGet,
+			headers:        map[string]string{"If-None-Match": currentETag},
+			expectedStatus: http.StatusNotModified,
+		},
+		"Happy Path - PUT With Current ETag": {
+			path:           "/Stage/document/{documentId}",
+			method:         http.MethodPut,
+			headers:        map[string]string{"If-Match": currentETag},
+			expectedStatus: http.StatusOK,
+		},
+		"Happy Path - PUT With Gzip ETag": {
+			path:           "/document/{documentId}",
+			method:         http.MethodPut,
+			headers:        map[string]string{"If-Match": strings.TrimSuffix(currentETag, `"`) + `-gzip"`},
+			expectedStatus: http.StatusOK,
+		},
+		"Happy Path - PUT Without If-Match": {
+			path:           "/document/{documentId}",
+			method:         http.MethodPut,
+			expectedStatus: http.StatusOK,
+		},
+		"Happy Path - PUT Without A Readable Representation And Any ETag": {
+			path:           "/archive/{documentId}",
+			method:         http.MethodPut,
+			headers:        map[string]string{"If-Match": "*"},
+			expectedStatus: http.StatusOK,
+		},
+		"Sad Path - PUT Without A Readable Representation": {
+			path:           "/archive/{documentId}",
+			method:         http.MethodPut,
+			headers:        map[string]string{"If-Match": `"stale"`},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		"Sad Path - PUT With Stale ETag": {
+			path:           "/document/{documentId}",
+			method:         http.MethodPut,
+			headers:        map[string]string{"If-Match": `"stale"`},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		"Sad Path - PUT With Weak ETag": {
+			path:           "/document/{documentId}",
+			method:         http.MethodPut,
+			headers:        map[string]string{"If-Match": "W/" + currentETag},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+	}
+
+	testRegistry := NewRegistry(appconfig.NewAppConfig(), nil, nil, nil, nil)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := events.APIGatewayProxyRequest{
+				HTTPMethod: tc.method,
+				Headers:    tc.headers,
+				RequestContext: events.APIGatewayProxyRequestContext{
+					ResourcePath: tc.path,
+				},
+			}
+			handler, ok := testRegistry.GetHandler(request)
+			assert.True(t, ok)
+
+			resp, err := testRegistry.RunHandler(context.Background(), handler, request)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestCheckIfMatchWithoutReadableRoute(t *testing.T) {
+	tests := map[string]struct {
+		path   string
+		method string
+	}{
+		"Update Medication": {
+			path:   "/medication/{medicationId}",
+			method: http.MethodPut,
+		},
+		"Delete Event": {
+			path:   "/event/{eventId}",
+			method: http.MethodDelete,
+		},
+		"Delete Schedule": {
+			path:   "/schedule/{scheduleId}",
+			method: http.MethodDelete,
+		},
+		"Delete Alert Rule": {
+			path:   "/alert-rule/{ruleId}",
+			method: http.MethodDelete,
+		},
+	}
+
+	testRegistry := NewRegistry(appconfig.NewAppConfig(), nil, nil, nil, nil)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := events.APIGatewayProxyRequest{
+				HTTPMethod: tc.method,
+				Headers:    map[string]string{"If-Match": `"abc"`},
+				RequestContext: events.APIGatewayProxyRequestContext{
+					ResourcePath: tc.path,
+				},
+			}
+
+			resp, failed := testRegistry.checkIfMatch(context.Background(), request)
+			assert.True(t, failed)
+			assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+		})
+	}
+}
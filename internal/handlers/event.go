@@ -2,10 +2,18 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"sort"
+	"strconv"
+	"time"
 
 	awsevents "github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/changelog"
+	"github.com/care-giver-app/care-giver-api/internal/eventbatch"
 	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/tombstone"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
@@ -16,10 +24,13 @@ import (
 )
 
 const (
-	addReceiverEvent    = "add receiver event"
-	deleteReceiverEvent = "delete receiver event"
-	getReceiverEvents   = "get receiver events"
-	getEventConfigs     = "get event configs"
+	addReceiverEvent     = "add receiver event"
+	deleteReceiverEvent  = "delete receiver event"
+	restoreReceiverEvent = "restore receiver event"
+	getReceiverEvents    = "get receiver events"
+	getEventConfigs      = "get event configs"
+
+	includeDeletedParam = "includeDeleted"
 )
 
 type ReceiverEventRequest struct {
@@ -29,7 +40,7 @@ type ReceiverEventRequest struct {
 	StartTime  string            `json:"startTime" validate:"required"`
 	EndTime    string            `json:"endTime" validate:"required"`
 	Data       []event.DataPoint `json:"data"`
-	Note       string            `json:"note"`
+	Note       string            `json:"note" validate:"omitempty,max=2000,freetext"`
 }
 
 type ReceiverEventResponse struct {
@@ -38,6 +49,22 @@ type ReceiverEventResponse struct {
 	Status     string `json:"status"`
 }
 
+// GetReceiverEventsResponse is the v2 body of GET /receiver/{receiverId}/events.
+type GetReceiverEventsResponse struct {
+	ReceiverID string               `json:"receiverId"`
+	Events     []ReceiverEventEntry `json:"events"`
+	Status     string               `json:"status"`
+}
+
+// ReceiverEventEntry is an event as listed to a caregiver. The deletion fields are only set
+// on deleted events, so a live entry serializes exactly like a bare event.Entry.
+type ReceiverEventEntry struct {
+	event.Entry
+	Deleted   bool   `json:"deleted,omitempty"`
+	DeletedBy string `json:"deletedBy,omitempty"`
+	DeletedAt string `json:"deletedAt,omitempty"`
+}
+
 func HandleReceiverEvent(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
 	params.AppCfg.Logger.Sugar().Infof(handlerStart, addReceiverEvent)
 	params.AppCfg.Logger.Info("handling add receiver event")
@@ -46,7 +73,7 @@ func HandleReceiverEvent(ctx context.Context, params HandlerParams) (awsevents.A
 	err := readRequestBody(params.Request.Body, &rer)
 	if err != nil {
 		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
-		return response.CreateBadRequestResponse(), nil
+		return createRequestBodyErrorResponse(err), nil
 	}
 
 	err = validateTimestamps(rer.StartTime, rer.EndTime)
@@ -72,16 +99,7 @@ func HandleReceiverEvent(ctx context.Context, params HandlerParams) (awsevents.A
 		return response.CreateAccessDeniedResponse(), nil
 	}
 
-	opts := []event.EntryOption{}
-	if len(rer.Data) > 0 {
-		opts = append(opts, event.WithData(rer.Data))
-	}
-
-	if rer.Note != "" {
-		opts = append(opts, event.WithNote(rer.Note))
-	}
-
-	newEvent, err := event.NewEntry(rer.ReceiverID, u.UserID, rer.Type, rer.StartTime, rer.EndTime, opts...)
+	newEvent, err := event.NewEntry(rer.ReceiverID, u.UserID, rer.Type, rer.StartTime, rer.EndTime, entryOptions(rer.Data, rer.Note)...)
 	if err != nil {
 		params.AppCfg.Logger.Error("error creating new event entry", zap.Error(err))
 		return response.CreateBadRequestResponse(), nil
@@ -93,6 +111,14 @@ func HandleReceiverEvent(ctx context.Context, params HandlerParams) (awsevents.A
 		return response.CreateInternalServerErrorResponse(), nil
 	}
 
+	recordChange(params, changelog.OperationUpsert, *newEvent, u.UserID)
+	recordAudit(params, rer.ReceiverID, u.UserID, audit.ActionAddEvent,
+		audit.WithTarget(event.ParamID, newEvent.EventID),
+		audit.WithAfter(newEvent),
+	)
+	notifyAlertBreaches(ctx, params, *newEvent, u)
+	queueActivityNotifications(params, *newEvent, u)
+
 	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, addReceiverEvent)
 	return response.FormatResponse(ReceiverEventResponse{
 		ReceiverID: rer.ReceiverID,
@@ -139,12 +165,38 @@ func HandleDeleteReceiverEvent(ctx context.Context, params HandlerParams) (awsev
 		return response.CreateAccessDeniedResponse(), nil
 	}
 
+	found, err := params.EventBatchRepo.GetEvent(rid, eid)
+	if errors.Is(err, eventbatch.ErrEventNotFound) {
+		params.AppCfg.Logger.Error("event not found", zap.String(log.ReceiverIDLogKey, rid), zap.String(event.ParamID, eid))
+		return response.CreateResourceNotFoundResponse(), nil
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error(eventDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+	e := *found
+
+	err = params.TombstoneRepo.AddTombstone(tombstone.NewTombstone(e, u.UserID, params.AppCfg.EventRetention()))
+	if err != nil {
+		params.AppCfg.Logger.Error("error adding tombstone to db", zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
 	err = params.EventRepo.DeleteEvent(rid, eid)
 	if err != nil {
 		params.AppCfg.Logger.Error("error deleting event from db", zap.Error(err))
+		if err := params.TombstoneRepo.DeleteTombstone(rid, eid); err != nil {
+			params.AppCfg.Logger.Error("error removing tombstone for event that was not deleted", zap.Error(err))
+		}
 		return response.CreateInternalServerErrorResponse(), nil
 	}
 
+	recordChange(params, changelog.OperationDelete, e, u.UserID)
+	recordAudit(params, rid, u.UserID, audit.ActionDeleteEvent,
+		audit.WithTarget(event.ParamID, eid),
+		audit.WithBefore(e),
+	)
+
 	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, deleteReceiverEvent)
 	return response.FormatResponse(
 		map[string]string{
@@ -156,33 +208,77 @@ func HandleDeleteReceiverEvent(ctx context.Context, params HandlerParams) (awsev
 func HandleGetReceiverEvents(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
 	params.AppCfg.Logger.Sugar().Infof(handlerStart, getReceiverEvents)
 
+	_, eventsList, errResp, ok := receiverEvents(params)
+	if !ok {
+		return errResp, nil
+	}
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getReceiverEvents)
+	return response.FormatResponse(eventsList, http.StatusOK), nil
+}
+
+// HandleGetReceiverEventsV2 wraps the event list in an object so fields can be added to the
+// response without breaking clients.
+func HandleGetReceiverEventsV2(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, getReceiverEvents)
+
+	rid, eventsList, errResp, ok := receiverEvents(params)
+	if !ok {
+		return errResp, nil
+	}
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getReceiverEvents)
+	return response.FormatResponse(GetReceiverEventsResponse{
+		ReceiverID: rid,
+		Events:     eventsList,
+		Status:     response.Success,
+	}, http.StatusOK), nil
+}
+
+// receiverEvents loads the events a caregiver asked for, including deleted ones when
+// includeDeleted is set. On failure it returns the response to send.
+func receiverEvents(params HandlerParams) (string, []ReceiverEventEntry, awsevents.APIGatewayProxyResponse, bool) {
 	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
 	if err != nil {
 		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
-		return response.CreateBadRequestResponse(), nil
+		return "", nil, response.CreateBadRequestResponse(), false
 	}
 
 	uid, err := validateQueryParameters(params.Request, user.ParamID)
 	if err != nil {
 		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
-		return response.CreateBadRequestResponse(), nil
+		return "", nil, response.CreateBadRequestResponse(), false
 	}
 
 	u, err := params.UserRepo.GetUser(uid)
 	if err != nil {
 		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
-		return response.CreateInternalServerErrorResponse(), nil
+		return "", nil, response.CreateInternalServerErrorResponse(), false
 	}
 
 	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(uid)
 	if err != nil {
 		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
-		return response.CreateInternalServerErrorResponse(), nil
+		return "", nil, response.CreateInternalServerErrorResponse(), false
 	}
 
 	if !relationship.IsACareGiver(uid, rid, relationships) {
 		params.AppCfg.Logger.Sugar().Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
-		return response.CreateAccessDeniedResponse(), nil
+		return "", nil, response.CreateAccessDeniedResponse(), false
+	}
+
+	includeDeleted := false
+	if value := params.Request.QueryStringParameters[includeDeletedParam]; value != "" {
+		includeDeleted, err = strconv.ParseBool(value)
+		if err != nil {
+			params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, includeDeletedParam), zap.Error(err))
+			return "", nil, response.CreateBadRequestResponse(), false
+		}
+	}
+
+	if includeDeleted && !relationship.IsAPrimaryCareGiver(uid, rid, relationships) {
+		params.AppCfg.Logger.Error("only primary care givers can view deleted events", zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, uid))
+		return "", nil, response.CreateAccessDeniedResponse(), false
 	}
 
 	bound := repository.TimestampBound{}
@@ -191,18 +287,107 @@ func HandleGetReceiverEvents(ctx context.Context, params HandlerParams) (awseven
 	if startTime != "" && endTime != "" {
 		if err := validateTimestamps(startTime, endTime); err != nil {
 			params.AppCfg.Logger.Error("invalid date bound query params", zap.Error(err))
-			return response.CreateBadRequestResponse(), nil
+			return "", nil, response.CreateBadRequestResponse(), false
 		}
 		bound = repository.TimestampBound{Lower: startTime, Upper: endTime}
 	}
 	eventsList, err := params.EventRepo.GetEvents(rid, bound)
 	if err != nil {
-		params.AppCfg.Logger.Error("error retrieving events from db", zap.Error(err))
+		params.AppCfg.Logger.Error(eventDatabaseError, zap.Error(err))
+		return "", nil, response.CreateInternalServerErrorResponse(), false
+	}
+
+	if !includeDeleted {
+		live := make([]ReceiverEventEntry, 0, len(eventsList))
+		for _, e := range eventsList {
+			live = append(live, ReceiverEventEntry{Entry: e})
+		}
+		return rid, live, awsevents.APIGatewayProxyResponse{}, true
+	}
+
+	tombstones, err := params.TombstoneRepo.GetTombstones(rid)
+	if err != nil {
+		params.AppCfg.Logger.Error(tombstoneDatabaseError, zap.Error(err))
+		return "", nil, response.CreateInternalServerErrorResponse(), false
+	}
+
+	return rid, mergeDeletedEvents(eventsList, tombstones, bound), awsevents.APIGatewayProxyResponse{}, true
+}
+
+func HandleRestoreReceiverEvent(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, restoreReceiverEvent)
+
+	eid, err := validatePathParameters(params.Request, event.ParamID, event.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, event.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	rid, err := validateQueryParameters(params.Request, receiver.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
 		return response.CreateInternalServerErrorResponse(), nil
 	}
 
-	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getReceiverEvents)
-	return response.FormatResponse(eventsList, http.StatusOK), nil
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(uid, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	ts, err := params.TombstoneRepo.GetTombstone(rid, eid)
+	if errors.Is(err, tombstone.ErrNotFound) {
+		params.AppCfg.Logger.Error("deleted event not found", zap.String(log.ReceiverIDLogKey, rid), zap.String(event.ParamID, eid))
+		return response.CreateResourceNotFoundResponse(), nil
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error(tombstoneDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	restored := ts.Event
+	err = params.EventRepo.AddEvent(&restored)
+	if err != nil {
+		params.AppCfg.Logger.Error("error adding event to db", zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	// Restoring is idempotent, so a failure here is safe for the client to retry.
+	err = params.TombstoneRepo.DeleteTombstone(rid, eid)
+	if err != nil {
+		params.AppCfg.Logger.Error("error deleting tombstone from db", zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	recordChange(params, changelog.OperationUpsert, restored, u.UserID)
+	recordAudit(params, rid, u.UserID, audit.ActionRestoreEvent,
+		audit.WithTarget(event.ParamID, eid),
+		audit.WithAfter(restored),
+	)
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, restoreReceiverEvent)
+	return response.FormatResponse(ReceiverEventResponse{
+		ReceiverID: rid,
+		EventID:    eid,
+		Status:     response.Success,
+	}, http.StatusOK), nil
 }
 
 func HandleGetEventConfigs(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
@@ -216,3 +401,54 @@ func HandleGetEventConfigs(ctx context.Context, params HandlerParams) (awsevents
 
 	return response.FormatResponse(eventConfigs, http.StatusOK), nil
 }
+
+func entryOptions(data []event.DataPoint, note string) []event.EntryOption {
+	opts := []event.EntryOption{}
+	if len(data) > 0 {
+		opts = append(opts, event.WithData(data))
+	}
+
+	if note != "" {
+		opts = append(opts, event.WithNote(note))
+	}
+	return opts
+}
+
+func mergeDeletedEvents(eventsList []event.Entry, tombstones []tombstone.Tombstone, bound repository.TimestampBound) []ReceiverEventEntry {
+	merged := make([]ReceiverEventEntry, 0, len(eventsList)+len(tombstones))
+	for _, e := range eventsList {
+		merged = append(merged, ReceiverEventEntry{Entry: e})
+	}
+
+	for _, ts := range tombstones {
+		if !withinBound(ts.Event.StartTime, bound) {
+			continue
+		}
+		merged = append(merged, ReceiverEventEntry{
+			Entry:     ts.Event,
+			Deleted:   true,
+			DeletedBy: ts.DeletedBy,
+			DeletedAt: ts.DeletedAt,
+		})
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].StartTime < merged[j].StartTime
+	})
+	return merged
+}
+
+func withinBound(timestamp string, bound repository.TimestampBound) bool {
+	if bound.Lower == "" || bound.Upper == "" {
+		return true
+	}
+
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return false
+	}
+
+	lower, _ := time.Parse(time.RFC3339, bound.Lower)
+	upper, _ := time.Parse(time.RFC3339, bound.Upper)
+	return !t.Before(lower) && !t.After(upper)
+}
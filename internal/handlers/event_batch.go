@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	awsevents "github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/changelog"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/validation"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/relationship"
+	"go.uber.org/zap"
+)
+
+const (
+	addReceiverEventBatch = "add receiver event batch"
+
+	batchItemFailed     = "Failed"
+	batchPartialSuccess = "Multi-Status"
+
+	batchInvalidTimestampsError = "startTime and endTime must be valid RFC3339 timestamps"
+	batchInvalidEventError      = "event could not be created from the request"
+	batchNotCareGiverError      = "user is not a caregiver for the receiver"
+	batchWriteError             = "event could not be saved"
+)
+
+type BatchEventRequest struct {
+	UserID string               `json:"userId" validate:"required"`
+	Events []BatchEventItemData `json:"events" validate:"required,min=1"`
+}
+
+type BatchEventItemData struct {
+	ReceiverID string            `json:"receiverId" validate:"required"`
+	Type       string            `json:"type" validate:"required"`
+	StartTime  string            `json:"startTime" validate:"required"`
+	EndTime    string            `json:"endTime" validate:"required"`
+	Data       []event.DataPoint `json:"data"`
+	Note       string            `json:"note" validate:"omitempty,max=2000,freetext"`
+}
+
+// BatchEventResult reports the outcome of one item, in the same position as the request.
+type BatchEventResult struct {
+	Index      int                   `json:"index"`
+	ReceiverID string                `json:"receiverId"`
+	EventID    string                `json:"eventId,omitempty"`
+	Status     string                `json:"status"`
+	Error      string                `json:"error,omitempty"`
+	Errors     []response.FieldError `json:"errors,omitempty"`
+}
+
+type BatchEventResponse struct {
+	Results   []BatchEventResult `json:"results"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Status    string             `json:"status"`
+}
+
+func HandleReceiverEventBatch(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, addReceiverEventBatch)
+
+	var ber BatchEventRequest
+	err := readRequestBody(params.Request.Body, &ber)
+	if err != nil {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		return createRequestBodyErrorResponse(err), nil
+	}
+
+	if len(ber.Events) > params.AppCfg.MaxBatchEvents {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Int("count", len(ber.Events)), zap.Int("max", params.AppCfg.MaxBatchEvents))
+		return response.CreateValidationErrorResponse([]response.FieldError{
+			{Field: "events", Message: fmt.Sprintf("must contain at most %d events", params.AppCfg.MaxBatchEvents)},
+		}), nil
+	}
+
+	u, err := params.UserRepo.GetUser(ber.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, ber.UserID), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	results := make([]BatchEventResult, len(ber.Events))
+	resultIndex := make(map[string]int, len(ber.Events))
+	authorized := map[string]bool{}
+	entries := []*event.Entry{}
+
+	for i, item := range ber.Events {
+		results[i] = BatchEventResult{Index: i, ReceiverID: item.ReceiverID, Status: batchItemFailed}
+
+		if err := validation.Struct(item); err != nil {
+			results[i].Errors = validation.FieldErrors(err)
+			continue
+		}
+
+		if err := validateTimestamps(item.StartTime, item.EndTime); err != nil {
+			results[i].Error = batchInvalidTimestampsError
+			continue
+		}
+
+		isCareGiver, checked := authorized[item.ReceiverID]
+		if !checked {
+			isCareGiver = relationship.IsACareGiver(u.UserID, item.ReceiverID, relationships)
+			authorized[item.ReceiverID] = isCareGiver
+			if !isCareGiver {
+				params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, item.ReceiverID), zap.String(log.UserIDLogKey, u.UserID))
+			}
+		}
+		if !isCareGiver {
+			results[i].Error = batchNotCareGiverError
+			continue
+		}
+
+		newEvent, err := event.NewEntry(item.ReceiverID, u.UserID, item.Type, item.StartTime, item.EndTime, entryOptions(item.Data, item.Note)...)
+		if err != nil {
+			params.AppCfg.Logger.Error("error creating new event entry", zap.Int("index", i), zap.Error(err))
+			results[i].Error = batchInvalidEventError
+			continue
+		}
+
+		results[i].EventID = newEvent.EventID
+		results[i].Status = response.Success
+		resultIndex[newEvent.EventID] = i
+		entries = append(entries, newEvent)
+	}
+
+	if len(entries) > 0 {
+		unprocessed, err := params.EventBatchRepo.AddEvents(entries)
+		if err != nil {
+			params.AppCfg.Logger.Error("error adding event batch to db", zap.Int("unprocessed", len(unprocessed)), zap.Error(err))
+		}
+
+		for _, e := range unprocessed {
+			i := resultIndex[e.EventID]
+			results[i].EventID = ""
+			results[i].Status = batchItemFailed
+			results[i].Error = batchWriteError
+		}
+
+		for _, e := range entries {
+			if results[resultIndex[e.EventID]].Status != response.Success {
+				continue
+			}
+			recordChange(params, changelog.OperationUpsert, *e, u.UserID)
+			recordAudit(params, e.ReceiverID, u.UserID, audit.ActionAddEvent,
+				audit.WithTarget(event.ParamID, e.EventID),
+				audit.WithAfter(e),
+			)
+		}
+	}
+
+	resp := BatchEventResponse{Results: results, Status: response.Success}
+	for _, result := range results {
+		if result.Status == response.Success {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	statusCode := http.StatusOK
+	if resp.Failed > 0 {
+		resp.Status = batchPartialSuccess
+		statusCode = http.StatusMultiStatus
+	}
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, addReceiverEventBatch)
+	return response.FormatResponse(resp, statusCode), nil
+}
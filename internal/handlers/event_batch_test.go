@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func batchItem(rid string) map[string]interface{} {
+	return map[string]interface{}{
+		"receiverId": rid,
+		"type":       "Shower",
+		"startTime":  "2023-10-01T12:00:00Z",
+		"endTime":    "2023-10-01T12:30:00Z",
+	}
+}
+
+func TestHandleReceiverEventBatch(t *testing.T) {
+	tooMany := []interface{}{}
+	for i := 0; i < 101; i++ {
+		tooMany = append(tooMany, batchItem("Receiver#123"))
+	}
+
+	tests := map[string]struct {
+		requestBody        map[string]interface{}
+		expectedStatusCode int
+		expectedResults    []BatchEventResult
+	}{
+		"Happy Path - Events Added": {
+			requestBody: map[string]interface{}{
+				"userId": "User#123",
+				"events": []interface{}{batchItem("Receiver#123"), batchItem("Receiver#123")},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResults: []BatchEventResult{
+				{Index: 0, ReceiverID: "Receiver#123", Status: response.Success},
+				{Index: 1, ReceiverID: "Receiver#123", Status: response.Success},
+			},
+		},
+		"Happy Path - Partial Success": {
+			requestBody: map[string]interface{}{
+				"userId": "User#123",
+				"events": []interface{}{
+					batchItem("Receiver#123"),
+					batchItem("Receiver#NotMine"),
+					map[string]interface{}{"receiverId": "Receiver#123", "type": "Shower", "startTime": "yesterday", "endTime": "today"},
+					map[string]interface{}{"receiverId": "Receiver#123", "type": "badEventType", "startTime": "2023-10-01T12:00:00Z", "endTime": "2023-10-01T12:30:00Z"},
+					map[string]interface{}{"receiverId": "Receiver#123"},
+					batchItem("Receiver#Error"),
+				},
+			},
+			expectedStatusCode: http.StatusMultiStatus,
+			expectedResults: []BatchEventResult{
+				{Index: 0, ReceiverID: "Receiver#123", Status: response.Success},
+				{Index: 1, ReceiverID: "Receiver#NotMine", Status: batchItemFailed, Error: batchNotCareGiverError},
+				{Index: 2, ReceiverID: "Receiver#123", Status: batchItemFailed, Error: batchInvalidTimestampsError},
+				{Index: 3, ReceiverID: "Receiver#123", Status: batchItemFailed, Error: batchInvalidEventError},
+				{Index: 4, ReceiverID: "Receiver#123", Status: batchItemFailed, Errors: []response.FieldError{
+					{Field: "type", Message: "is required"},
+					{Field: "startTime", Message: "is required"},
+					{Field: "endTime", Message: "is required"},
+				}},
+				{Index: 5, ReceiverID: "Receiver#Error", Status: batchItemFailed, Error: batchWriteError},
+			},
+		},
+		"Sad Path - No Events": {
+			requestBody: map[string]interface{}{
+				"userId": "User#123",
+				"events": []interface{}{},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Too Many Events": {
+			requestBody: map[string]interface{}{
+				"userId": "User#123",
+				"events": tooMany,
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Error Getting User": {
+			requestBody: map[string]interface{}{
+				"userId": "User#Error",
+				"events": []interface{}{batchItem("Receiver#123")},
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		"Sad Path - Error Getting Relationships": {
+			requestBody: map[string]interface{}{
+				"userId": "User#RelationshipError",
+				"events": []interface{}{batchItem("Receiver#123")},
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			requestBody, err := json.Marshal(tc.requestBody)
+			assert.Nil(t, err)
+
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPost,
+					Body:       string(requestBody),
+				},
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				AuditRepo:        testAuditRepo,
+				ChangeLogRepo:    testChangeLogRepo,
+				EventBatchRepo:   testEventBatchRepo,
+			}
+			resp, err := HandleReceiverEventBatch(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedResults == nil {
+				return
+			}
+
+			var responseBody BatchEventResponse
+			err = json.Unmarshal([]byte(resp.Body), &responseBody)
+			assert.Nil(t, err)
+
+			for i := range responseBody.Results {
+				if responseBody.Results[i].Status == response.Success {
+					assert.NotEmpty(t, responseBody.Results[i].EventID, fmt.Sprintf("result %d", i))
+					responseBody.Results[i].EventID = ""
+				}
+			}
+			assert.Equal(t, tc.expectedResults, responseBody.Results)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/notifications"
 	"github.com/care-giver-app/care-giver-api/internal/response"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
 	"github.com/stretchr/testify/assert"
@@ -15,10 +16,11 @@ import (
 
 func TestHandleReceiverEvent(t *testing.T) {
 	tests := map[string]struct {
-		requestMethod        string
-		requestBody          map[string]interface{}
-		expectedResponseBody map[string]interface{}
-		expectedStatusCode   int
+		requestMethod         string
+		requestBody           map[string]interface{}
+		expectedResponseBody  map[string]interface{}
+		expectedStatusCode    int
+		expectedNotifications []notifications.NotificationType
 	}{
 		"Happy Path - Event Added": {
 			requestMethod: http.MethodPost,
@@ -35,6 +37,23 @@ func TestHandleReceiverEvent(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		"Happy Path - Event Added - Alert Rule Breached": {
+			requestMethod: http.MethodPost,
+			requestBody: map[string]interface{}{
+				"receiverId": "Receiver#123",
+				"userID":     "User#123",
+				"type":       "Weight",
+				"startTime":  "2023-10-01T12:00:00Z",
+				"endTime":    "2023-10-01T12:00:00Z",
+				"data":       []map[string]interface{}{{"name": "weight", "value": "350"}},
+			},
+			expectedResponseBody: map[string]interface{}{
+				"status":     "Success",
+				"receiverId": "Receiver#123",
+			},
+			expectedStatusCode:    http.StatusOK,
+			expectedNotifications: []notifications.NotificationType{notifications.TypeAlert},
+		},
 		"Happy Path - Event Added - With Timestamp": {
 			requestMethod: http.MethodPost,
 			requestBody: map[string]interface{}{
@@ -79,6 +98,24 @@ func TestHandleReceiverEvent(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusBadRequest,
 		},
+		"Sad Path - Note With Control Characters": {
+			requestMethod: http.MethodPost,
+			requestBody: map[string]interface{}{
+				"receiverId": "Receiver#123",
+				"userId":     "User#123",
+				"type":       "Shower",
+				"startTime":  "2023-10-01T12:00:00Z",
+				"endTime":    "2024-10-01T12:00:00Z",
+				"note":       "some\u0000note",
+			},
+			expectedResponseBody: map[string]interface{}{
+				"status": "Bad Request",
+				"errors": []interface{}{
+					map[string]interface{}{"field": "note", "message": "must not contain control characters"},
+				},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
 		"Sad Path - Error Getting User": {
 			requestMethod: http.MethodPost,
 			requestBody: map[string]interface{}{
@@ -167,6 +204,7 @@ func TestHandleReceiverEvent(t *testing.T) {
 				Body:       string(requestBody),
 			}
 
+			publisher := notifications.NewMemoryPublisher()
 			params := HandlerParams{
 				AppCfg:           appconfig.NewAppConfig(),
 				Request:          req,
@@ -174,6 +212,12 @@ func TestHandleReceiverEvent(t *testing.T) {
 				ReceiverRepo:     testReceiverRepo,
 				EventRepo:        testEventRepo,
 				RelationshipRepo: testRelationshipRepo,
+				AuditRepo:        testAuditRepo,
+				ChangeLogRepo:    testChangeLogRepo,
+				AlertRuleRepo:    testAlertRuleRepo,
+				PreferenceRepo:   testPreferenceRepo,
+				DigestRepo:       testDigestRepo,
+				Publisher:        publisher,
 			}
 			resp, err := HandleReceiverEvent(context.Background(), params)
 			assert.Nil(t, err)
@@ -181,13 +225,21 @@ func TestHandleReceiverEvent(t *testing.T) {
 
 			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
 
-			if tc.expectedStatusCode == http.StatusOK {
+			if tc.expectedResponseBody != nil {
 				var responseBody map[string]interface{}
 				err = json.Unmarshal([]byte(resp.Body), &responseBody)
 				assert.Nil(t, err)
-				tc.expectedResponseBody["eventId"] = responseBody["eventId"]
+				if tc.expectedStatusCode == http.StatusOK {
+					tc.expectedResponseBody["eventId"] = responseBody["eventId"]
+				}
 				assert.Equal(t, tc.expectedResponseBody, responseBody)
 			}
+
+			var published []notifications.NotificationType
+			for _, n := range publisher.Notifications() {
+				published = append(published, n.NotificationType)
+			}
+			assert.Equal(t, tc.expectedNotifications, published)
 		})
 	}
 }
@@ -290,6 +342,19 @@ func TestHandleDeleteReceiverEvent(t *testing.T) {
 			},
 			expectedResponse: response.CreateAccessDeniedResponse(),
 		},
+		"Sad Path - Event Not Found": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodDelete,
+				PathParameters: map[string]string{
+					"eventId": "Event#999",
+				},
+				QueryStringParameters: map[string]string{
+					"userId":     "User#123",
+					"receiverId": "Receiver#123",
+				},
+			},
+			expectedResponse: response.CreateResourceNotFoundResponse(),
+		},
 		"Sad Path - Error Adding Event": {
 			request: events.APIGatewayProxyRequest{
 				HTTPMethod: http.MethodDelete,
@@ -314,6 +379,10 @@ func TestHandleDeleteReceiverEvent(t *testing.T) {
 				ReceiverRepo:     testReceiverRepo,
 				EventRepo:        testEventRepo,
 				RelationshipRepo: testRelationshipRepo,
+				TombstoneRepo:    testTombstoneRepo,
+				AuditRepo:        testAuditRepo,
+				ChangeLogRepo:    testChangeLogRepo,
+				EventBatchRepo:   testEventBatchRepo,
 			}
 
 			resp, err := HandleDeleteReceiverEvent(context.Background(), params)
@@ -388,6 +457,63 @@ func TestHandleGetReceiverEvents(t *testing.T) {
 				}, http.StatusOK,
 			),
 		},
+		"Happy Path - Events Retrieved Including Deleted": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"receiverId": "Receiver#123",
+				},
+				QueryStringParameters: map[string]string{
+					"userId":         "User#123",
+					"includeDeleted": "true",
+				},
+			},
+			expectedResponse: response.FormatResponse(
+				[]ReceiverEventEntry{
+					{
+						Entry: event.Entry{
+							EventID:    "Event#123",
+							ReceiverID: "Receiver#123",
+						},
+					},
+					{
+						Entry: event.Entry{
+							EventID:    "Event#Deleted",
+							ReceiverID: "Receiver#123",
+						},
+						Deleted:   true,
+						DeletedBy: "User#123",
+						DeletedAt: "2026-04-23T12:00:00Z",
+					},
+				}, http.StatusOK,
+			),
+		},
+		"Sad Path - Include Deleted Is Not A Bool": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"receiverId": "Receiver#123",
+				},
+				QueryStringParameters: map[string]string{
+					"userId":         "User#123",
+					"includeDeleted": "maybe",
+				},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Include Deleted By Non Primary Care Giver": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"receiverId": "Receiver#123",
+				},
+				QueryStringParameters: map[string]string{
+					"userId":         "User#NotAPrimaryCareGiver",
+					"includeDeleted": "true",
+				},
+			},
+			expectedResponse: response.CreateAccessDeniedResponse(),
+		},
 		"Sad Path - Bad Path Parameter - receiverId": {
 			request: events.APIGatewayProxyRequest{
 				HTTPMethod: http.MethodGet,
@@ -483,6 +609,7 @@ func TestHandleGetReceiverEvents(t *testing.T) {
 				ReceiverRepo:     testReceiverRepo,
 				EventRepo:        testEventRepo,
 				RelationshipRepo: testRelationshipRepo,
+				TombstoneRepo:    testTombstoneRepo,
 			}
 
 			resp, err := HandleGetReceiverEvents(context.Background(), params)
@@ -492,6 +619,194 @@ func TestHandleGetReceiverEvents(t *testing.T) {
 	}
 }
 
+func TestHandleGetReceiverEventsV2(t *testing.T) {
+	tests := map[string]struct {
+		request            events.APIGatewayProxyRequest
+		expectedStatusCode int
+	}{
+		"Happy Path - Events Wrapped In Response": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"receiverId": "Receiver#123",
+				},
+				QueryStringParameters: map[string]string{
+					"userId": "User#123",
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		"Sad Path - Not A Care Giver": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"receiverId": "Receiver#123",
+				},
+				QueryStringParameters: map[string]string{
+					"userId": "User#NotACareGiver",
+				},
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg:           appconfig.NewAppConfig(),
+				Request:          tc.request,
+				UserRepo:         testUserRepo,
+				EventRepo:        testEventRepo,
+				RelationshipRepo: testRelationshipRepo,
+				TombstoneRepo:    testTombstoneRepo,
+			}
+
+			resp, err := HandleGetReceiverEventsV2(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			if tc.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			v1, _ := HandleGetReceiverEvents(context.Background(), params)
+			var expectedEvents []event.Entry
+			assert.Nil(t, json.Unmarshal([]byte(v1.Body), &expectedEvents))
+
+			var body struct {
+				ReceiverID string        `json:"receiverId"`
+				Events     []event.Entry `json:"events"`
+				Status     string        `json:"status"`
+			}
+			assert.Nil(t, json.Unmarshal([]byte(resp.Body), &body))
+			assert.Equal(t, "Receiver#123", body.ReceiverID)
+			assert.Equal(t, response.Success, body.Status)
+			assert.Equal(t, expectedEvents, body.Events)
+		})
+	}
+}
+
+func TestHandleRestoreReceiverEvent(t *testing.T) {
+	tests := map[string]struct {
+		request          events.APIGatewayProxyRequest
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - Event Restored Successfully": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				PathParameters: map[string]string{
+					"eventId": "Event#Deleted",
+				},
+				QueryStringParameters: map[string]string{
+					"userId":     "User#123",
+					"receiverId": "Receiver#123",
+				},
+			},
+			expectedResponse: response.FormatResponse(ReceiverEventResponse{
+				ReceiverID: "Receiver#123",
+				EventID:    "Event#Deleted",
+				Status:     response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - Bad Path Parameter - eventId": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				PathParameters: map[string]string{
+					"NotEventId": "Event#Deleted",
+				},
+				QueryStringParameters: map[string]string{
+					"userId":     "User#123",
+					"receiverId": "Receiver#123",
+				},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Bad Query Parameter - receiverId": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				PathParameters: map[string]string{
+					"eventId": "Event#Deleted",
+				},
+				QueryStringParameters: map[string]string{
+					"userId": "User#123",
+				},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - User Is Not A Care Giver": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				PathParameters: map[string]string{
+					"eventId": "Event#Deleted",
+				},
+				QueryStringParameters: map[string]string{
+					"userId":     "User#NotACareGiver",
+					"receiverId": "Receiver#123",
+				},
+			},
+			expectedResponse: response.CreateAccessDeniedResponse(),
+		},
+		"Sad Path - Deleted Event Not Found": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				PathParameters: map[string]string{
+					"eventId": "Event#NotFound",
+				},
+				QueryStringParameters: map[string]string{
+					"userId":     "User#123",
+					"receiverId": "Receiver#123",
+				},
+			},
+			expectedResponse: response.CreateResourceNotFoundResponse(),
+		},
+		"Sad Path - Error Getting Tombstone": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				PathParameters: map[string]string{
+					"eventId": "Event#Error",
+				},
+				QueryStringParameters: map[string]string{
+					"userId":     "User#123",
+					"receiverId": "Receiver#123",
+				},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+		"Sad Path - Error Adding Event": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				PathParameters: map[string]string{
+					"eventId": "Event#Deleted",
+				},
+				QueryStringParameters: map[string]string{
+					"userId":     "User#123",
+					"receiverId": "Receiver#Error",
+				},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg:           appconfig.NewAppConfig(),
+				Request:          tc.request,
+				UserRepo:         testUserRepo,
+				ReceiverRepo:     testReceiverRepo,
+				EventRepo:        testEventRepo,
+				RelationshipRepo: testRelationshipRepo,
+				TombstoneRepo:    testTombstoneRepo,
+				AuditRepo:        testAuditRepo,
+				ChangeLogRepo:    testChangeLogRepo,
+			}
+
+			resp, err := HandleRestoreReceiverEvent(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
+
 func TestHandleGetEventConfigs(t *testing.T) {
 	tests := map[string]struct {
 		request events.APIGatewayProxyRequest
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	awsevents "github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/export"
+	"github.com/care-giver-app/care-giver-api/internal/fhir"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/relationship"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/repository"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
+	"go.uber.org/zap"
+)
+
+const (
+	exportReceiverEvents = "export receiver events"
+
+	formatParam = "format"
+
+	csvContentType = "text/csv; charset=utf-8"
+	pdfContentType = "application/pdf"
+)
+
+type exportFormat struct {
+	contentType string
+	extension   string
+}
+
+var exportFormats = map[string]exportFormat{
+	export.FormatCSV: {contentType: csvContentType, extension: "csv"},
+	export.FormatPDF: {contentType: pdfContentType, extension: "pdf"},
+	fhir.Format:      {contentType: fhir.ContentType, extension: "json"},
+}
+
+// HandleExportReceiverEvents downloads a receiver's event history as a file: a CSV of every
+// event, a printable PDF report to take to appointments, or a FHIR R4 Bundle of the
+// receiver's vital signs for clinics. Times are shown in the receiver's timezone and
+// caregivers by name, so the export reads on its own.
+func HandleExportReceiverEvents(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, exportReceiverEvents)
+
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	format := strings.ToLower(params.Request.QueryStringParameters[formatParam])
+	if format == "" {
+		format = export.FormatCSV
+	}
+	ef, ok := exportFormats[format]
+	if !ok {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, formatParam), zap.String(formatParam, format))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	mappings := params.ObservationMappings
+	if mappings == nil {
+		mappings = fhir.DefaultMappings()
+	}
+
+	bound := repository.TimestampBound{}
+	startTime := params.Request.QueryStringParameters["startTime"]
+	endTime := params.Request.QueryStringParameters["endTime"]
+	if startTime != "" || endTime != "" {
+		if err := validateTimestamps(startTime, endTime); err != nil {
+			params.AppCfg.Logger.Error("invalid date bound query params", zap.Error(err))
+			return response.CreateBadRequestResponse(), nil
+		}
+		bound = repository.TimestampBound{Lower: startTime, Upper: endTime}
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	eventsList, err := params.EventRepo.GetEvents(rid, bound)
+	if err != nil {
+		params.AppCfg.Logger.Error(eventDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	loc := receiverLocation(params, rid, u.UserID)
+	names := caregiverNames(params, eventsList)
+
+	var r receiver.Receiver
+	if format != export.FormatCSV {
+		r, err = params.ReceiverRepo.GetReceiver(rid)
+		if err != nil {
+			params.AppCfg.Logger.Error(receiverDatabaseError, zap.String(log.ReceiverIDLogKey, rid), zap.Error(err))
+			return response.CreateInternalServerErrorResponse(), nil
+		}
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case export.FormatCSV:
+		err = export.WriteCSV(&buf, eventsList, names, loc)
+	case export.FormatPDF:
+		report := export.Report{
+			ReceiverName: strings.TrimSpace(fmt.Sprintf("%s %s", r.FirstName, r.LastName)),
+			GeneratedAt:  time.Now(),
+			Location:     loc,
+			Events:       eventsList,
+			Names:        names,
+		}
+		report.From, _ = time.Parse(time.RFC3339, bound.Lower)
+		report.To, _ = time.Parse(time.RFC3339, bound.Upper)
+		err = export.WriteReport(&buf, report)
+	case fhir.Format:
+		err = fhir.WriteBundle(&buf, r, eventsList, mappings, time.Now())
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error("error writing export", zap.String(log.ReceiverIDLogKey, rid), zap.String(formatParam, format), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	recordAudit(params, rid, u.UserID, audit.ActionExportEvents, audit.WithAfter(map[string]string{
+		formatParam: format,
+		"startTime": startTime,
+		"endTime":   endTime,
+	}))
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, exportReceiverEvents)
+	return response.FormatAttachmentResponse(buf.Bytes(), ef.contentType, exportFilename(rid, ef.extension, time.Now().In(loc))), nil
+}
+
+// receiverLocation is the timezone a receiver's care happens in. Receivers don't carry one, so
+// it comes from the primary caregiver's profile, then the requester's, then UTC.
+func receiverLocation(params HandlerParams, rid, fallbackUID string) *time.Location {
+	uids := []string{}
+	relationships, err := params.RelationshipRepo.GetRelationshipsByReceiver(rid)
+	if err != nil {
+		params.AppCfg.Logger.Warn("unable to look up primary care giver", zap.String(log.ReceiverIDLogKey, rid), zap.Error(err))
+	}
+	for _, r := range relationships {
+		if r.PrimaryCareGiver {
+			uids = append(uids, r.UserID)
+		}
+	}
+	uids = append(uids, fallbackUID)
+
+	for _, uid := range uids {
+		p, err := params.ProfileRepo.GetProfile(uid)
+		if err != nil {
+			params.AppCfg.Logger.Error("unable to load profile for receiver timezone", zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, uid), zap.Error(err))
+			continue
+		}
+		if p.Timezone == "" {
+			continue
+		}
+		return p.Location()
+	}
+
+	params.AppCfg.Logger.Warn("unable to load receiver timezone, using UTC", zap.String(log.ReceiverIDLogKey, rid))
+	return time.UTC
+}
+
+// caregiverNames looks each caregiver up once, leaving out anyone without a name.
+func caregiverNames(params HandlerParams, events []event.Entry) map[string]string {
+	names := map[string]string{}
+	for _, e := range events {
+		if _, ok := names[e.UserID]; ok || e.UserID == "" {
+			continue
+		}
+		names[e.UserID] = contributorName(params, e.UserID)
+	}
+	return names
+}
+
+func exportFilename(rid, extension string, now time.Time) string {
+	id := strings.TrimPrefix(rid, receiver.DBPrefix+"#")
+	return fmt.Sprintf("care-events-%s-%s.%s", id, now.Format("20060102"), extension)
+}
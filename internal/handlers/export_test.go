@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/fhir"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestHandleExportReceiverEvents(t *testing.T) {
+	tests := map[string]struct {
+		receiverID          string
+		queryParams         map[string]string
+		fhirMappings        []fhir.Mapping
+		expectedStatusCode  int
+		expectedContentType string
+		expectedLOINC       string
+		expectedBody        string
+	}{
+		"Happy Path - CSV In Primary Care Giver Timezone": {
+			receiverID:         "Receiver#Export",
+			queryParams:        map[string]string{"userId": "User#Exporter"},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: "Event ID,Type,Start Time (America/Chicago),End Time (America/Chicago),Logged By,Note,Weight: weight\n" +
+				"Event#Shower,Shower,2025-03-10 08:00,2025-03-10 08:30,User#Gone,,\n" +
+				"Event#Weight,Weight,2025-03-10 09:00,2025-03-10 09:00,Jane Smith,After breakfast,150.5\n",
+		},
+		"Happy Path - Explicit Format And Time Range": {
+			receiverID: "Receiver#Export",
+			queryParams: map[string]string{
+				"userId":    "User#Exporter",
+				"format":    "CSV",
+				"startTime": "2025-03-01T00:00:00Z",
+				"endTime":   "2025-04-01T00:00:00Z",
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		"Happy Path - Falls Back To UTC": {
+			receiverID:         "Receiver#123",
+			queryParams:        map[string]string{"userId": "User#123"},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: "Event ID,Type,Start Time (UTC),End Time (UTC),Logged By,Note\n" +
+				"Event#123,,,,,\n",
+		},
+		"Happy Path - PDF Report": {
+			receiverID: "Receiver#Export",
+			queryParams: map[string]string{
+				"userId":    "User#Exporter",
+				"format":    "pdf",
+				"startTime": "2025-03-01T00:00:00Z",
+				"endTime":   "2025-04-01T00:00:00Z",
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: pdfContentType,
+		},
+		"Happy Path - FHIR Bundle": {
+			receiverID:          "Receiver#Export",
+			queryParams:         map[string]string{"userId": "User#Exporter", "format": "fhir"},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: fhir.ContentType,
+			expectedLOINC:       "29463-7",
+		},
+		"Happy Path - FHIR Bundle With Configured Mappings": {
+			receiverID:          "Receiver#Export",
+			queryParams:         map[string]string{"userId": "User#Exporter", "format": "fhir"},
+			fhirMappings:        []fhir.Mapping{{EventType: "Weight", DataPoint: "weight", Code: "3141-9", Display: "Body weight Measured", Unit: "lb", UnitCode: "[lb_av]"}},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: fhir.ContentType,
+			expectedLOINC:       "3141-9",
+		},
+		"Sad Path - Error Getting Receiver For PDF": {
+			receiverID:         "Receiver#Sync",
+			queryParams:        map[string]string{"userId": "User#Syncer", "format": "pdf"},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		"Sad Path - Unsupported Format": {
+			receiverID:         "Receiver#Export",
+			queryParams:        map[string]string{"userId": "User#Exporter", "format": "xlsx"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Only Start Time": {
+			receiverID:         "Receiver#Export",
+			queryParams:        map[string]string{"userId": "User#Exporter", "startTime": "2025-03-01T00:00:00Z"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Bad Receiver ID": {
+			receiverID:         "Event#123",
+			queryParams:        map[string]string{"userId": "User#Exporter"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Missing User ID": {
+			receiverID:         "Receiver#Export",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Error Getting User": {
+			receiverID:         "Receiver#Export",
+			queryParams:        map[string]string{"userId": "User#Error"},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		"Sad Path - Not A Care Giver": {
+			receiverID:         "Receiver#Export",
+			queryParams:        map[string]string{"userId": "User#NotACareGiver"},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - Error Getting Events": {
+			receiverID:         "Receiver#Error",
+			queryParams:        map[string]string{"userId": "User#123"},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodGet,
+					PathParameters:        map[string]string{"receiverId": tc.receiverID},
+					QueryStringParameters: tc.queryParams,
+				},
+				UserRepo:         testUserRepo,
+				EventRepo:        testEventRepo,
+				RelationshipRepo: testRelationshipRepo,
+				ProfileRepo:      testProfileRepo,
+				ReceiverRepo:     testReceiverRepo,
+				AuditRepo:        testAuditRepo,
+
+				ObservationMappings: tc.fhirMappings,
+			}
+			resp, err := HandleExportReceiverEvents(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedStatusCode != http.StatusOK {
+				return
+			}
+			if tc.expectedContentType == "" {
+				tc.expectedContentType = csvContentType
+			}
+			assert.Equal(t, tc.expectedContentType, resp.Headers["Content-Type"])
+			assert.True(t, strings.HasPrefix(resp.Headers["Content-Disposition"], "attachment; filename=care-events-"))
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, resp.Body)
+			}
+			if tc.expectedContentType == fhir.ContentType {
+				var bundle struct {
+					ResourceType string `json:"resourceType"`
+					Total        *int   `json:"total"`
+					Entry        []struct {
+						Resource map[string]interface{} `json:"resource"`
+					} `json:"entry"`
+				}
+				assert.Nil(t, json.Unmarshal([]byte(resp.Body), &bundle))
+				assert.Equal(t, "Bundle", bundle.ResourceType)
+				assert.Nil(t, bundle.Total)
+				assert.Len(t, bundle.Entry, 2)
+				assert.Equal(t, "Patient", bundle.Entry[0].Resource["resourceType"])
+				assert.Equal(t, "Observation", bundle.Entry[1].Resource["resourceType"])
+				assert.Contains(t, resp.Body, `"code": "`+tc.expectedLOINC+`"`)
+				assert.True(t, strings.HasSuffix(resp.Headers["Content-Disposition"], ".json"))
+			}
+			if tc.expectedContentType == pdfContentType {
+				assert.True(t, strings.HasPrefix(resp.Body, "%PDF-"))
+				assert.Contains(t, resp.Body, "(Mary Jones)")
+				assert.Contains(t, resp.Body, "(Jane Smith)")
+			}
+		})
+	}
+}
+
+func TestReceiverLocation(t *testing.T) {
+	tests := map[string]struct {
+		receiverID        string
+		fallbackUID       string
+		expectedLocation  string
+		expectedErrorLogs int
+	}{
+		"Happy Path - Primary Care Giver Timezone": {
+			receiverID:       "Receiver#Export",
+			fallbackUID:      "User#Exporter",
+			expectedLocation: "America/Chicago",
+		},
+		"Happy Path - Requester Timezone When Primary Profile Fails": {
+			receiverID:        "Receiver#UserError",
+			fallbackUID:       "User#WithProfile",
+			expectedLocation:  "America/Chicago",
+			expectedErrorLogs: 1,
+		},
+		"Sad Path - Every Profile Fails": {
+			receiverID:        "Receiver#UserError",
+			fallbackUID:       "User#Exporter",
+			expectedLocation:  "UTC",
+			expectedErrorLogs: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			core, logs := observer.New(zap.ErrorLevel)
+			appCfg := appconfig.NewAppConfig()
+			appCfg.Logger = zap.New(core)
+			params := HandlerParams{
+				AppCfg:           appCfg,
+				RelationshipRepo: testRelationshipRepo,
+				ProfileRepo:      testProfileRepo,
+			}
+
+			loc := receiverLocation(params, tc.receiverID, tc.fallbackUID)
+			assert.Equal(t, tc.expectedLocation, loc.String())
+			assert.Equal(t, tc.expectedErrorLogs, logs.Len())
+		})
+	}
+}
+
+func TestExportFilename(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, "care-events-123-20250310.csv", exportFilename("Receiver#123", "csv", now))
+	assert.Equal(t, "care-events-123-20250310.json", exportFilename("Receiver#123", "json", now))
+	assert.Equal(t, "care-events-123-20250310.pdf", exportFilename("Receiver#123", "pdf", now))
+}
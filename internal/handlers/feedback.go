@@ -2,37 +2,56 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/feedback"
+	"github.com/care-giver-app/care-giver-api/internal/notifications"
 	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
 	"go.uber.org/zap"
 )
 
 const (
-	submitFeedback = "submit feedback"
+	submitFeedback       = "submit feedback"
+	listFeedback         = "list feedback"
+	updateFeedbackStatus = "update feedback status"
+
+	anonymousActor = "anonymous"
+
+	feedbackStatusParam   = "status"
+	feedbackCategoryParam = "category"
+	feedbackPlatformParam = "platform"
+	feedbackSinceParam    = "since"
+	feedbackUntilParam    = "until"
+
+	userNotAdminError = "user is not an admin"
 )
 
 type FeedbackRequest struct {
-	Message string `json:"message" validate:"required"`
+	Message    string `json:"message" validate:"required,max=5000,freetext"`
+	Category   string `json:"category" validate:"omitempty,oneof=bug idea praise"`
+	Rating     int    `json:"rating" validate:"omitempty,min=1,max=5"`
+	AppVersion string `json:"appVersion" validate:"omitempty,max=32,freetext"`
+	Platform   string `json:"platform" validate:"omitempty,oneof=ios android web"`
 }
 
 type FeedbackResponse struct {
-	Status string `json:"status"`
+	FeedbackID string `json:"feedbackId"`
+	Status     string `json:"status"`
 }
 
-type Notification struct {
-	NotificationType string   `json:"notification_type"`
-	Channel          []string `json:"channel"`
-	ExecutionData    any      `json:"execution_data"`
+type ListFeedbackResponse struct {
+	Feedback []feedback.Feedback `json:"feedback"`
+	Status   string              `json:"status"`
 }
 
-type FeedbackNotification struct {
-	Email   string `json:"email"`
-	Message string `json:"message"`
+type FeedbackStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=new triaged resolved"`
 }
 
 func HandleFeedbackRequest(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
@@ -42,47 +61,191 @@ func HandleFeedbackRequest(ctx context.Context, params HandlerParams) (events.AP
 	err := readRequestBody(params.Request.Body, &feedbackRequest)
 	if err != nil {
 		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
-		return response.CreateBadRequestResponse(), nil
+		return createRequestBodyErrorResponse(err), nil
 	}
 
-	if params.AppCfg.FeedbackQueueURL == "" {
-		params.AppCfg.Logger.Error("feedback queue URL not configured")
+	senderEmail := normalizeEmail(authorizerClaim(params.Request, "email"))
+	senderID := feedbackSenderID(params, senderEmail)
+
+	fb := feedback.NewFeedback(
+		feedbackRequest.Message,
+		feedbackRequest.Category,
+		feedbackRequest.Rating,
+		feedbackRequest.AppVersion,
+		feedbackRequest.Platform,
+		senderID,
+		senderEmail,
+	)
+
+	err = params.FeedbackRepo.AddFeedback(fb)
+	if err != nil {
+		params.AppCfg.Logger.Error("error adding feedback to db", zap.Error(err))
 		return response.CreateInternalServerErrorResponse(), nil
 	}
 
-	sqsClient := sqs.NewFromConfig(params.AppCfg.AWSConfig)
+	notifyFeedbackRecipients(ctx, params, fb)
+
+	actor := senderEmail
+	if actor == "" {
+		actor = anonymousActor
+	}
+	recordAudit(params, actor, actor, audit.ActionSubmitFeedback,
+		audit.WithTarget(feedback.ParamID, fb.FeedbackID),
+		audit.WithAfter(feedbackRequest),
+	)
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, submitFeedback)
+
+	resp := FeedbackResponse{
+		FeedbackID: fb.FeedbackID,
+		Status:     response.Success,
+	}
+
+	return response.FormatResponse(resp, http.StatusOK), nil
+}
+
+// notifyFeedbackRecipients emails each configured recipient. The feedback is already stored,
+// so a failed publish is logged rather than failing the request.
+func notifyFeedbackRecipients(ctx context.Context, params HandlerParams, fb *feedback.Feedback) {
+	if len(params.AppCfg.FeedbackRecipients) == 0 {
+		params.AppCfg.Logger.Warn("no feedback recipients configured", zap.String(feedback.ParamID, fb.FeedbackID))
+		return
+	}
+
+	for _, recipient := range params.AppCfg.FeedbackRecipients {
+		err := params.Publisher.Publish(ctx, notifications.NewFeedback(notifications.FeedbackPayload{
+			Email:      recipient,
+			FeedbackID: fb.FeedbackID,
+			Message:    fb.Message,
+			Category:   fb.Category,
+			Rating:     fb.Rating,
+			AppVersion: fb.AppVersion,
+			Platform:   fb.Platform,
+			UserID:     fb.UserID,
+			UserEmail:  fb.UserEmail,
+		}))
+		if err != nil {
+			params.AppCfg.Logger.Error("error publishing feedback notification", zap.String(feedback.ParamID, fb.FeedbackID), zap.Error(err))
+		}
+	}
+}
+
+// feedbackSenderID looks up the caller by their authorizer email. Feedback is still
+// accepted when the lookup fails, just without a user ID attached.
+func feedbackSenderID(params HandlerParams, email string) string {
+	if email == "" {
+		return ""
+	}
+
+	u, err := params.UserRepo.GetUserByEmail(email)
+	if err != nil {
+		params.AppCfg.Logger.Warn("unable to resolve feedback sender", zap.Error(err))
+		return ""
+	}
+	return u.UserID
+}
+
+func HandleListFeedback(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, listFeedback)
 
-	sqsMessage := Notification{
-		NotificationType: "feedback",
-		Channel:          []string{"email"},
-		ExecutionData: FeedbackNotification{
-			Email:   "twilliams0095@gmail.com",
-			Message: feedbackRequest.Message,
-		},
+	if !isAdmin(params) {
+		params.AppCfg.Logger.Error(userNotAdminError)
+		return response.CreateAccessDeniedResponse(), nil
 	}
 
-	messageBody, err := json.Marshal(sqsMessage)
+	filter, err := feedbackFilter(params.Request.QueryStringParameters)
 	if err != nil {
-		params.AppCfg.Logger.Error("error marshaling feedback message", zap.Error(err))
+		params.AppCfg.Logger.Error(queryParamsError, zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	items, err := params.FeedbackRepo.ListFeedback(filter)
+	if err != nil {
+		params.AppCfg.Logger.Error("error retrieving feedback from db", zap.Error(err))
 		return response.CreateInternalServerErrorResponse(), nil
 	}
 
-	input := &sqs.SendMessageInput{
-		QueueUrl:    aws.String(params.AppCfg.FeedbackQueueURL),
-		MessageBody: aws.String(string(messageBody)),
+	// Timestamps are RFC3339 in UTC, so they sort correctly as strings.
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].CreatedAt > items[j].CreatedAt
+	})
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, listFeedback)
+	return response.FormatResponse(ListFeedbackResponse{
+		Feedback: items,
+		Status:   response.Success,
+	}, http.StatusOK), nil
+}
+
+func HandleUpdateFeedbackStatus(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, updateFeedbackStatus)
+
+	fid, err := validatePathParameters(params.Request, feedback.ParamID, feedback.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, feedback.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
 	}
 
-	_, err = sqsClient.SendMessage(ctx, input)
+	var statusRequest FeedbackStatusRequest
+	err = readRequestBody(params.Request.Body, &statusRequest)
 	if err != nil {
-		params.AppCfg.Logger.Error("error sending message to SQS", zap.Error(err))
+		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		return createRequestBodyErrorResponse(err), nil
+	}
+
+	if !isAdmin(params) {
+		params.AppCfg.Logger.Error(userNotAdminError)
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	actor := normalizeEmail(authorizerClaim(params.Request, "email"))
+	err = params.FeedbackRepo.UpdateStatus(fid, feedback.Status(statusRequest.Status), actor)
+	if err != nil {
+		if errors.Is(err, feedback.ErrNotFound) {
+			params.AppCfg.Logger.Error("feedback not found", zap.String(feedback.ParamID, fid))
+			return response.CreateResourceNotFoundResponse(), nil
+		}
+		params.AppCfg.Logger.Error("error updating feedback status in db", zap.Error(err))
 		return response.CreateInternalServerErrorResponse(), nil
 	}
 
-	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, submitFeedback)
+	recordAudit(params, fid, actor, audit.ActionUpdateFeedbackStatus,
+		audit.WithTarget(feedback.ParamID, fid),
+		audit.WithAfter(statusRequest),
+	)
 
-	resp := FeedbackResponse{
-		Status: response.Success,
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, updateFeedbackStatus)
+	return response.FormatResponse(FeedbackResponse{
+		FeedbackID: fid,
+		Status:     response.Success,
+	}, http.StatusOK), nil
+}
+
+func feedbackFilter(query map[string]string) (feedback.Filter, error) {
+	filter := feedback.Filter{
+		Status:   feedback.Status(query[feedbackStatusParam]),
+		Category: query[feedbackCategoryParam],
+		Platform: query[feedbackPlatformParam],
+	}
+	if filter.Status != "" && !filter.Status.Valid() {
+		return feedback.Filter{}, errors.New("invalid feedback status")
 	}
 
-	return response.FormatResponse(resp, http.StatusOK), nil
+	var err error
+	if value := query[feedbackSinceParam]; value != "" {
+		filter.Since, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return feedback.Filter{}, err
+		}
+	}
+	if value := query[feedbackUntilParam]; value != "" {
+		filter.Until, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return feedback.Filter{}, err
+		}
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Until.After(filter.Since) {
+		return feedback.Filter{}, errors.New("until must be after since")
+	}
+	return filter, nil
 }
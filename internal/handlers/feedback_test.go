@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/feedback"
+	"github.com/care-giver-app/care-giver-api/internal/notifications"
+	"github.com/stretchr/testify/assert"
+)
+
+func claimsRequest(email string) events.APIGatewayProxyRequestContext {
+	return events.APIGatewayProxyRequestContext{
+		Authorizer: map[string]interface{}{
+			"claims": map[string]interface{}{"email": email},
+		},
+	}
+}
+
+func TestHandleFeedbackRequest(t *testing.T) {
+	tests := map[string]struct {
+		body               string
+		requestContext     events.APIGatewayProxyRequestContext
+		recipients         []string
+		expectedStatusCode int
+		expectedPayloads   []notifications.FeedbackPayload
+	}{
+		"Happy Path - Feedback Sent": {
+			body:               "{\"message\":\"The new calendar is great\"}",
+			recipients:         []string{"support@test.com"},
+			expectedStatusCode: http.StatusOK,
+			expectedPayloads: []notifications.FeedbackPayload{
+				{Email: "support@test.com", Message: "The new calendar is great"},
+			},
+		},
+		"Happy Path - Feedback Sent To Each Recipient With Sender Details": {
+			body:           "{\"message\":\"Sync dropped an event\",\"category\":\"bug\",\"rating\":2,\"appVersion\":\"1.4.0\",\"platform\":\"ios\"}",
+			requestContext: claimsRequest("Valid@example.com"),
+			recipients: []string{
+				"support@test.com",
+				"product@test.com",
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedPayloads: []notifications.FeedbackPayload{
+				{Email: "support@test.com", Message: "Sync dropped an event", Category: "bug", Rating: 2, AppVersion: "1.4.0", Platform: "ios", UserID: "User#123", UserEmail: "valid@example.com"},
+				{Email: "product@test.com", Message: "Sync dropped an event", Category: "bug", Rating: 2, AppVersion: "1.4.0", Platform: "ios", UserID: "User#123", UserEmail: "valid@example.com"},
+			},
+		},
+		"Happy Path - Sender Lookup Fails": {
+			body:               "{\"message\":\"Love it\",\"category\":\"praise\"}",
+			requestContext:     claimsRequest("error@example.com"),
+			recipients:         []string{"support@test.com"},
+			expectedStatusCode: http.StatusOK,
+			expectedPayloads: []notifications.FeedbackPayload{
+				{Email: "support@test.com", Message: "Love it", Category: "praise", UserEmail: "error@example.com"},
+			},
+		},
+		"Happy Path - Stored Without Recipients Configured": {
+			body:               "{\"message\":\"hi\"}",
+			expectedStatusCode: http.StatusOK,
+		},
+		"Sad Path - Missing Message": {
+			body:               "{}",
+			recipients:         []string{"support@test.com"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Invalid Category": {
+			body:               "{\"message\":\"hi\",\"category\":\"complaint\"}",
+			recipients:         []string{"support@test.com"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Rating Out Of Range": {
+			body:               "{\"message\":\"hi\",\"rating\":6}",
+			recipients:         []string{"support@test.com"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Error Storing Feedback": {
+			body:               "{\"message\":\"trigger db error\"}",
+			recipients:         []string{"support@test.com"},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			publisher := notifications.NewMemoryPublisher()
+			appCfg := appconfig.NewAppConfig()
+			appCfg.FeedbackRecipients = tc.recipients
+			params := HandlerParams{
+				AppCfg: appCfg,
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:     http.MethodPost,
+					Body:           tc.body,
+					RequestContext: tc.requestContext,
+				},
+				UserRepo:     testUserRepo,
+				AuditRepo:    testAuditRepo,
+				FeedbackRepo: testFeedbackRepo,
+				Publisher:    publisher,
+			}
+			resp, err := HandleFeedbackRequest(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedStatusCode == http.StatusOK {
+				var body FeedbackResponse
+				assert.Nil(t, json.Unmarshal([]byte(resp.Body), &body))
+				assert.True(t, strings.HasPrefix(body.FeedbackID, feedback.DBPrefix+"#"))
+				for i := range tc.expectedPayloads {
+					tc.expectedPayloads[i].FeedbackID = body.FeedbackID
+				}
+			}
+
+			sent := publisher.Notifications()
+			assert.Len(t, sent, len(tc.expectedPayloads))
+			for i, expected := range tc.expectedPayloads {
+				assert.Equal(t, notifications.TypeFeedback, sent[i].NotificationType)
+				assert.Equal(t, expected, sent[i].ExecutionData)
+			}
+		})
+	}
+}
+
+func TestHandleListFeedback(t *testing.T) {
+	tests := map[string]struct {
+		requestContext     events.APIGatewayProxyRequestContext
+		queryParams        map[string]string
+		expectedStatusCode int
+		expectedIDs        []string
+	}{
+		"Happy Path - All Feedback Newest First": {
+			requestContext:     claimsRequest("admin@test.com"),
+			expectedStatusCode: http.StatusOK,
+			expectedIDs:        []string{"Feedback#3", "Feedback#2", "Feedback#1"},
+		},
+		"Happy Path - Filtered By Status And Category": {
+			requestContext:     claimsRequest("admin@test.com"),
+			queryParams:        map[string]string{"status": "new", "category": "bug"},
+			expectedStatusCode: http.StatusOK,
+			expectedIDs:        []string{"Feedback#2"},
+		},
+		"Happy Path - Filtered By Time Range": {
+			requestContext:     claimsRequest("admin@test.com"),
+			queryParams:        map[string]string{"since": "2025-03-05T00:00:00Z", "until": "2025-03-15T00:00:00Z"},
+			expectedStatusCode: http.StatusOK,
+			expectedIDs:        []string{"Feedback#2"},
+		},
+		"Sad Path - Not An Admin": {
+			requestContext:     claimsRequest("valid@example.com"),
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - No Claims": {
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - Invalid Status": {
+			requestContext:     claimsRequest("admin@test.com"),
+			queryParams:        map[string]string{"status": "closed"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Until Before Since": {
+			requestContext:     claimsRequest("admin@test.com"),
+			queryParams:        map[string]string{"since": "2025-03-15T00:00:00Z", "until": "2025-03-05T00:00:00Z"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			appCfg := appconfig.NewAppConfig()
+			appCfg.AdminEmails = []string{"admin@test.com"}
+			params := HandlerParams{
+				AppCfg: appCfg,
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodGet,
+					QueryStringParameters: tc.queryParams,
+					RequestContext:        tc.requestContext,
+				},
+				FeedbackRepo: testFeedbackRepo,
+			}
+			resp, err := HandleListFeedback(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedStatusCode == http.StatusOK {
+				var body ListFeedbackResponse
+				assert.Nil(t, json.Unmarshal([]byte(resp.Body), &body))
+				ids := []string{}
+				for _, fb := range body.Feedback {
+					ids = append(ids, fb.FeedbackID)
+				}
+				assert.Equal(t, tc.expectedIDs, ids)
+			}
+		})
+	}
+}
+
+func TestHandleUpdateFeedbackStatus(t *testing.T) {
+	tests := map[string]struct {
+		feedbackID         string
+		body               string
+		requestContext     events.APIGatewayProxyRequestContext
+		expectedStatusCode int
+	}{
+		"Happy Path - Status Updated": {
+			feedbackID:         "Feedback#123",
+			body:               "{\"status\":\"triaged\"}",
+			requestContext:     claimsRequest("admin@test.com"),
+			expectedStatusCode: http.StatusOK,
+		},
+		"Sad Path - Not An Admin": {
+			feedbackID:         "Feedback#123",
+			body:               "{\"status\":\"triaged\"}",
+			requestContext:     claimsRequest("valid@example.com"),
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - Invalid Status": {
+			feedbackID:         "Feedback#123",
+			body:               "{\"status\":\"closed\"}",
+			requestContext:     claimsRequest("admin@test.com"),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Bad Feedback ID": {
+			feedbackID:         "Event#123",
+			body:               "{\"status\":\"triaged\"}",
+			requestContext:     claimsRequest("admin@test.com"),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Feedback Not Found": {
+			feedbackID:         "Feedback#Missing",
+			body:               "{\"status\":\"resolved\"}",
+			requestContext:     claimsRequest("admin@test.com"),
+			expectedStatusCode: http.StatusNotFound,
+		},
+		"Sad Path - Error Updating Status": {
+			feedbackID:         "Feedback#Error",
+			body:               "{\"status\":\"resolved\"}",
+			requestContext:     claimsRequest("admin@test.com"),
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			appCfg := appconfig.NewAppConfig()
+			appCfg.AdminEmails = []string{"admin@test.com"}
+			params := HandlerParams{
+				AppCfg: appCfg,
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:     http.MethodPut,
+					PathParameters: map[string]string{feedback.ParamID: tc.feedbackID},
+					Body:           tc.body,
+					RequestContext: tc.requestContext,
+				},
+				AuditRepo:    testAuditRepo,
+				FeedbackRepo: testFeedbackRepo,
+			}
+			resp, err := HandleUpdateFeedbackStatus(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
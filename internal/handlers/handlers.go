@@ -3,23 +3,48 @@ package handlers
 import (
 	"context"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/alert"
 	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/calendarfeed"
+	"github.com/care-giver-app/care-giver-api/internal/changelog"
+	"github.com/care-giver-app/care-giver-api/internal/digest"
+	"github.com/care-giver-app/care-giver-api/internal/emailclaim"
+	"github.com/care-giver-app/care-giver-api/internal/eventbatch"
+	"github.com/care-giver-app/care-giver-api/internal/feedback"
+	"github.com/care-giver-app/care-giver-api/internal/fhir"
+	"github.com/care-giver-app/care-giver-api/internal/medication"
+	"github.com/care-giver-app/care-giver-api/internal/notifications"
+	"github.com/care-giver-app/care-giver-api/internal/preference"
+	"github.com/care-giver-app/care-giver-api/internal/profile"
+	"github.com/care-giver-app/care-giver-api/internal/ratelimit"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/schedule"
+	"github.com/care-giver-app/care-giver-api/internal/tombstone"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/repository"
+	"go.uber.org/zap"
 )
 
 const (
-	handlerStart              = "handling %s"
-	handlerSuccessful         = "processed %s successfully"
-	requestBodyError          = "error reading request body"
-	pathParametersError       = "error validating path parameters"
-	queryParamsError          = "error validating query parameters"
-	userDatabaseError         = "error retrieving user from db"
-	receiverDatabaseError     = "error retrieving receiver from db"
-	userNotCareGiverError     = "user is not a caregiver for the receiver"
-	relationshipDatabaseError = "error retrieving relationship from db"
+	handlerStart                 = "handling %s"
+	handlerSuccessful            = "processed %s successfully"
+	requestBodyError             = "error reading request body"
+	pathParametersError          = "error validating path parameters"
+	queryParamsError             = "error validating query parameters"
+	userDatabaseError            = "error retrieving user from db"
+	receiverDatabaseError        = "error retrieving receiver from db"
+	userNotCareGiverError        = "user is not a caregiver for the receiver"
+	userNotPrimaryCareGiverError = "user is not a primary caregiver for the receiver"
+	userNotCallerError           = "user is not the authenticated caller"
+	relationshipDatabaseError    = "error retrieving relationship from db"
+	profileDatabaseError         = "error retrieving user profile from db"
+	eventDatabaseError           = "error retrieving events from db"
+	tombstoneDatabaseError       = "error retrieving deleted events from db"
+	alertRuleDatabaseError       = "error retrieving alert rules from db"
 )
 
 type HandlerParams struct {
@@ -29,6 +54,22 @@ type HandlerParams struct {
 	ReceiverRepo     repository.ReceiverRepositoryProvider
 	EventRepo        repository.EventRepositoryProvider
 	RelationshipRepo repository.RelationshipRepositoryProvider
+	TombstoneRepo    tombstone.RepositoryProvider
+	AuditRepo        audit.RepositoryProvider
+	ProfileRepo      profile.RepositoryProvider
+	EmailClaimRepo   emailclaim.RepositoryProvider
+	EventBatchRepo   eventbatch.RepositoryProvider
+	ChangeLogRepo    changelog.RepositoryProvider
+	ScheduleRepo     schedule.RepositoryProvider
+	MedicationRepo   medication.RepositoryProvider
+	AlertRuleRepo    alert.RepositoryProvider
+	PreferenceRepo   preference.RepositoryProvider
+	DigestRepo       digest.RepositoryProvider
+	FeedbackRepo     feedback.RepositoryProvider
+	CalendarFeedRepo calendarfeed.RepositoryProvider
+	Publisher        notifications.Publisher
+	// ObservationMappings drive FHIR exports. Nil uses fhir.DefaultMappings.
+	ObservationMappings []fhir.Mapping
 }
 
 type Endpoint struct {
@@ -39,23 +80,65 @@ type Endpoint struct {
 type HandlerFunc func(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error)
 
 var handlersMap = map[Endpoint]HandlerFunc{
-	{"/user", http.MethodPost}:                       HandleCreateUser,
-	{"/user/{userId}", http.MethodGet}:               HandleGetUser,
-	{"/user/primary-receiver", http.MethodPost}:      HandleUserPrimaryReceiver,
-	{"/user/additional-receiver", http.MethodPost}:   HandleUserAdditionalReceiver,
-	{"/user/relationships/{userId}", http.MethodGet}: HandleGetUserRelationships,
-	{"/receiver/{receiverId}", http.MethodGet}:                HandleReceiver,
-	{"/receiver/care-givers/{receiverId}", http.MethodGet}: HandleGetReceiverCareGivers,
-	{"/event", http.MethodPost}:                      HandleReceiverEvent,
-	{"/event/{eventId}", http.MethodDelete}:          HandleDeleteReceiverEvent,
-	{"/events/{receiverId}", http.MethodGet}:         HandleGetReceiverEvents,
-	{"/events/configs", http.MethodGet}:              HandleGetEventConfigs,
-	{"/feedback", http.MethodPost}:                   HandleFeedbackRequest,
+	{"/user", http.MethodPost}:                                          HandleCreateUser,
+	{"/user/{userId}", http.MethodGet}:                                  HandleGetUser,
+	{"/user/{userId}", http.MethodPut}:                                  HandleUpdateUser,
+	{"/user/{userId}", http.MethodDelete}:                               HandleDeleteUser,
+	{"/user/primary-receiver", http.MethodPost}:                         HandleUserPrimaryReceiver,
+	{"/user/additional-receiver", http.MethodPost}:                      HandleUserAdditionalReceiver,
+	{"/user/relationships/{userId}", http.MethodGet}:                    HandleGetUserRelationships,
+	{"/receiver/{receiverId}", http.MethodGet}:                          HandleReceiver,
+	{"/receiver/care-givers/{receiverId}", http.MethodGet}:              HandleGetReceiverCareGivers,
+	{"/receiver/{receiverId}/audit", http.MethodGet}:                    HandleGetReceiverAudit,
+	{"/receiver/{receiverId}/sync", http.MethodGet}:                     HandleSyncReceiverEvents,
+	{"/receiver/{receiverId}/sync", http.MethodPost}:                    HandleUploadReceiverEvents,
+	{"/receiver/{receiverId}/schedule", http.MethodPost}:                HandleAddReceiverSchedule,
+	{"/receiver/{receiverId}/schedules", http.MethodGet}:                HandleGetReceiverSchedules,
+	{"/receiver/{receiverId}/occurrences", http.MethodGet}:              HandleGetScheduleOccurrences,
+	{"/schedule/{scheduleId}", http.MethodDelete}:                       HandleDeleteReceiverSchedule,
+	{"/receiver/{receiverId}/summary", http.MethodGet}:                  HandleGetReceiverSummary,
+	{"/receiver/{receiverId}/trends", http.MethodGet}:                   HandleGetReceiverTrend,
+	{"/receiver/{receiverId}/export", http.MethodGet}:                   HandleExportReceiverEvents,
+	{"/receiver/{receiverId}/medication", http.MethodPost}:              HandleAddReceiverMedication,
+	{"/receiver/{receiverId}/medications", http.MethodGet}:              HandleGetReceiverMedications,
+	{"/medication/{medicationId}", http.MethodPut}:                      HandleUpdateReceiverMedication,
+	{"/medication/{medicationId}/dose", http.MethodPost}:                HandleLogMedicationDose,
+	{"/receiver/{receiverId}/alert-rule", http.MethodPost}:              HandleAddReceiverAlertRule,
+	{"/receiver/{receiverId}/alert-rules", http.MethodGet}:              HandleGetReceiverAlertRules,
+	{"/receiver/{receiverId}/notification-preferences", http.MethodGet}: HandleGetNotificationPreferences,
+	{"/receiver/{receiverId}/notification-preferences", http.MethodPut}: HandleUpdateNotificationPreferences,
+	{"/alert-rule/{ruleId}", http.MethodDelete}:                         HandleDeleteReceiverAlertRule,
+	{"/event", http.MethodPost}:                                         HandleReceiverEvent,
+	{"/event/{eventId}", http.MethodDelete}:                             HandleDeleteReceiverEvent,
+	{"/event/{eventId}/restore", http.MethodPost}:                       HandleRestoreReceiverEvent,
+	{"/events/{receiverId}", http.MethodGet}:                            HandleGetReceiverEvents,
+	{"/events/configs", http.MethodGet}:                                 HandleGetEventConfigs,
+	{"/events/batch", http.MethodPost}:                                  HandleReceiverEventBatch,
+	{"/feedback", http.MethodPost}:                                      HandleFeedbackRequest,
+	{"/feedback", http.MethodGet}:                                       HandleListFeedback,
+	{"/feedback/{feedbackId}/status", http.MethodPut}:                   HandleUpdateFeedbackStatus,
+	{"/user/{userId}/calendar-token", http.MethodPost}:                  HandleRegenerateCalendarToken,
+	{"/calendar/{userId}/feed.ics", http.MethodGet}:                     HandleGetCalendarFeed,
+}
+
+// TaskFunc is work the Lambda runs on a schedule instead of in response to an API request.
+type TaskFunc func(ctx context.Context, params HandlerParams) error
+
+const (
+	FlushActivityDigestsTask = "flush-activity-digests"
+	BackfillEmailClaimsTask  = "backfill-email-claims"
+)
+
+var tasksMap = map[string]TaskFunc{
+	FlushActivityDigestsTask: FlushActivityDigests,
+	BackfillEmailClaimsTask:  BackfillEmailClaims,
 }
 
 type RegistryProvider interface {
 	GetHandler(request events.APIGatewayProxyRequest) (HandlerFunc, bool)
 	RunHandler(ctx context.Context, handler HandlerFunc, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+	GetTask(name string) (TaskFunc, bool)
+	RunTask(ctx context.Context, task TaskFunc) error
 }
 
 type Registry struct {
@@ -64,39 +147,262 @@ type Registry struct {
 	ReceiverRepo     repository.ReceiverRepositoryProvider
 	EventRepo        repository.EventRepositoryProvider
 	RelationshipRepo repository.RelationshipRepositoryProvider
+	TombstoneRepo    tombstone.RepositoryProvider
+	AuditRepo        audit.RepositoryProvider
+	ProfileRepo      profile.RepositoryProvider
+	EmailClaimRepo   emailclaim.RepositoryProvider
+	EventBatchRepo   eventbatch.RepositoryProvider
+	ChangeLogRepo    changelog.RepositoryProvider
+	ScheduleRepo     schedule.RepositoryProvider
+	MedicationRepo   medication.RepositoryProvider
+	AlertRuleRepo    alert.RepositoryProvider
+	PreferenceRepo   preference.RepositoryProvider
+	DigestRepo       digest.RepositoryProvider
+	FeedbackRepo     feedback.RepositoryProvider
+	CalendarFeedRepo calendarfeed.RepositoryProvider
+	Publisher        notifications.Publisher
+	RateLimiter      ratelimit.Limiter
+	// ObservationMappings are parsed once at startup; see WithObservationMappings.
+	ObservationMappings []fhir.Mapping
+}
+
+type RegistryOption func(*Registry)
+
+func WithTombstoneRepo(tombstoneRepo tombstone.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.TombstoneRepo = tombstoneRepo
+	}
+}
+
+func WithAuditRepo(auditRepo audit.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.AuditRepo = auditRepo
+	}
+}
+
+func WithProfileRepo(profileRepo profile.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.ProfileRepo = profileRepo
+	}
+}
+
+func WithEmailClaimRepo(emailClaimRepo emailclaim.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.EmailClaimRepo = emailClaimRepo
+	}
+}
+
+func WithEventBatchRepo(eventBatchRepo eventbatch.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.EventBatchRepo = eventBatchRepo
+	}
+}
+
+func WithChangeLogRepo(changeLogRepo changelog.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.ChangeLogRepo = changeLogRepo
+	}
+}
+
+func WithScheduleRepo(scheduleRepo schedule.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.ScheduleRepo = scheduleRepo
+	}
+}
+
+func WithMedicationRepo(medicationRepo medication.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.MedicationRepo = medicationRepo
+	}
+}
+
+func WithAlertRuleRepo(alertRuleRepo alert.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.AlertRuleRepo = alertRuleRepo
+	}
+}
+
+func WithPreferenceRepo(preferenceRepo preference.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.PreferenceRepo = preferenceRepo
+	}
+}
+
+func WithDigestRepo(digestRepo digest.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.DigestRepo = digestRepo
+	}
+}
+
+func WithFeedbackRepo(feedbackRepo feedback.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.FeedbackRepo = feedbackRepo
+	}
+}
+
+func WithCalendarFeedRepo(calendarFeedRepo calendarfeed.RepositoryProvider) RegistryOption {
+	return func(r *Registry) {
+		r.CalendarFeedRepo = calendarFeedRepo
+	}
 }
 
-func NewRegistry(appCfg *appconfig.AppConfig, userRepo repository.UserRepositoryProvider, receiverRepo repository.ReceiverRepositoryProvider, eventRepo repository.EventRepositoryProvider, relationshipRepo repository.RelationshipRepositoryProvider) *Registry {
-	return &Registry{
+func WithPublisher(publisher notifications.Publisher) RegistryOption {
+	return func(r *Registry) {
+		r.Publisher = publisher
+	}
+}
+
+func WithRateLimiter(rateLimiter ratelimit.Limiter) RegistryOption {
+	return func(r *Registry) {
+		r.RateLimiter = rateLimiter
+	}
+}
+
+func WithObservationMappings(mappings []fhir.Mapping) RegistryOption {
+	return func(r *Registry) {
+		r.ObservationMappings = mappings
+	}
+}
+
+func NewRegistry(appCfg *appconfig.AppConfig, userRepo repository.UserRepositoryProvider, receiverRepo repository.ReceiverRepositoryProvider, eventRepo repository.EventRepositoryProvider, relationshipRepo repository.RelationshipRepositoryProvider, opts ...RegistryOption) *Registry {
+	r := &Registry{
 		AppCfg:           appCfg,
 		UserRepo:         userRepo,
 		ReceiverRepo:     receiverRepo,
 		EventRepo:        eventRepo,
 		RelationshipRepo: relationshipRepo,
 	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *Registry) GetHandler(request events.APIGatewayProxyRequest) (HandlerFunc, bool) {
-	endpoint := Endpoint{
-		Path:   removePathPrefix(request.RequestContext.ResourcePath),
-		Method: request.HTTPMethod,
+	endpoint, version, err := resolveEndpoint(request)
+	if err != nil {
+		return nil, false
 	}
 
-	handler, exists := handlersMap[endpoint]
-	return handler, exists
+	if endpoint.Method == http.MethodOptions {
+		return preflightHandler(endpoint.Path)
+	}
+
+	handler, served, exists := lookupHandler(endpoint, version)
+	if !exists {
+		return nil, false
+	}
+
+	if successor := successorVersion(endpoint, served); successor != 0 {
+		return deprecated(handler, successor), true
+	}
+	return handler, true
 }
 
 func (r *Registry) RunHandler(ctx context.Context, handler HandlerFunc, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	params := HandlerParams{
+	resp, err := r.run(ctx, handler, request)
+	if err != nil {
+		return resp, err
+	}
+	return r.cors().Apply(resp, requestHeader(request, "Origin")), nil
+}
+
+func (r *Registry) run(ctx context.Context, handler HandlerFunc, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	request, err := decodeRequestBody(request)
+	if err != nil {
+		if r.AppCfg != nil {
+			r.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		}
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	if resp, limited := r.rateLimit(ctx, request); limited {
+		return resp, nil
+	}
+
+	if resp, failed := r.checkIfMatch(ctx, request); failed {
+		return resp, nil
+	}
+
+	resp, err := handler(ctx, r.params(request))
+	if err != nil {
+		return resp, err
+	}
+
+	// Compress first so a 304 carries the ETag of the representation the client would get.
+	if r.AppCfg != nil {
+		resp = response.Compress(resp, requestHeader(request, "Accept-Encoding"), r.AppCfg.CompressionMinBytes)
+	}
+	return notModified(request, resp), nil
+}
+
+func (r *Registry) cors() response.CORS {
+	if r.AppCfg == nil {
+		return response.CORS{}
+	}
+	return response.CORS{AllowedOrigins: r.AppCfg.CORSAllowedOrigins}
+}
+
+// preflightHandler answers OPTIONS for any path that has at least one registered handler.
+func preflightHandler(path string) (HandlerFunc, bool) {
+	found := map[string]bool{}
+	for _, handlers := range handlerVersions() {
+		for endpoint := range handlers {
+			if endpoint.Path == path {
+				found[endpoint.Method] = true
+			}
+		}
+	}
+
+	methods := []string{}
+	for method := range found {
+		methods = append(methods, method)
+	}
+	if len(methods) == 0 {
+		return nil, false
+	}
+	sort.Strings(methods)
+
+	return func(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+		return response.CreatePreflightResponse(methods), nil
+	}, true
+}
+
+func (r *Registry) GetTask(name string) (TaskFunc, bool) {
+	task, exists := tasksMap[name]
+	return task, exists
+}
+
+func (r *Registry) RunTask(ctx context.Context, task TaskFunc) error {
+	return task(ctx, r.params(events.APIGatewayProxyRequest{}))
+}
+
+func (r *Registry) params(request events.APIGatewayProxyRequest) HandlerParams {
+	return HandlerParams{
 		AppCfg:           r.AppCfg,
 		Request:          request,
 		UserRepo:         r.UserRepo,
 		ReceiverRepo:     r.ReceiverRepo,
 		EventRepo:        r.EventRepo,
 		RelationshipRepo: r.RelationshipRepo,
-	}
+		TombstoneRepo:    r.TombstoneRepo,
+		AuditRepo:        r.AuditRepo,
+		ProfileRepo:      r.ProfileRepo,
+		EmailClaimRepo:   r.EmailClaimRepo,
+		EventBatchRepo:   r.EventBatchRepo,
+		ChangeLogRepo:    r.ChangeLogRepo,
+		ScheduleRepo:     r.ScheduleRepo,
+		MedicationRepo:   r.MedicationRepo,
+		AlertRuleRepo:    r.AlertRuleRepo,
+		PreferenceRepo:   r.PreferenceRepo,
+		DigestRepo:       r.DigestRepo,
+		FeedbackRepo:     r.FeedbackRepo,
+		CalendarFeedRepo: r.CalendarFeedRepo,
+		Publisher:        r.Publisher,
 
-	return handler(ctx, params)
+		ObservationMappings: r.ObservationMappings,
+	}
 }
 
 func removePathPrefix(path string) string {
@@ -2,9 +2,14 @@ package handlers
 
 import (
 	"context"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/ratelimit"
+	"github.com/care-giver-app/care-giver-api/internal/response"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -81,6 +86,14 @@ func TestGetRegisteredHandler(t *testing.T) {
 				HTTPMethod: "GET",
 			},
 		},
+		"Sad Path - Preflight For Unknown Path": {
+			request: events.APIGatewayProxyRequest{
+				RequestContext: events.APIGatewayProxyRequestContext{
+					ResourcePath: "/bad/path",
+				},
+				HTTPMethod: "OPTIONS",
+			},
+		},
 		"Sad Path - Invalid Path": {
 			request: events.APIGatewayProxyRequest{
 				RequestContext: events.APIGatewayProxyRequestContext{
@@ -132,3 +145,173 @@ func TestRunHandler(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "Test Response", response.Body)
 }
+
+func TestPreflight(t *testing.T) {
+	appCfg := appconfig.NewAppConfig()
+	appCfg.CORSAllowedOrigins = []string{"https://app.test.com"}
+	testRegistry := NewRegistry(appCfg, nil, nil, nil, nil)
+
+	noop := func(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{}, nil
+	}
+	handlersMap = map[Endpoint]HandlerFunc{
+		{Path: "/receiver/{receiverId}/notification-preferences", Method: http.MethodPut}: noop,
+		{Path: "/receiver/{receiverId}/notification-preferences", Method: http.MethodGet}: noop,
+		{Path: "/receiver/{receiverId}", Method: http.MethodGet}:                          noop,
+	}
+
+	request := events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodOptions,
+		Headers:    map[string]string{"origin": "https://app.test.com"},
+		RequestContext: events.APIGatewayProxyRequestContext{
+			ResourcePath: "/Stage/receiver/{receiverId}/notification-preferences",
+		},
+	}
+
+	handler, ok := testRegistry.GetHandler(request)
+	assert.True(t, ok)
+
+	resp, err := testRegistry.RunHandler(context.Background(), handler, request)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "GET, PUT, OPTIONS", resp.Headers["Access-Control-Allow-Methods"])
+	assert.Equal(t, "https://app.test.com", resp.Headers["Access-Control-Allow-Origin"])
+}
+
+func TestRunHandlerCORS(t *testing.T) {
+	testHandler := func(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+		return response.FormatResponse(struct{}{}, http.StatusOK), nil
+	}
+
+	appCfg := appconfig.NewAppConfig()
+	appCfg.CORSAllowedOrigins = []string{"https://app.test.com"}
+	testRegistry := NewRegistry(appCfg, nil, nil, nil, nil)
+
+	resp, err := testRegistry.RunHandler(context.Background(), testHandler, events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Headers:    map[string]string{"Origin": "https://app.test.com"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "https://app.test.com", resp.Headers["Access-Control-Allow-Origin"])
+	assert.Equal(t, "nosniff", resp.Headers["X-Content-Type-Options"])
+
+	resp, err = testRegistry.RunHandler(context.Background(), testHandler, events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Headers:    map[string]string{"Origin": "https://evil.test.com"},
+	})
+	assert.Nil(t, err)
+	assert.NotContains(t, resp.Headers, "Access-Control-Allow-Origin")
+}
+
+func TestRunHandlerCompression(t *testing.T) {
+	var received string
+	testHandler := func(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+		received = params.Request.Body
+		return response.FormatResponse(map[string]string{"note": strings.Repeat("Took a shower. ", 200)}, http.StatusOK), nil
+	}
+
+	appCfg := appconfig.NewAppConfig()
+	appCfg.CORSAllowedOrigins = []string{"*"}
+	testRegistry := NewRegistry(appCfg, nil, nil, nil, nil)
+
+	resp, err := testRegistry.RunHandler(context.Background(), testHandler, events.APIGatewayProxyRequest{
+		HTTPMethod:      http.MethodPost,
+		Headers:         map[string]string{"Accept-Encoding": "gzip", "Origin": "https://app.test.com"},
+		Body:            "eyJtZXNzYWdlIjoiaGkifQ==",
+		IsBase64Encoded: true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"message\":\"hi\"}", received)
+	assert.True(t, resp.IsBase64Encoded)
+	assert.Equal(t, "gzip", resp.Headers["Content-Encoding"])
+	assert.Equal(t, "Accept-Encoding, Origin", resp.Headers["Vary"])
+
+	resp, err = testRegistry.RunHandler(context.Background(), testHandler, events.APIGatewayProxyRequest{
+		HTTPMethod:      http.MethodPost,
+		Body:            "not base64!",
+		IsBase64Encoded: true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRunHandlerRateLimited(t *testing.T) {
+	testHandler := func(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{
+			Body: "Test Response",
+		}, nil
+	}
+
+	appCfg := appconfig.NewAppConfig()
+	appCfg.RateLimitPerMinute = 0
+	appCfg.RateLimitEndpoints = map[string]int{"POST /feedback": 1}
+	testRegistry := NewRegistry(appCfg, nil, nil, nil, nil, WithRateLimiter(ratelimit.NewMemoryLimiter()))
+
+	request := func(path, email string) events.APIGatewayProxyRequest {
+		return events.APIGatewayProxyRequest{
+			HTTPMethod: http.MethodPost,
+			RequestContext: events.APIGatewayProxyRequestContext{
+				ResourcePath: path,
+				Authorizer: map[string]interface{}{
+					"claims": map[string]interface{}{"email": email},
+				},
+			},
+		}
+	}
+
+	resp, err := testRegistry.RunHandler(context.Background(), testHandler, request("/Stage/feedback", "valid@example.com"))
+	assert.Nil(t, err)
+	assert.Equal(t, "Test Response", resp.Body)
+
+	resp, err = testRegistry.RunHandler(context.Background(), testHandler, request("/feedback", "valid@example.com"))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "60", resp.Headers["Retry-After"])
+
+	resp, _ = testRegistry.RunHandler(context.Background(), testHandler, request("/feedback", "other@example.com"))
+	assert.Equal(t, "Test Response", resp.Body)
+
+	for i := 0; i < 3; i++ {
+		resp, _ = testRegistry.RunHandler(context.Background(), testHandler, request("/event", "valid@example.com"))
+		assert.Equal(t, "Test Response", resp.Body)
+	}
+}
+
+func TestCallerIdentity(t *testing.T) {
+	withClaims := func(claims map[string]interface{}) events.APIGatewayProxyRequest {
+		return events.APIGatewayProxyRequest{
+			RequestContext: events.APIGatewayProxyRequestContext{
+				Authorizer: map[string]interface{}{"claims": claims},
+				Identity:   events.APIGatewayRequestIdentity{SourceIP: "203.0.113.7"},
+			},
+		}
+	}
+
+	assert.Equal(t, "abc-123", callerIdentity(withClaims(map[string]interface{}{"sub": "abc-123", "email": "valid@example.com"})))
+	assert.Equal(t, "valid@example.com", callerIdentity(withClaims(map[string]interface{}{"email": "Valid@Example.com"})))
+	assert.Equal(t, "203.0.113.7", callerIdentity(withClaims(nil)))
+	assert.Equal(t, anonymousActor, callerIdentity(events.APIGatewayProxyRequest{}))
+}
+
+func TestGetTask(t *testing.T) {
+	testRegistry := NewRegistry(nil, nil, nil, nil, nil)
+
+	task, ok := testRegistry.GetTask(FlushActivityDigestsTask)
+	assert.True(t, ok)
+	assert.NotNil(t, task)
+
+	_, ok = testRegistry.GetTask("bad-task")
+	assert.False(t, ok)
+}
+
+func TestRunTask(t *testing.T) {
+	testRegistry := &Registry{}
+
+	ran := false
+	err := testRegistry.RunTask(context.Background(), func(ctx context.Context, params HandlerParams) error {
+		ran = true
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.True(t, ran)
+}
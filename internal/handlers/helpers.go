@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,7 +11,9 @@ import (
 	"time"
 
 	"github.com/aws/aws-lambda-go/events"
-	"github.com/go-playground/validator/v10"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/validation"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
 )
 
 const (
@@ -64,8 +67,7 @@ func readRequestBody(requestBody string, requestStruct interface{}) error {
 		return err
 	}
 
-	validate := validator.New()
-	err = validate.Struct(requestStruct)
+	err = validation.Struct(requestStruct)
 	if err != nil {
 		return err
 	}
@@ -73,6 +75,76 @@ func readRequestBody(requestBody string, requestStruct interface{}) error {
 	return nil
 }
 
+// createRequestBodyErrorResponse reports which fields failed validation, or a plain
+// bad request when the body could not be decoded at all.
+func createRequestBodyErrorResponse(err error) events.APIGatewayProxyResponse {
+	if fieldErrors := validation.FieldErrors(err); len(fieldErrors) > 0 {
+		return response.CreateValidationErrorResponse(fieldErrors)
+	}
+	return response.CreateBadRequestResponse()
+}
+
+// normalizeEmail gives every spelling of an address the same form before it is stored or looked up.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// authorizerClaim reads a claim that the Cognito authorizer attached to the request.
+func authorizerClaim(request events.APIGatewayProxyRequest, claim string) string {
+	claims, ok := request.RequestContext.Authorizer["claims"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	value, _ := claims[claim].(string)
+	return value
+}
+
+// decodeRequestBody undoes the base64 encoding API Gateway applies to bodies it treats as
+// binary, so handlers always see the raw JSON.
+func decodeRequestBody(request events.APIGatewayProxyRequest) (events.APIGatewayProxyRequest, error) {
+	if !request.IsBase64Encoded {
+		return request, nil
+	}
+
+	body, err := base64.StdEncoding.DecodeString(request.Body)
+	if err != nil {
+		return request, err
+	}
+	request.Body = string(body)
+	request.IsBase64Encoded = false
+	return request, nil
+}
+
+// requestHeader looks up a request header by name. API Gateway passes headers through with
+// whatever casing the client sent.
+func requestHeader(request events.APIGatewayProxyRequest, name string) string {
+	if value, ok := request.Headers[name]; ok {
+		return value
+	}
+	for key, value := range request.Headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// isAdmin reports whether the authenticated caller's email is one of the configured admins.
+func isAdmin(params HandlerParams) bool {
+	email := normalizeEmail(authorizerClaim(params.Request, "email"))
+	if email == "" {
+		return false
+	}
+
+	for _, admin := range params.AppCfg.AdminEmails {
+		if normalizeEmail(admin) == email {
+			return true
+		}
+	}
+	return false
+}
+
 func validateTimestamps(startTime, endTime string) error {
 	st, err := time.Parse(time.RFC3339, startTime)
 	if err != nil {
@@ -90,3 +162,7 @@ func validateTimestamps(startTime, endTime string) error {
 
 	return nil
 }
+
+func displayName(u user.User) string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s", u.FirstName, u.LastName))
+}
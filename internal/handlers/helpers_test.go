@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -191,3 +193,91 @@ func TestReadRequestBody(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorizerClaim(t *testing.T) {
+	tests := map[string]struct {
+		request       events.APIGatewayProxyRequest
+		expectedValue string
+	}{
+		"Happy Path - Claim Present": {
+			request: events.APIGatewayProxyRequest{
+				RequestContext: events.APIGatewayProxyRequestContext{
+					Authorizer: map[string]interface{}{
+						"claims": map[string]interface{}{
+							"email": "good@test.com",
+						},
+					},
+				},
+			},
+			expectedValue: "good@test.com",
+		},
+		"Sad Path - Claim Missing": {
+			request: events.APIGatewayProxyRequest{
+				RequestContext: events.APIGatewayProxyRequestContext{
+					Authorizer: map[string]interface{}{
+						"claims": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		"Sad Path - No Authorizer": {
+			request: events.APIGatewayProxyRequest{},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedValue, authorizerClaim(tc.request, "email"))
+		})
+	}
+}
+
+func TestDecodeRequestBody(t *testing.T) {
+	request, err := decodeRequestBody(events.APIGatewayProxyRequest{Body: "{\"message\":\"hi\"}"})
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"message\":\"hi\"}", request.Body)
+
+	request, err = decodeRequestBody(events.APIGatewayProxyRequest{Body: "eyJtZXNzYWdlIjoiaGkifQ==", IsBase64Encoded: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"message\":\"hi\"}", request.Body)
+	assert.False(t, request.IsBase64Encoded)
+
+	_, err = decodeRequestBody(events.APIGatewayProxyRequest{Body: "not base64!", IsBase64Encoded: true})
+	assert.NotNil(t, err)
+}
+
+func TestRequestHeader(t *testing.T) {
+	request := events.APIGatewayProxyRequest{
+		Headers: map[string]string{"origin": "https://app.test.com"},
+	}
+
+	assert.Equal(t, "https://app.test.com", requestHeader(request, "Origin"))
+	assert.Equal(t, "", requestHeader(request, "Accept-Encoding"))
+}
+
+func TestIsAdmin(t *testing.T) {
+	appCfg := &appconfig.AppConfig{AdminEmails: []string{"Admin@Test.com"}}
+	withEmail := func(email string) events.APIGatewayProxyRequest {
+		return events.APIGatewayProxyRequest{
+			RequestContext: events.APIGatewayProxyRequestContext{
+				Authorizer: map[string]interface{}{
+					"claims": map[string]interface{}{"email": email},
+				},
+			},
+		}
+	}
+
+	assert.True(t, isAdmin(HandlerParams{AppCfg: appCfg, Request: withEmail(" admin@test.com")}))
+	assert.False(t, isAdmin(HandlerParams{AppCfg: appCfg, Request: withEmail("valid@example.com")}))
+	assert.False(t, isAdmin(HandlerParams{AppCfg: appCfg, Request: events.APIGatewayProxyRequest{}}))
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "good@test.com", normalizeEmail("  Good@Test.COM\n"))
+	assert.Equal(t, "good@test.com", normalizeEmail("good@test.com"))
+}
+
+func TestDisplayName(t *testing.T) {
+	assert.Equal(t, "John Doe", displayName(user.User{FirstName: "John", LastName: "Doe"}))
+	assert.Equal(t, "John", displayName(user.User{FirstName: "John"}))
+	assert.Equal(t, "", displayName(user.User{}))
+}
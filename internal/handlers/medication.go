@@ -0,0 +1,397 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	awsevents "github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/changelog"
+	"github.com/care-giver-app/care-giver-api/internal/medication"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/relationship"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
+	"go.uber.org/zap"
+)
+
+const (
+	addReceiverMedication    = "add receiver medication"
+	getReceiverMedications   = "get receiver medications"
+	updateReceiverMedication = "update receiver medication"
+	logMedicationDose        = "log medication dose"
+
+	medicationDatabaseError = "error retrieving medication from db"
+)
+
+type MedicationRequest struct {
+	Name            string `json:"name" validate:"required,max=200,freetext"`
+	Dose            string `json:"dose" validate:"required,max=100,freetext"`
+	Route           string `json:"route" validate:"omitempty,max=100,freetext"`
+	Prescriber      string `json:"prescriber" validate:"omitempty,max=200,freetext"`
+	Instructions    string `json:"instructions" validate:"omitempty,max=2000,freetext"`
+	Inventory       *int   `json:"inventory" validate:"omitempty,min=0"`
+	UnitsPerDose    int    `json:"unitsPerDose" validate:"omitempty,min=1"`
+	RefillThreshold int    `json:"refillThreshold" validate:"min=0"`
+}
+
+func (mr MedicationRequest) details() medication.Details {
+	return medication.Details{
+		Name:            mr.Name,
+		Dose:            mr.Dose,
+		Route:           mr.Route,
+		Prescriber:      mr.Prescriber,
+		Instructions:    mr.Instructions,
+		Inventory:       mr.Inventory,
+		UnitsPerDose:    mr.UnitsPerDose,
+		RefillThreshold: mr.RefillThreshold,
+	}
+}
+
+type MedicationEntry struct {
+	medication.Medication
+	RefillNeeded bool `json:"refillNeeded"`
+}
+
+type MedicationResponse struct {
+	Medication MedicationEntry `json:"medication"`
+	Status     string          `json:"status"`
+}
+
+type GetMedicationsResponse struct {
+	Medications []MedicationEntry `json:"medications"`
+	Status      string            `json:"status"`
+}
+
+type DoseRequest struct {
+	StartTime string `json:"startTime" validate:"required"`
+	EndTime   string `json:"endTime"`
+	Quantity  int    `json:"quantity" validate:"omitempty,min=1"`
+	Note      string `json:"note" validate:"omitempty,max=2000,freetext"`
+}
+
+type DoseResponse struct {
+	ReceiverID   string `json:"receiverId"`
+	MedicationID string `json:"medicationId"`
+	EventID      string `json:"eventId"`
+	Inventory    int    `json:"inventory"`
+	RefillNeeded bool   `json:"refillNeeded"`
+	Status       string `json:"status"`
+}
+
+func newMedicationEntry(m medication.Medication) MedicationEntry {
+	return MedicationEntry{Medication: m, RefillNeeded: m.NeedsRefill()}
+}
+
+func HandleAddReceiverMedication(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, addReceiverMedication)
+
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	var mr MedicationRequest
+	err = readRequestBody(params.Request.Body, &mr)
+	if err != nil {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		return createRequestBodyErrorResponse(err), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	m := medication.NewMedication(rid, u.UserID, mr.details())
+	err = params.MedicationRepo.AddMedication(m)
+	if err != nil {
+		params.AppCfg.Logger.Error("error adding medication to db", zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	recordAudit(params, rid, u.UserID, audit.ActionAddMedication,
+		audit.WithTarget(medication.ParamID, m.MedicationID),
+		audit.WithAfter(m),
+	)
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, addReceiverMedication)
+	return response.FormatResponse(MedicationResponse{
+		Medication: newMedicationEntry(*m),
+		Status:     response.Success,
+	}, http.StatusOK), nil
+}
+
+func HandleGetReceiverMedications(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, getReceiverMedications)
+
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	medications, err := params.MedicationRepo.GetMedications(rid)
+	if err != nil {
+		params.AppCfg.Logger.Error(medicationDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	entries := make([]MedicationEntry, 0, len(medications))
+	for _, m := range medications {
+		entries = append(entries, newMedicationEntry(m))
+	}
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getReceiverMedications)
+	return response.FormatResponse(GetMedicationsResponse{
+		Medications: entries,
+		Status:      response.Success,
+	}, http.StatusOK), nil
+}
+
+func HandleUpdateReceiverMedication(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, updateReceiverMedication)
+
+	mid, err := validatePathParameters(params.Request, medication.ParamID, medication.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, medication.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	rid, err := validateQueryParameters(params.Request, receiver.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	var mr MedicationRequest
+	err = readRequestBody(params.Request.Body, &mr)
+	if err != nil {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		return createRequestBodyErrorResponse(err), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	m, err := params.MedicationRepo.GetMedication(rid, mid)
+	if errors.Is(err, medication.ErrNotFound) {
+		params.AppCfg.Logger.Error("medication not found", zap.String(log.ReceiverIDLogKey, rid), zap.String(medication.ParamID, mid))
+		return response.CreateResourceNotFoundResponse(), nil
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error(medicationDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	updated, err := params.MedicationRepo.UpdateMedication(rid, mid, mr.details())
+	if errors.Is(err, medication.ErrNotFound) {
+		params.AppCfg.Logger.Error("medication not found", zap.String(log.ReceiverIDLogKey, rid), zap.String(medication.ParamID, mid))
+		return response.CreateResourceNotFoundResponse(), nil
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error("error updating medication in db", zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	recordAudit(params, rid, u.UserID, audit.ActionUpdateMedication,
+		audit.WithTarget(medication.ParamID, mid),
+		audit.WithBefore(m),
+		audit.WithAfter(updated),
+	)
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, updateReceiverMedication)
+	return response.FormatResponse(MedicationResponse{
+		Medication: newMedicationEntry(*updated),
+		Status:     response.Success,
+	}, http.StatusOK), nil
+}
+
+// HandleLogMedicationDose takes a dose out of inventory and logs it as a medication event.
+// The inventory is decremented first so two caregivers logging at once cannot both use
+// the last pills; if the event cannot be written the units are put back.
+func HandleLogMedicationDose(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, logMedicationDose)
+
+	mid, err := validatePathParameters(params.Request, medication.ParamID, medication.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, medication.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	rid, err := validateQueryParameters(params.Request, receiver.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	var dr DoseRequest
+	err = readRequestBody(params.Request.Body, &dr)
+	if err != nil {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		return createRequestBodyErrorResponse(err), nil
+	}
+
+	if dr.EndTime == "" {
+		dr.EndTime = dr.StartTime
+	}
+
+	err = validateTimestamps(dr.StartTime, dr.EndTime)
+	if err != nil {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	m, err := params.MedicationRepo.GetMedication(rid, mid)
+	if errors.Is(err, medication.ErrNotFound) {
+		params.AppCfg.Logger.Error("medication not found", zap.String(log.ReceiverIDLogKey, rid), zap.String(medication.ParamID, mid))
+		return response.CreateResourceNotFoundResponse(), nil
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error(medicationDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	quantity := dr.Quantity
+	if quantity == 0 {
+		quantity = m.UnitsPerDose
+	}
+
+	updated, err := params.MedicationRepo.AdjustInventory(rid, mid, -quantity)
+	if errors.Is(err, medication.ErrInsufficientInventory) {
+		params.AppCfg.Logger.Warn("not enough medication on hand for dose", zap.String(medication.ParamID, mid), zap.Int("inventory", m.Inventory), zap.Int("quantity", quantity))
+		return response.FormatResponse(response.ErrorResponse{
+			DeveloperText: medication.ErrInsufficientInventory.Error(),
+			Status:        "Conflict",
+		}, http.StatusConflict), nil
+	}
+	if errors.Is(err, medication.ErrNotFound) {
+		params.AppCfg.Logger.Error("medication not found", zap.String(log.ReceiverIDLogKey, rid), zap.String(medication.ParamID, mid))
+		return response.CreateResourceNotFoundResponse(), nil
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error("error adjusting medication inventory", zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	newEvent, err := event.NewEntry(rid, u.UserID, medication.EventType, dr.StartTime, dr.EndTime, entryOptions(updated.DoseData(quantity), dr.Note)...)
+	if err == nil {
+		err = params.EventRepo.AddEvent(newEvent)
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error("error adding dose event to db", zap.Error(err))
+		if _, err := params.MedicationRepo.AdjustInventory(rid, mid, quantity); err != nil {
+			params.AppCfg.Logger.Error("error returning units for dose that was not logged", zap.String(medication.ParamID, mid), zap.Int("quantity", quantity), zap.Error(err))
+		}
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if updated.NeedsRefill() {
+		params.AppCfg.Logger.Warn("medication needs refill", zap.String(log.ReceiverIDLogKey, rid), zap.String(medication.ParamID, mid), zap.Int("inventory", updated.Inventory))
+	}
+
+	recordChange(params, changelog.OperationUpsert, *newEvent, u.UserID)
+	recordAudit(params, rid, u.UserID, audit.ActionLogDose,
+		audit.WithTarget(medication.ParamID, mid),
+		audit.WithTarget(event.ParamID, newEvent.EventID),
+		audit.WithAfter(newEvent),
+	)
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, logMedicationDose)
+	return response.FormatResponse(DoseResponse{
+		ReceiverID:   rid,
+		MedicationID: mid,
+		EventID:      newEvent.EventID,
+		Inventory:    updated.Inventory,
+		RefillNeeded: updated.NeedsRefill(),
+		Status:       response.Success,
+	}, http.StatusOK), nil
+}
@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/medication"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleAddReceiverMedication(t *testing.T) {
+	tests := map[string]struct {
+		receiverID         string
+		userID             string
+		body               string
+		expectedStatusCode int
+	}{
+		"Happy Path - Medication Added": {
+			receiverID:         "Receiver#123",
+			userID:             "User#123",
+			body:               "{\"name\":\"Lisinopril\", \"dose\":\"10mg\", \"route\":\"oral\", \"prescriber\":\"Dr. Patel\", \"inventory\":30, \"refillThreshold\":7}",
+			expectedStatusCode: http.StatusOK,
+		},
+		"Sad Path - Missing Dose": {
+			receiverID:         "Receiver#123",
+			userID:             "User#123",
+			body:               "{\"name\":\"Lisinopril\"}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Negative Inventory": {
+			receiverID:         "Receiver#123",
+			userID:             "User#123",
+			body:               "{\"name\":\"Lisinopril\", \"dose\":\"10mg\", \"inventory\":-1}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - User Is Not A Care Giver": {
+			receiverID:         "Receiver#123",
+			userID:             "User#NotACareGiver",
+			body:               "{\"name\":\"Lisinopril\", \"dose\":\"10mg\"}",
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - Error Adding Medication": {
+			receiverID:         "Receiver#Error",
+			userID:             "User#123",
+			body:               "{\"name\":\"Lisinopril\", \"dose\":\"10mg\"}",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodPost,
+					PathParameters:        map[string]string{"receiverId": tc.receiverID},
+					QueryStringParameters: map[string]string{"userId": tc.userID},
+					Body:                  tc.body,
+				},
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				AuditRepo:        testAuditRepo,
+				MedicationRepo:   testMedicationRepo,
+			}
+			resp, err := HandleAddReceiverMedication(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandleGetReceiverMedications(t *testing.T) {
+	tests := map[string]struct {
+		request          events.APIGatewayProxyRequest
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - Medications Retrieved With Refill Warning": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#123"},
+				QueryStringParameters: map[string]string{"userId": "User#123"},
+			},
+			expectedResponse: response.FormatResponse(GetMedicationsResponse{
+				Medications: []MedicationEntry{
+					{
+						Medication: medication.Medication{
+							ReceiverID:      "Receiver#123",
+							MedicationID:    "Medication#123",
+							Name:            "Lisinopril",
+							Dose:            "10mg",
+							Inventory:       4,
+							UnitsPerDose:    1,
+							RefillThreshold: 5,
+						},
+						RefillNeeded: true,
+					},
+				},
+				Status: response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - User Is Not A Care Giver": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#123"},
+				QueryStringParameters: map[string]string{"userId": "User#NotACareGiver"},
+			},
+			expectedResponse: response.CreateAccessDeniedResponse(),
+		},
+		"Sad Path - Error Getting Medications": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#Error"},
+				QueryStringParameters: map[string]string{"userId": "User#123"},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg:           appconfig.NewAppConfig(),
+				Request:          tc.request,
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				MedicationRepo:   testMedicationRepo,
+			}
+			resp, err := HandleGetReceiverMedications(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
+
+func TestHandleUpdateReceiverMedication(t *testing.T) {
+	tests := map[string]struct {
+		medicationID       string
+		body               string
+		expectedStatusCode int
+		expectedInventory  int
+	}{
+		"Happy Path - Medication Restocked": {
+			medicationID:       "Medication#123",
+			body:               "{\"name\":\"Lisinopril\", \"dose\":\"20mg\", \"inventory\":90, \"refillThreshold\":10}",
+			expectedStatusCode: http.StatusOK,
+			expectedInventory:  90,
+		},
+		"Happy Path - Edit Without Inventory Keeps Stock": {
+			medicationID:       "Medication#123",
+			body:               "{\"name\":\"Lisinopril\", \"dose\":\"20mg\"}",
+			expectedStatusCode: http.StatusOK,
+			expectedInventory:  6,
+		},
+		"Sad Path - Negative Inventory": {
+			medicationID:       "Medication#123",
+			body:               "{\"name\":\"Lisinopril\", \"dose\":\"20mg\", \"inventory\":-1}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Medication Not Found": {
+			medicationID:       "Medication#NotFound",
+			body:               "{\"name\":\"Lisinopril\", \"dose\":\"20mg\"}",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		"Sad Path - Error Updating Medication": {
+			medicationID:       "Medication#UpdateError",
+			body:               "{\"name\":\"Lisinopril\", \"dose\":\"20mg\"}",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		"Sad Path - Bad Path Parameters": {
+			medicationID:       "Event#123",
+			body:               "{\"name\":\"Lisinopril\", \"dose\":\"20mg\"}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodPut,
+					PathParameters:        map[string]string{"medicationId": tc.medicationID},
+					QueryStringParameters: map[string]string{"receiverId": "Receiver#123", "userId": "User#123"},
+					Body:                  tc.body,
+				},
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				AuditRepo:        testAuditRepo,
+				MedicationRepo:   testMedicationRepo,
+			}
+			resp, err := HandleUpdateReceiverMedication(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedStatusCode == http.StatusOK {
+				var body MedicationResponse
+				assert.Nil(t, json.Unmarshal([]byte(resp.Body), &body))
+				assert.Equal(t, tc.expectedInventory, body.Medication.Inventory)
+			}
+		})
+	}
+}
+
+func TestHandleLogMedicationDose(t *testing.T) {
+	tests := map[string]struct {
+		medicationID       string
+		receiverID         string
+		body               string
+		expectedStatusCode int
+		expectedInventory  int
+		expectedRefill     bool
+	}{
+		"Happy Path - Dose Logged With Refill Warning": {
+			medicationID:       "Medication#123",
+			receiverID:         "Receiver#123",
+			body:               "{\"startTime\":\"2026-05-01T08:00:00Z\"}",
+			expectedStatusCode: http.StatusOK,
+			expectedInventory:  5,
+			expectedRefill:     true,
+		},
+		"Happy Path - Dose Logged With Quantity": {
+			medicationID:       "Medication#123",
+			receiverID:         "Receiver#123",
+			body:               "{\"startTime\":\"2026-05-01T08:00:00Z\", \"quantity\":2, \"note\":\"with food\"}",
+			expectedStatusCode: http.StatusOK,
+			expectedInventory:  4,
+			expectedRefill:     true,
+		},
+		"Sad Path - Out Of Stock": {
+			medicationID:       "Medication#Empty",
+			receiverID:         "Receiver#123",
+			body:               "{\"startTime\":\"2026-05-01T08:00:00Z\"}",
+			expectedStatusCode: http.StatusConflict,
+		},
+		"Sad Path - Medication Not Found": {
+			medicationID:       "Medication#NotFound",
+			receiverID:         "Receiver#123",
+			body:               "{\"startTime\":\"2026-05-01T08:00:00Z\"}",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		"Sad Path - Bad Start Time": {
+			medicationID:       "Medication#123",
+			receiverID:         "Receiver#123",
+			body:               "{\"startTime\":\"this morning\"}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Error Adjusting Inventory": {
+			medicationID:       "Medication#AdjustError",
+			receiverID:         "Receiver#123",
+			body:               "{\"startTime\":\"2026-05-01T08:00:00Z\"}",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		"Sad Path - Error Adding Event Returns Units": {
+			medicationID:       "Medication#123",
+			receiverID:         "Receiver#Error",
+			body:               "{\"startTime\":\"2026-05-01T08:00:00Z\"}",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodPost,
+					PathParameters:        map[string]string{"medicationId": tc.medicationID},
+					QueryStringParameters: map[string]string{"receiverId": tc.receiverID, "userId": "User#123"},
+					Body:                  tc.body,
+				},
+				UserRepo:         testUserRepo,
+				EventRepo:        testEventRepo,
+				RelationshipRepo: testRelationshipRepo,
+				AuditRepo:        testAuditRepo,
+				ChangeLogRepo:    testChangeLogRepo,
+				MedicationRepo:   testMedicationRepo,
+			}
+			resp, err := HandleLogMedicationDose(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var responseBody DoseResponse
+			err = json.Unmarshal([]byte(resp.Body), &responseBody)
+			assert.Nil(t, err)
+			assert.NotEmpty(t, responseBody.EventID)
+			assert.Equal(t, tc.expectedInventory, responseBody.Inventory)
+			assert.Equal(t, tc.expectedRefill, responseBody.RefillNeeded)
+		})
+	}
+}
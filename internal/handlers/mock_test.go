@@ -2,7 +2,21 @@ package handlers
 
 import (
 	"errors"
+	"time"
 
+	"github.com/care-giver-app/care-giver-api/internal/alert"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/calendarfeed"
+	"github.com/care-giver-app/care-giver-api/internal/changelog"
+	"github.com/care-giver-app/care-giver-api/internal/digest"
+	"github.com/care-giver-app/care-giver-api/internal/emailclaim"
+	"github.com/care-giver-app/care-giver-api/internal/eventbatch"
+	"github.com/care-giver-app/care-giver-api/internal/feedback"
+	"github.com/care-giver-app/care-giver-api/internal/medication"
+	"github.com/care-giver-app/care-giver-api/internal/preference"
+	"github.com/care-giver-app/care-giver-api/internal/profile"
+	"github.com/care-giver-app/care-giver-api/internal/schedule"
+	"github.com/care-giver-app/care-giver-api/internal/tombstone"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/relationship"
@@ -15,6 +29,19 @@ var (
 	testReceiverRepo     = &MockReceiverRepo{}
 	testEventRepo        = &MockEventRepo{}
 	testRelationshipRepo = &MockRelationshipRepo{}
+	testTombstoneRepo    = &MockTombstoneRepo{}
+	testAuditRepo        = &MockAuditRepo{}
+	testProfileRepo      = &MockProfileRepo{}
+	testEmailClaimRepo   = &MockEmailClaimRepo{}
+	testEventBatchRepo   = &MockEventBatchRepo{}
+	testChangeLogRepo    = &MockChangeLogRepo{}
+	testScheduleRepo     = &MockScheduleRepo{}
+	testMedicationRepo   = &MockMedicationRepo{}
+	testAlertRuleRepo    = &MockAlertRuleRepo{}
+	testPreferenceRepo   = &MockPreferenceRepo{}
+	testDigestRepo       = &MockDigestRepo{}
+	testFeedbackRepo     = &MockFeedbackRepo{}
+	testCalendarFeedRepo = &MockCalendarFeedRepo{}
 )
 
 type MockUserRepo struct{}
@@ -45,6 +72,14 @@ func (mu *MockUserRepo) GetUser(uid string) (user.User, error) {
 		return user.User{
 			UserID: "User#NotACareGiver",
 		}, nil
+	case "User#NotAPrimaryCareGiver":
+		return user.User{
+			UserID: "User#NotAPrimaryCareGiver",
+		}, nil
+	case "User#Deletable", "User#SolePrimary", "User#SoleCareGiver", "User#AnonymizeError", "User#WithProfile", "User#Syncer", "User#Exporter", "User#FeedError":
+		return user.User{
+			UserID: uid,
+		}, nil
 	case "User#456":
 		return user.User{
 			UserID:    "User#456",
@@ -69,6 +104,10 @@ func (mu *MockUserRepo) GetUserByEmail(email string) (user.User, error) {
 		return user.User{
 			UserID: "User#RelationshipError",
 		}, nil
+	case "Legacy@Example.com":
+		return user.User{
+			UserID: "User#456",
+		}, nil
 	}
 	return user.User{}, errors.New("unsupported mock")
 }
@@ -101,6 +140,12 @@ func (mr *MockReceiverRepo) GetReceiver(rid string) (receiver.Receiver, error) {
 		return receiver.Receiver{
 			FirstName: "Success",
 		}, nil
+	case "Receiver#Export":
+		return receiver.Receiver{
+			ReceiverID: "Receiver#Export",
+			FirstName:  "Mary",
+			LastName:   "Jones",
+		}, nil
 	case "Receiver#Error":
 		return receiver.Receiver{}, errors.New("error retrieving from db")
 	}
@@ -127,6 +172,57 @@ func (me *MockEventRepo) GetEvents(rid string, bound repository.TimestampBound)
 				ReceiverID: "Receiver#123",
 			},
 		}, nil
+	case "Receiver#Sync":
+		return []event.Entry{
+			{
+				EventID:    "Event#9c1f3a52-5d0e-4d8c-8f6e-0a4b1c2d3e4f",
+				ReceiverID: "Receiver#Sync",
+				UserID:     "User#Syncer",
+				Type:       "Shower",
+				StartTime:  "2023-10-01T12:00:00Z",
+				EndTime:    "2023-10-01T12:30:00Z",
+			},
+		}, nil
+	case "Receiver#Vitals":
+		return []event.Entry{
+			{
+				EventID:    "Event#Weight1",
+				ReceiverID: "Receiver#Vitals",
+				Type:       "Weight",
+				StartTime:  "2023-10-01T08:00:00Z",
+				EndTime:    "2023-10-01T08:00:00Z",
+				Data:       []event.DataPoint{{Name: "weight", Value: "150"}},
+			},
+			{
+				EventID:    "Event#Weight2",
+				ReceiverID: "Receiver#Vitals",
+				Type:       "Weight",
+				StartTime:  "2023-10-01T20:00:00Z",
+				EndTime:    "2023-10-01T20:00:00Z",
+				Data:       []event.DataPoint{{Name: "weight", Value: "151"}},
+			},
+		}, nil
+	case "Receiver#Export":
+		return []event.Entry{
+			{
+				EventID:    "Event#Weight",
+				ReceiverID: "Receiver#Export",
+				UserID:     "User#456",
+				Type:       "Weight",
+				StartTime:  "2025-03-10T14:00:00Z",
+				EndTime:    "2025-03-10T14:00:00Z",
+				Data:       []event.DataPoint{{Name: "weight", Value: "150.5"}},
+				Note:       "After breakfast",
+			},
+			{
+				EventID:    "Event#Shower",
+				ReceiverID: "Receiver#Export",
+				UserID:     "User#Gone",
+				Type:       "Shower",
+				StartTime:  "2025-03-10T13:00:00Z",
+				EndTime:    "2025-03-10T13:30:00Z",
+			},
+		}, nil
 	case "Receiver#Error":
 		return nil, errors.New("error retrieving events")
 	}
@@ -146,6 +242,72 @@ func (me *MockEventRepo) DeleteEvent(rid, eid string) error {
 	return errors.New("unsupported mock")
 }
 
+type MockTombstoneRepo struct{}
+
+func (mt *MockTombstoneRepo) AddTombstone(t *tombstone.Tombstone) error {
+	switch t.ReceiverID {
+	case "Receiver#123":
+		return nil
+	}
+	return errors.New("unsupported mock")
+}
+
+func (mt *MockTombstoneRepo) GetTombstone(rid, eid string) (*tombstone.Tombstone, error) {
+	switch eid {
+	case "Event#Deleted":
+		return &tombstone.Tombstone{
+			ReceiverID: rid,
+			EventID:    eid,
+			Event: event.Entry{
+				EventID:    eid,
+				ReceiverID: rid,
+			},
+			DeletedBy: "User#123",
+		}, nil
+	case "Event#NotFound":
+		return nil, tombstone.ErrNotFound
+	case "Event#Error":
+		return nil, errors.New("error retrieving tombstone")
+	}
+	return nil, errors.New("unsupported mock")
+}
+
+func (mt *MockTombstoneRepo) GetTombstones(rid string) ([]tombstone.Tombstone, error) {
+	switch rid {
+	case "Receiver#123":
+		return []tombstone.Tombstone{
+			{
+				ReceiverID: "Receiver#123",
+				EventID:    "Event#Deleted",
+				Event: event.Entry{
+					EventID:    "Event#Deleted",
+					ReceiverID: "Receiver#123",
+				},
+				DeletedBy: "User#123",
+				DeletedAt: "2026-04-23T12:00:00Z",
+			},
+		}, nil
+	case "Receiver#Sync":
+		return []tombstone.Tombstone{
+			{
+				ReceiverID: "Receiver#Sync",
+				EventID:    "Event#4b7e2d10-8a3c-4f5e-9b1d-2c6a8e0f1d3b",
+				DeletedBy:  "User#Syncer",
+				DeletedAt:  "2026-04-23T12:00:00Z",
+			},
+		}, nil
+	}
+	return nil, errors.New("unsupported mock")
+}
+
+func (mt *MockTombstoneRepo) DeleteTombstone(rid, eid string) error {
+	switch rid {
+	case "Receiver#123":
+		return nil
+	}
+	return errors.New("unsupported mock")
+}
+
 type MockRelationshipRepo struct{}
 
 func (mr *MockRelationshipRepo) GetRelationshipsByUser(uid string) ([]relationship.Relationship, error) {
@@ -188,6 +350,49 @@ func (mr *MockRelationshipRepo) GetRelationshipsByUser(uid string) ([]relationsh
 				EmailNotifications: true,
 			},
 		}, nil
+	case "User#Deletable":
+		return []relationship.Relationship{
+			{
+				UserID:     "User#Deletable",
+				ReceiverID: "Receiver#123",
+			},
+		}, nil
+	case "User#SolePrimary":
+		return []relationship.Relationship{
+			{
+				UserID:           "User#SolePrimary",
+				ReceiverID:       "Receiver#SolePrimary",
+				PrimaryCareGiver: true,
+			},
+		}, nil
+	case "User#SoleCareGiver":
+		return []relationship.Relationship{
+			{
+				UserID:           "User#SoleCareGiver",
+				ReceiverID:       "Receiver#SoleCareGiver",
+				PrimaryCareGiver: true,
+			},
+		}, nil
+	case "User#AnonymizeError":
+		return []relationship.Relationship{}, nil
+	case "User#Syncer":
+		return []relationship.Relationship{
+			{
+				UserID:     "User#Syncer",
+				ReceiverID: "Receiver#Sync",
+			},
+			{
+				UserID:     "User#Syncer",
+				ReceiverID: "Receiver#Vitals",
+			},
+		}, nil
+	case "User#Exporter":
+		return []relationship.Relationship{
+			{
+				UserID:     "User#Exporter",
+				ReceiverID: "Receiver#Export",
+			},
+		}, nil
 	case "User#RelationshipError":
 		return nil, errors.New("error retrieving relationships from db")
 	}
@@ -226,9 +431,42 @@ func (mr *MockRelationshipRepo) GetRelationshipsByReceiver(rid string) ([]relati
 				PrimaryCareGiver: true,
 			},
 			{
-				UserID:           "User#456",
-				ReceiverID:       "Receiver#123",
-				PrimaryCareGiver: false,
+				UserID:             "User#456",
+				ReceiverID:         "Receiver#123",
+				PrimaryCareGiver:   false,
+				EmailNotifications: true,
+			},
+		}, nil
+	case "Receiver#SolePrimary":
+		return []relationship.Relationship{
+			{
+				UserID:           "User#SolePrimary",
+				ReceiverID:       "Receiver#SolePrimary",
+				PrimaryCareGiver: true,
+			},
+			{
+				UserID:     "User#456",
+				ReceiverID: "Receiver#SolePrimary",
+			},
+		}, nil
+	case "Receiver#SoleCareGiver":
+		return []relationship.Relationship{
+			{
+				UserID:           "User#SoleCareGiver",
+				ReceiverID:       "Receiver#SoleCareGiver",
+				PrimaryCareGiver: true,
+			},
+		}, nil
+	case "Receiver#Export":
+		return []relationship.Relationship{
+			{
+				UserID:           "User#WithProfile",
+				ReceiverID:       "Receiver#Export",
+				PrimaryCareGiver: true,
+			},
+			{
+				UserID:     "User#Exporter",
+				ReceiverID: "Receiver#Export",
 			},
 		}, nil
 	case "Receiver#RelationshipError":
@@ -244,3 +482,522 @@ func (mr *MockRelationshipRepo) GetRelationshipsByReceiver(rid string) ([]relati
 	}
 	return nil, errors.New("unsupported mock")
 }
+
+type MockAuditRepo struct{}
+
+func (ma *MockAuditRepo) AddEntry(e *audit.Entry) error {
+	return nil
+}
+
+func (ma *MockAuditRepo) GetEntries(scopeID string) ([]audit.Entry, error) {
+	switch scopeID {
+	case "Receiver#123":
+		return []audit.Entry{
+			{
+				ScopeID: "Receiver#123",
+				AuditID: "2026-04-23T12:00:00Z#123",
+				ActorID: "User#123",
+				Action:  audit.ActionAddEvent,
+			},
+		}, nil
+	case "Receiver#RelationshipError":
+		return nil, errors.New("error retrieving audit entries")
+	}
+	return nil, errors.New("unsupported mock")
+}
+
+type MockProfileRepo struct {
+	userEmails []profile.UserEmail
+	listErr    error
+}
+
+func (mp *MockProfileRepo) GetProfile(uid string) (profile.Profile, error) {
+	switch uid {
+	case "User#123", "User#UpdateError":
+		return profile.Profile{
+			FirstName: "John",
+			LastName:  "Doe",
+		}, nil
+	case "User#WithProfile":
+		return profile.Profile{
+			FirstName: "Jane",
+			LastName:  "Smith",
+			Phone:     "555-0100",
+			Timezone:  "America/Chicago",
+			NotificationPreferences: profile.NotificationPreferences{
+				Email: true,
+			},
+		}, nil
+	case "User#NotFound":
+		return profile.Profile{}, profile.ErrUserNotFound
+	}
+	return profile.Profile{}, errors.New("unsupported mock")
+}
+
+func (mp *MockProfileRepo) UpdateProfile(uid string, p profile.Profile) error {
+	switch uid {
+	case "User#123":
+		return nil
+	case "User#UpdateError":
+		return errors.New("error updating profile")
+	}
+	return errors.New("unsupported mock")
+}
+
+func (mp *MockProfileRepo) AnonymizeUser(uid string) error {
+	switch uid {
+	case "User#AnonymizeError":
+		return errors.New("error anonymizing user")
+	}
+	return nil
+}
+
+func (mp *MockProfileRepo) ListUserEmails() ([]profile.UserEmail, error) {
+	return mp.userEmails, mp.listErr
+}
+
+type MockEmailClaimRepo struct{}
+
+func (mc *MockEmailClaimRepo) ClaimEmail(email, uid string) error {
+	if _, ok := claimedEmails[email]; ok {
+		return emailclaim.ErrEmailTaken
+	}
+
+	switch email {
+	case "claimerror@test.com":
+		return errors.New("error claiming email")
+	}
+	return nil
+}
+
+// claimedEmails maps the caller emails used by ownership checks to the users that claimed them.
+var claimedEmails = map[string]string{
+	"taken@test.com":             "User#Taken",
+	"valid@example.com":          "User#123",
+	"notfound@example.com":       "User#NotFound",
+	"usererror@example.com":      "User#Error",
+	"updateerror@example.com":    "User#UpdateError",
+	"deletable@example.com":      "User#Deletable",
+	"soleprimary@example.com":    "User#SolePrimary",
+	"solecaregiver@example.com":  "User#SoleCareGiver",
+	"anonymizeerror@example.com": "User#AnonymizeError",
+	"feederror@example.com":      "User#FeedError",
+}
+
+func (mc *MockEmailClaimRepo) GetClaim(email string) (*emailclaim.Claim, error) {
+	if uid, ok := claimedEmails[email]; ok {
+		return &emailclaim.Claim{
+			Email:  email,
+			UserID: uid,
+		}, nil
+	}
+
+	switch email {
+	case "claimerror@example.com":
+		return nil, errors.New("error getting email claim")
+	}
+	return nil, emailclaim.ErrClaimNotFound
+}
+
+func (mc *MockEmailClaimRepo) ReleaseEmail(email, uid string) error {
+	return nil
+}
+
+type MockEventBatchRepo struct{}
+
+func (me *MockEventBatchRepo) AddEvents(entries []*event.Entry) ([]*event.Entry, error) {
+	var unprocessed []*event.Entry
+	for _, e := range entries {
+		if e.ReceiverID == "Receiver#Error" {
+			unprocessed = append(unprocessed, e)
+		}
+	}
+
+	if len(unprocessed) > 0 {
+		return unprocessed, errors.New("error batch writing events")
+	}
+	return nil, nil
+}
+
+// GetEvent reads from the same fixtures as MockEventRepo.GetEvents.
+func (me *MockEventBatchRepo) GetEvent(rid, eid string) (*event.Entry, error) {
+	entries, err := testEventRepo.GetEvents(rid, repository.TimestampBound{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		if e.EventID == eid {
+			return &e, nil
+		}
+	}
+	return nil, eventbatch.ErrEventNotFound
+}
+
+// racedEventID is free when an upload reads the receiver's events but taken by the time it is written.
+const racedEventID = "Event#2d9a6c3e-4f1b-4a7d-8e5c-3b0f9d1a6e42"
+
+func (me *MockEventBatchRepo) CreateEvents(entries []*event.Entry) map[string]error {
+	failed := map[string]error{}
+	for _, e := range entries {
+		switch e.EventID {
+		case racedEventID:
+			failed[e.EventID] = eventbatch.ErrEventExists
+		case "Event#5e2c8d41-7b9a-4f0e-a6d3-1f8b2c9e0a7d":
+			failed[e.EventID] = errors.New("error creating event")
+		}
+	}
+	return failed
+}
+
+type MockChangeLogRepo struct{}
+
+func (mc *MockChangeLogRepo) AddChange(c *changelog.Change) error {
+	return nil
+}
+
+func (mc *MockChangeLogRepo) GetChanges(rid string, since string) ([]changelog.Change, error) {
+	switch rid {
+	case "Receiver#123":
+		return []changelog.Change{
+			{
+				ReceiverID: "Receiver#123",
+				ChangeID:   "1",
+				EventID:    "Event#123",
+				Operation:  changelog.OperationUpsert,
+				Event: event.Entry{
+					EventID:    "Event#123",
+					ReceiverID: "Receiver#123",
+				},
+			},
+			{
+				ReceiverID: "Receiver#123",
+				ChangeID:   "2",
+				EventID:    "Event#456",
+				Operation:  changelog.OperationUpsert,
+			},
+			{
+				ReceiverID: "Receiver#123",
+				ChangeID:   "3",
+				EventID:    "Event#456",
+				Operation:  changelog.OperationDelete,
+				ChangedBy:  "User#123",
+				ChangedAt:  "2026-04-23T12:00:00Z",
+			},
+		}, nil
+	case "Receiver#Error":
+		return nil, errors.New("error retrieving changes")
+	}
+	return nil, errors.New("unsupported mock")
+}
+
+type MockScheduleRepo struct{}
+
+func (ms *MockScheduleRepo) AddSchedule(s *schedule.Schedule) error {
+	switch s.ReceiverID {
+	case "Receiver#123":
+		return nil
+	case "Receiver#Error":
+		return errors.New("error adding schedule")
+	}
+	return errors.New("unsupported mock")
+}
+
+func (ms *MockScheduleRepo) GetSchedules(rid string) ([]schedule.Schedule, error) {
+	switch rid {
+	case "Receiver#123":
+		return []schedule.Schedule{}, nil
+	case "Receiver#Sync":
+		return []schedule.Schedule{
+			{
+				ReceiverID:         "Receiver#Sync",
+				ScheduleID:         "Schedule#123",
+				Type:               "Shower",
+				RRule:              "FREQ=DAILY",
+				StartTime:          "2023-10-01T12:00:00Z",
+				Timezone:           "UTC",
+				MatchWindowMinutes: 60,
+			},
+		}, nil
+	case "Receiver#Vitals":
+		return []schedule.Schedule{}, nil
+	case "Receiver#Error":
+		return nil, errors.New("error retrieving schedules")
+	}
+	return nil, errors.New("unsupported mock")
+}
+
+func (ms *MockScheduleRepo) DeleteSchedule(rid, sid string) error {
+	switch sid {
+	case "Schedule#123":
+		return nil
+	case "Schedule#NotFound":
+		return schedule.ErrNotFound
+	case "Schedule#Error":
+		return errors.New("error deleting schedule")
+	}
+	return errors.New("unsupported mock")
+}
+
+type MockMedicationRepo struct{}
+
+func (mm *MockMedicationRepo) AddMedication(m *medication.Medication) error {
+	switch m.ReceiverID {
+	case "Receiver#123":
+		return nil
+	case "Receiver#Error":
+		return errors.New("error adding medication")
+	}
+	return errors.New("unsupported mock")
+}
+
+func (mm *MockMedicationRepo) GetMedication(rid, mid string) (*medication.Medication, error) {
+	switch mid {
+	case "Medication#123", "Medication#Empty", "Medication#AdjustError", "Medication#UpdateError":
+		return &medication.Medication{
+			ReceiverID:      rid,
+			MedicationID:    mid,
+			Name:            "Lisinopril",
+			Dose:            "10mg",
+			Inventory:       6,
+			UnitsPerDose:    1,
+			RefillThreshold: 5,
+		}, nil
+	case "Medication#NotFound":
+		return nil, medication.ErrNotFound
+	case "Medication#Error":
+		return nil, errors.New("error retrieving medication")
+	}
+	return nil, errors.New("unsupported mock")
+}
+
+func (mm *MockMedicationRepo) GetMedications(rid string) ([]medication.Medication, error) {
+	switch rid {
+	case "Receiver#123":
+		return []medication.Medication{
+			{
+				ReceiverID:      "Receiver#123",
+				MedicationID:    "Medication#123",
+				Name:            "Lisinopril",
+				Dose:            "10mg",
+				Inventory:       4,
+				UnitsPerDose:    1,
+				RefillThreshold: 5,
+			},
+		}, nil
+	case "Receiver#Error":
+		return nil, errors.New("error retrieving medications")
+	}
+	return nil, errors.New("unsupported mock")
+}
+
+func (mm *MockMedicationRepo) UpdateMedication(rid, mid string, d medication.Details) (*medication.Medication, error) {
+	switch mid {
+	case "Medication#123":
+		m, _ := mm.GetMedication(rid, mid)
+		m.Apply(d)
+		return m, nil
+	case "Medication#UpdateError":
+		return nil, errors.New("error updating medication")
+	}
+	return nil, errors.New("unsupported mock")
+}
+
+func (mm *MockMedicationRepo) AdjustInventory(rid, mid string, delta int) (*medication.Medication, error) {
+	switch mid {
+	case "Medication#123":
+		m, _ := mm.GetMedication(rid, mid)
+		m.Inventory += delta
+		return m, nil
+	case "Medication#Empty":
+		if delta < 0 {
+			return nil, medication.ErrInsufficientInventory
+		}
+		return mm.GetMedication(rid, mid)
+	case "Medication#AdjustError":
+		return nil, errors.New("error adjusting inventory")
+	}
+	return nil, errors.New("unsupported mock")
+}
+
+type MockAlertRuleRepo struct{}
+
+func (ma *MockAlertRuleRepo) AddRule(rule *alert.Rule) error {
+	switch rule.ReceiverID {
+	case "Receiver#123":
+		return nil
+	case "Receiver#Error":
+		return errors.New("error adding alert rule")
+	}
+	return errors.New("unsupported mock")
+}
+
+func (ma *MockAlertRuleRepo) GetRules(rid string) ([]alert.Rule, error) {
+	switch rid {
+	case "Receiver#123":
+		return []alert.Rule{
+			{
+				ReceiverID: "Receiver#123",
+				RuleID:     "AlertRule#123",
+				Type:       "Weight",
+				DataPoint:  "weight",
+				Operator:   alert.OperatorGreaterThan,
+				Threshold:  300,
+			},
+		}, nil
+	case "Receiver#Error":
+		return nil, errors.New("error retrieving alert rules")
+	}
+	return nil, errors.New("unsupported mock")
+}
+
+func (ma *MockAlertRuleRepo) DeleteRule(rid, ruleID string) error {
+	switch ruleID {
+	case "AlertRule#123":
+		return nil
+	case "AlertRule#NotFound":
+		return alert.ErrNotFound
+	}
+	return errors.New("unsupported mock")
+}
+
+type MockPreferenceRepo struct {
+	// quietHoursAround, when set, moves User#123's quiet hours to an hour either side of it.
+	quietHoursAround time.Time
+}
+
+func (mp *MockPreferenceRepo) GetPreferences(uid, rid string) (preference.Preferences, error) {
+	switch uid {
+	case "User#123":
+		p := preference.Preferences{
+			UserID:          "User#123",
+			ReceiverID:      rid,
+			EventTypes:      []string{"Medication"},
+			QuietHoursStart: "22:00",
+			QuietHoursEnd:   "07:00",
+			Timezone:        "America/Chicago",
+			DigestMinutes:   30,
+		}
+		if !mp.quietHoursAround.IsZero() {
+			loc, _ := time.LoadLocation(p.Timezone)
+			local := mp.quietHoursAround.In(loc)
+			p.QuietHoursStart = local.Add(-time.Hour).Format("15:04")
+			p.QuietHoursEnd = local.Add(time.Hour).Format("15:04")
+		}
+		return p, nil
+	case "User#456", "User#Syncer":
+		return preference.Preferences{}, preference.ErrNotFound
+	}
+	return preference.Preferences{}, errors.New("unsupported mock")
+}
+
+func (mp *MockPreferenceRepo) PutPreferences(p preference.Preferences) error {
+	switch p.ReceiverID {
+	case "Receiver#123":
+		return nil
+	case "Receiver#Error":
+		return errors.New("error saving preferences")
+	}
+	return errors.New("unsupported mock")
+}
+
+type MockDigestRepo struct{}
+
+func (md *MockDigestRepo) AddItem(uid, rid, email string, item digest.Item, flushAt time.Time) error {
+	switch uid {
+	case "User#456":
+		return nil
+	}
+	return errors.New("unsupported mock")
+}
+
+func (md *MockDigestRepo) GetDueDigests(now time.Time) ([]digest.Digest, error) {
+	return []digest.Digest{
+		{
+			UserID:     "User#456",
+			ReceiverID: "Receiver#123",
+			Email:      "jane@test.com",
+			Items: []digest.Item{
+				{EventID: "Event#123", Type: "Shower", StartTime: "2023-10-01T12:00:00Z", LoggedBy: "John Doe"},
+			},
+		},
+	}, nil
+}
+
+func (md *MockDigestRepo) Reschedule(uid, rid string, flushAt time.Time) error {
+	return nil
+}
+
+func (md *MockDigestRepo) TakeDigest(uid, rid string) (digest.Digest, error) {
+	switch uid {
+	case "User#456":
+		return digest.Digest{
+			UserID:     "User#456",
+			ReceiverID: "Receiver#123",
+			Email:      "jane@test.com",
+			Items: []digest.Item{
+				{EventID: "Event#123", Type: "Shower", StartTime: "2023-10-01T12:00:00Z", LoggedBy: "John Doe"},
+			},
+		}, nil
+	}
+	return digest.Digest{}, digest.ErrNotFound
+}
+
+type MockFeedbackRepo struct{}
+
+func (mf *MockFeedbackRepo) AddFeedback(fb *feedback.Feedback) error {
+	switch fb.Message {
+	case "trigger db error":
+		return errors.New("error adding feedback")
+	}
+	return nil
+}
+
+func (mf *MockFeedbackRepo) ListFeedback(filter feedback.Filter) ([]feedback.Feedback, error) {
+	all := []feedback.Feedback{
+		{FeedbackID: "Feedback#1", Status: feedback.StatusResolved, Message: "Crash on login", Category: "bug", Platform: "ios", CreatedAt: "2025-03-01T12:00:00Z"},
+		{FeedbackID: "Feedback#3", Status: feedback.StatusNew, Message: "Add dark mode", Category: "idea", Platform: "android", CreatedAt: "2025-03-20T12:00:00Z"},
+		{FeedbackID: "Feedback#2", Status: feedback.StatusNew, Message: "Sync is slow", Category: "bug", Platform: "web", CreatedAt: "2025-03-10T12:00:00Z"},
+	}
+
+	items := []feedback.Feedback{}
+	for _, fb := range all {
+		if filter.Matches(fb) {
+			items = append(items, fb)
+		}
+	}
+	return items, nil
+}
+
+func (mf *MockFeedbackRepo) UpdateStatus(feedbackID string, status feedback.Status, updatedBy string) error {
+	switch feedbackID {
+	case "Feedback#123":
+		return nil
+	case "Feedback#Error":
+		return errors.New("error updating feedback status")
+	}
+	return feedback.ErrNotFound
+}
+
+// testCalendarToken unlocks the feeds returned by MockCalendarFeedRepo.
+var testCalendarFeed, testCalendarToken, _ = calendarfeed.NewFeed("User#Syncer")
+
+type MockCalendarFeedRepo struct{}
+
+func (mc *MockCalendarFeedRepo) GetFeed(uid string) (calendarfeed.Feed, error) {
+	switch uid {
+	case "User#Syncer", "User#RelationshipError":
+		return *testCalendarFeed, nil
+	case "User#Error":
+		return calendarfeed.Feed{}, errors.New("error retrieving calendar feed")
+	}
+	return calendarfeed.Feed{}, calendarfeed.ErrNotFound
+}
+
+func (mc *MockCalendarFeedRepo) PutFeed(f *calendarfeed.Feed) error {
+	switch f.UserID {
+	case "User#FeedError":
+		return errors.New("error saving calendar feed")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/ratelimit"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"go.uber.org/zap"
+)
+
+// rateLimit spends a token from the caller's bucket for the endpoint and returns a 429
+// response once the bucket is empty.
+func (r *Registry) rateLimit(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, bool) {
+	if r.RateLimiter == nil || r.AppCfg == nil || request.HTTPMethod == http.MethodOptions {
+		return events.APIGatewayProxyResponse{}, false
+	}
+
+	endpoint, _, _ := resolveEndpoint(request)
+	path := endpoint.Path
+	perMinute := r.AppCfg.RateLimit(request.HTTPMethod, path)
+	if perMinute <= 0 {
+		return events.APIGatewayProxyResponse{}, false
+	}
+
+	caller := callerIdentity(request)
+	key := fmt.Sprintf("%s|%s %s", caller, request.HTTPMethod, path)
+	decision := r.RateLimiter.Allow(ctx, key, ratelimit.PerMinute(perMinute))
+	if decision.Allowed {
+		return events.APIGatewayProxyResponse{}, false
+	}
+
+	r.AppCfg.Logger.Warn("rate limit exceeded",
+		zap.String("caller", caller),
+		zap.String(log.PathLogKey, path),
+		zap.String(log.MethodLogKey, request.HTTPMethod),
+		zap.Duration("retryAfter", decision.RetryAfter),
+	)
+	return response.CreateTooManyRequestsResponse(decision.RetryAfter), true
+}
+
+// callerIdentity prefers the authenticated Cognito subject and falls back to the source IP
+// for unauthenticated requests.
+func callerIdentity(request events.APIGatewayProxyRequest) string {
+	if sub := authorizerClaim(request, "sub"); sub != "" {
+		return sub
+	}
+	if email := authorizerClaim(request, "email"); email != "" {
+		return normalizeEmail(email)
+	}
+	if ip := request.RequestContext.Identity.SourceIP; ip != "" {
+		return ip
+	}
+	return anonymousActor
+}
@@ -0,0 +1,340 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	awsevents "github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/schedule"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/relationship"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/repository"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
+	"go.uber.org/zap"
+)
+
+const (
+	addReceiverSchedule    = "add receiver schedule"
+	getReceiverSchedules   = "get receiver schedules"
+	deleteReceiverSchedule = "delete receiver schedule"
+	getScheduleOccurrences = "get schedule occurrences"
+
+	scheduleDatabaseError = "error retrieving schedules from db"
+
+	// maxOccurrenceRange bounds how many days of occurrences are expanded per request.
+	maxOccurrenceRange = 92 * 24 * time.Hour
+)
+
+type ScheduleRequest struct {
+	Type               string `json:"type" validate:"required"`
+	Title              string `json:"title" validate:"omitempty,max=100,freetext"`
+	RRule              string `json:"rrule" validate:"required,max=500"`
+	StartTime          string `json:"startTime" validate:"required"`
+	Timezone           string `json:"timezone" validate:"omitempty,max=64"`
+	MatchWindowMinutes int    `json:"matchWindowMinutes" validate:"omitempty,min=1,max=1440"`
+}
+
+type ScheduleResponse struct {
+	ReceiverID string `json:"receiverId"`
+	ScheduleID string `json:"scheduleId"`
+	Status     string `json:"status"`
+}
+
+type GetSchedulesResponse struct {
+	Schedules []schedule.Schedule `json:"schedules"`
+	Status    string              `json:"status"`
+}
+
+type GetOccurrencesResponse struct {
+	Occurrences []schedule.Occurrence `json:"occurrences"`
+	Status      string                `json:"status"`
+}
+
+func HandleAddReceiverSchedule(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, addReceiverSchedule)
+
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	var sr ScheduleRequest
+	err = readRequestBody(params.Request.Body, &sr)
+	if err != nil {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		return createRequestBodyErrorResponse(err), nil
+	}
+
+	if !isEventConfigType(sr.Type) {
+		params.AppCfg.Logger.Error("schedule type is not an event config type", zap.String("type", sr.Type))
+		return response.CreateValidationErrorResponse([]response.FieldError{
+			{Field: "type", Message: "must be one of the event config types"},
+		}), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	s, err := schedule.NewSchedule(rid, u.UserID, sr.Type, sr.Title, sr.RRule, sr.StartTime, sr.Timezone, sr.MatchWindowMinutes)
+	if err != nil {
+		params.AppCfg.Logger.Error("error creating new schedule", zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	err = params.ScheduleRepo.AddSchedule(s)
+	if err != nil {
+		params.AppCfg.Logger.Error("error adding schedule to db", zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	recordAudit(params, rid, u.UserID, audit.ActionAddSchedule,
+		audit.WithTarget(schedule.ParamID, s.ScheduleID),
+		audit.WithAfter(s),
+	)
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, addReceiverSchedule)
+	return response.FormatResponse(ScheduleResponse{
+		ReceiverID: rid,
+		ScheduleID: s.ScheduleID,
+		Status:     response.Success,
+	}, http.StatusOK), nil
+}
+
+func HandleGetReceiverSchedules(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, getReceiverSchedules)
+
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	schedules, err := params.ScheduleRepo.GetSchedules(rid)
+	if err != nil {
+		params.AppCfg.Logger.Error(scheduleDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getReceiverSchedules)
+	return response.FormatResponse(GetSchedulesResponse{
+		Schedules: schedules,
+		Status:    response.Success,
+	}, http.StatusOK), nil
+}
+
+func HandleDeleteReceiverSchedule(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, deleteReceiverSchedule)
+
+	sid, err := validatePathParameters(params.Request, schedule.ParamID, schedule.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, schedule.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	rid, err := validateQueryParameters(params.Request, receiver.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	err = params.ScheduleRepo.DeleteSchedule(rid, sid)
+	if errors.Is(err, schedule.ErrNotFound) {
+		params.AppCfg.Logger.Error("schedule not found", zap.String(log.ReceiverIDLogKey, rid), zap.String(schedule.ParamID, sid))
+		return response.CreateResourceNotFoundResponse(), nil
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error("error deleting schedule from db", zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	recordAudit(params, rid, u.UserID, audit.ActionDeleteSchedule,
+		audit.WithTarget(schedule.ParamID, sid),
+	)
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, deleteReceiverSchedule)
+	return response.FormatResponse(ScheduleResponse{
+		ReceiverID: rid,
+		ScheduleID: sid,
+		Status:     response.Success,
+	}, http.StatusOK), nil
+}
+
+// HandleGetScheduleOccurrences expands every schedule for a receiver over the requested
+// range and marks each occurrence completed, missed or upcoming against the logged events.
+func HandleGetScheduleOccurrences(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, getScheduleOccurrences)
+
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	startTime := params.Request.QueryStringParameters["startTime"]
+	endTime := params.Request.QueryStringParameters["endTime"]
+	if err := validateTimestamps(startTime, endTime); err != nil {
+		params.AppCfg.Logger.Error("invalid date bound query params", zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	from, _ := time.Parse(time.RFC3339, startTime)
+	to, _ := time.Parse(time.RFC3339, endTime)
+	if to.Sub(from) > maxOccurrenceRange {
+		params.AppCfg.Logger.Error("occurrence range is too large", zap.String("startTime", startTime), zap.String("endTime", endTime))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	schedules, err := params.ScheduleRepo.GetSchedules(rid)
+	if err != nil {
+		params.AppCfg.Logger.Error(scheduleDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	// Events logged just outside the range can still complete an occurrence inside it.
+	widestWindow := time.Duration(0)
+	for _, s := range schedules {
+		widestWindow = max(widestWindow, s.MatchWindow())
+	}
+
+	eventsList, err := params.EventRepo.GetEvents(rid, repository.TimestampBound{
+		Lower: from.Add(-widestWindow).Format(time.RFC3339),
+		Upper: to.Add(widestWindow).Format(time.RFC3339),
+	})
+	if err != nil {
+		params.AppCfg.Logger.Error(eventDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	now := time.Now()
+	occurrences := []schedule.Occurrence{}
+	for _, s := range schedules {
+		scheduleOccurrences, err := s.Occurrences(from, to)
+		if err != nil {
+			params.AppCfg.Logger.Error("error expanding schedule", zap.String(schedule.ParamID, s.ScheduleID), zap.Error(err))
+			continue
+		}
+		occurrences = append(occurrences, schedule.Match(scheduleOccurrences, eventsList, s.MatchWindow(), now)...)
+	}
+
+	// Occurrence times carry their schedule's offset, so compare instants rather than strings.
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		ti, _ := time.Parse(time.RFC3339, occurrences[i].Time)
+		tj, _ := time.Parse(time.RFC3339, occurrences[j].Time)
+		return ti.Before(tj)
+	})
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getScheduleOccurrences)
+	return response.FormatResponse(GetOccurrencesResponse{
+		Occurrences: occurrences,
+		Status:      response.Success,
+	}, http.StatusOK), nil
+}
+
+func isEventConfigType(eventType string) bool {
+	configs, err := event.GetAllConfigs()
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(configs, func(c event.Config) bool {
+		return c.Type == eventType
+	})
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/schedule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleAddReceiverSchedule(t *testing.T) {
+	tests := map[string]struct {
+		receiverID         string
+		userID             string
+		body               string
+		expectedStatusCode int
+	}{
+		"Happy Path - Schedule Added": {
+			receiverID:         "Receiver#123",
+			userID:             "User#123",
+			body:               "{\"type\":\"Medication\", \"title\":\"Morning and evening pills\", \"rrule\":\"FREQ=DAILY;BYHOUR=8,20;BYMINUTE=0\", \"startTime\":\"2026-05-01T08:00:00-04:00\", \"timezone\":\"America/New_York\"}",
+			expectedStatusCode: http.StatusOK,
+		},
+		"Sad Path - Unknown Event Type": {
+			receiverID:         "Receiver#123",
+			userID:             "User#123",
+			body:               "{\"type\":\"Juggling\", \"rrule\":\"FREQ=DAILY\", \"startTime\":\"2026-05-01T08:00:00Z\"}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Invalid Recurrence": {
+			receiverID:         "Receiver#123",
+			userID:             "User#123",
+			body:               "{\"type\":\"Shower\", \"rrule\":\"FREQ=SOMETIMES\", \"startTime\":\"2026-05-01T08:00:00Z\"}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Missing Fields": {
+			receiverID:         "Receiver#123",
+			userID:             "User#123",
+			body:               "{\"type\":\"Shower\"}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - User Is Not A Care Giver": {
+			receiverID:         "Receiver#123",
+			userID:             "User#NotACareGiver",
+			body:               "{\"type\":\"Shower\", \"rrule\":\"FREQ=WEEKLY\", \"startTime\":\"2026-05-01T08:00:00Z\"}",
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - Error Adding Schedule": {
+			receiverID:         "Receiver#Error",
+			userID:             "User#123",
+			body:               "{\"type\":\"Shower\", \"rrule\":\"FREQ=WEEKLY\", \"startTime\":\"2026-05-01T08:00:00Z\"}",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodPost,
+					PathParameters:        map[string]string{"receiverId": tc.receiverID},
+					QueryStringParameters: map[string]string{"userId": tc.userID},
+					Body:                  tc.body,
+				},
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				AuditRepo:        testAuditRepo,
+				ScheduleRepo:     testScheduleRepo,
+			}
+			resp, err := HandleAddReceiverSchedule(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandleGetReceiverSchedules(t *testing.T) {
+	tests := map[string]struct {
+		request          events.APIGatewayProxyRequest
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - Schedules Retrieved": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#123"},
+				QueryStringParameters: map[string]string{"userId": "User#123"},
+			},
+			expectedResponse: response.FormatResponse(GetSchedulesResponse{
+				Schedules: []schedule.Schedule{},
+				Status:    response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - Bad Path Parameters": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				QueryStringParameters: map[string]string{"userId": "User#123"},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - User Is Not A Care Giver": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#123"},
+				QueryStringParameters: map[string]string{"userId": "User#NotACareGiver"},
+			},
+			expectedResponse: response.CreateAccessDeniedResponse(),
+		},
+		"Sad Path - Error Getting Schedules": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#Error"},
+				QueryStringParameters: map[string]string{"userId": "User#123"},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg:           appconfig.NewAppConfig(),
+				Request:          tc.request,
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				ScheduleRepo:     testScheduleRepo,
+			}
+			resp, err := HandleGetReceiverSchedules(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
+
+func TestHandleDeleteReceiverSchedule(t *testing.T) {
+	tests := map[string]struct {
+		scheduleID       string
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - Schedule Deleted": {
+			scheduleID: "Schedule#123",
+			expectedResponse: response.FormatResponse(ScheduleResponse{
+				ReceiverID: "Receiver#123",
+				ScheduleID: "Schedule#123",
+				Status:     response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - Schedule Not Found": {
+			scheduleID:       "Schedule#NotFound",
+			expectedResponse: response.CreateResourceNotFoundResponse(),
+		},
+		"Sad Path - Error Deleting Schedule": {
+			scheduleID:       "Schedule#Error",
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+		"Sad Path - Bad Path Parameters": {
+			scheduleID:       "Event#123",
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodDelete,
+					PathParameters:        map[string]string{"scheduleId": tc.scheduleID},
+					QueryStringParameters: map[string]string{"receiverId": "Receiver#123", "userId": "User#123"},
+				},
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				AuditRepo:        testAuditRepo,
+				ScheduleRepo:     testScheduleRepo,
+			}
+			resp, err := HandleDeleteReceiverSchedule(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
+
+func TestHandleGetScheduleOccurrences(t *testing.T) {
+	tests := map[string]struct {
+		request          events.APIGatewayProxyRequest
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - Occurrences Matched": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				PathParameters: map[string]string{"receiverId": "Receiver#Sync"},
+				QueryStringParameters: map[string]string{
+					"userId":    "User#Syncer",
+					"startTime": "2023-10-01T00:00:00Z",
+					"endTime":   "2023-10-02T23:59:59Z",
+				},
+			},
+			expectedResponse: response.FormatResponse(GetOccurrencesResponse{
+				Occurrences: []schedule.Occurrence{
+					{
+						ScheduleID: "Schedule#123",
+						Type:       "Shower",
+						Time:       "2023-10-01T12:00:00Z",
+						Status:     schedule.StatusCompleted,
+						EventID:    "Event#9c1f3a52-5d0e-4d8c-8f6e-0a4b1c2d3e4f",
+					},
+					{
+						ScheduleID: "Schedule#123",
+						Type:       "Shower",
+						Time:       "2023-10-02T12:00:00Z",
+						Status:     schedule.StatusMissed,
+					},
+				},
+				Status: response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - Missing Range": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#Sync"},
+				QueryStringParameters: map[string]string{"userId": "User#Syncer"},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Range Too Large": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				PathParameters: map[string]string{"receiverId": "Receiver#Sync"},
+				QueryStringParameters: map[string]string{
+					"userId":    "User#Syncer",
+					"startTime": "2023-01-01T00:00:00Z",
+					"endTime":   "2023-12-31T00:00:00Z",
+				},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Error Getting Schedules": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				PathParameters: map[string]string{"receiverId": "Receiver#Error"},
+				QueryStringParameters: map[string]string{
+					"userId":    "User#123",
+					"startTime": "2023-10-01T00:00:00Z",
+					"endTime":   "2023-10-02T00:00:00Z",
+				},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg:           appconfig.NewAppConfig(),
+				Request:          tc.request,
+				UserRepo:         testUserRepo,
+				EventRepo:        testEventRepo,
+				RelationshipRepo: testRelationshipRepo,
+				ScheduleRepo:     testScheduleRepo,
+			}
+			resp, err := HandleGetScheduleOccurrences(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	awsevents "github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/schedule"
+	"github.com/care-giver-app/care-giver-api/internal/summary"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/relationship"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/repository"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
+	"go.uber.org/zap"
+)
+
+const (
+	getReceiverSummary = "get receiver summary"
+
+	periodParam = "period"
+	dateParam   = "date"
+)
+
+type SummaryResponse struct {
+	ReceiverID   string                `json:"receiverId"`
+	Period       summary.Period        `json:"period"`
+	StartTime    string                `json:"startTime"`
+	EndTime      string                `json:"endTime"`
+	Types        []summary.TypeSummary `json:"types"`
+	Contributors []summary.Contributor `json:"contributors"`
+	Gaps         []schedule.Occurrence `json:"gaps"`
+	Status       string                `json:"status"`
+}
+
+// HandleGetReceiverSummary rolls a receiver's events for a calendar day, week or month up by
+// type and caregiver, and lists the scheduled occurrences in that period that were missed.
+// Periods follow the requesting user's timezone.
+func HandleGetReceiverSummary(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, getReceiverSummary)
+
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	period, err := summary.ParsePeriod(params.Request.QueryStringParameters[periodParam])
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, periodParam), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	loc := time.UTC
+	p, err := params.ProfileRepo.GetProfile(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Warn("unable to load profile timezone, summarizing in UTC", zap.String(log.UserIDLogKey, u.UserID), zap.Error(err))
+	} else {
+		loc = p.Location()
+	}
+
+	date := time.Now()
+	if value, ok := params.Request.QueryStringParameters[dateParam]; ok && value != "" {
+		date, err = time.ParseInLocation(summary.DateFormat, value, loc)
+		if err != nil {
+			params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, dateParam), zap.Error(err))
+			return response.CreateBadRequestResponse(), nil
+		}
+	}
+	from, to := summary.Window(period, date, loc)
+
+	schedules, err := params.ScheduleRepo.GetSchedules(rid)
+	if err != nil {
+		params.AppCfg.Logger.Error(scheduleDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	// Events logged just outside the period can still complete an occurrence inside it.
+	widestWindow := time.Duration(0)
+	for _, s := range schedules {
+		widestWindow = max(widestWindow, s.MatchWindow())
+	}
+
+	eventsList, err := params.EventRepo.GetEvents(rid, repository.TimestampBound{
+		Lower: from.Add(-widestWindow).Format(time.RFC3339),
+		Upper: to.Add(widestWindow).Format(time.RFC3339),
+	})
+	if err != nil {
+		params.AppCfg.Logger.Error(eventDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	inPeriod := eventsInRange(eventsList, from, to)
+
+	contributors := summary.Contributors(inPeriod)
+	for i, c := range contributors {
+		contributors[i].Name = contributorName(params, c.UserID)
+	}
+
+	now := time.Now()
+	gaps := []schedule.Occurrence{}
+	for _, s := range schedules {
+		occurrences, err := s.Occurrences(from, to)
+		if err != nil {
+			params.AppCfg.Logger.Error("error expanding schedule", zap.String(schedule.ParamID, s.ScheduleID), zap.Error(err))
+			continue
+		}
+
+		for _, o := range schedule.Match(occurrences, eventsList, s.MatchWindow(), now) {
+			if o.Status == schedule.StatusMissed {
+				gaps = append(gaps, o)
+			}
+		}
+	}
+
+	sort.SliceStable(gaps, func(i, j int) bool {
+		ti, _ := time.Parse(time.RFC3339, gaps[i].Time)
+		tj, _ := time.Parse(time.RFC3339, gaps[j].Time)
+		return ti.Before(tj)
+	})
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getReceiverSummary)
+	return response.FormatResponse(SummaryResponse{
+		ReceiverID:   rid,
+		Period:       period,
+		StartTime:    from.Format(time.RFC3339),
+		EndTime:      to.Format(time.RFC3339),
+		Types:        summary.Aggregate(inPeriod),
+		Contributors: contributors,
+		Gaps:         gaps,
+		Status:       response.Success,
+	}, http.StatusOK), nil
+}
+
+func eventsInRange(events []event.Entry, from, to time.Time) []event.Entry {
+	inRange := []event.Entry{}
+	for _, e := range events {
+		start, err := time.Parse(time.RFC3339, e.StartTime)
+		if err != nil || start.Before(from) || !start.Before(to) {
+			continue
+		}
+		inRange = append(inRange, e)
+	}
+	return inRange
+}
+
+// contributorName is best effort; a caregiver who has since left still counts towards the summary.
+func contributorName(params HandlerParams, uid string) string {
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Warn("unable to look up contributor", zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return ""
+	}
+	return displayName(u)
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/schedule"
+	"github.com/care-giver-app/care-giver-api/internal/summary"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleGetReceiverSummary(t *testing.T) {
+	tests := map[string]struct {
+		request          events.APIGatewayProxyRequest
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - Events Summarized": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				PathParameters: map[string]string{"receiverId": "Receiver#Sync"},
+				QueryStringParameters: map[string]string{
+					"userId": "User#Syncer",
+					"period": "day",
+					"date":   "2023-10-01",
+				},
+			},
+			expectedResponse: response.FormatResponse(SummaryResponse{
+				ReceiverID: "Receiver#Sync",
+				Period:     summary.PeriodDay,
+				StartTime:  "2023-10-01T00:00:00Z",
+				EndTime:    "2023-10-02T00:00:00Z",
+				Types: []summary.TypeSummary{
+					{Type: "Shower", Count: 1, TotalDurationMinutes: 30, AverageDurationMinutes: 30},
+				},
+				Contributors: []summary.Contributor{{UserID: "User#Syncer", Count: 1}},
+				Gaps:         []schedule.Occurrence{},
+				Status:       response.Success,
+			}, http.StatusOK),
+		},
+		"Happy Path - Missed Occurrence Reported As Gap": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				PathParameters: map[string]string{"receiverId": "Receiver#Sync"},
+				QueryStringParameters: map[string]string{
+					"userId": "User#Syncer",
+					"period": "day",
+					"date":   "2023-10-02",
+				},
+			},
+			expectedResponse: response.FormatResponse(SummaryResponse{
+				ReceiverID:   "Receiver#Sync",
+				Period:       summary.PeriodDay,
+				StartTime:    "2023-10-02T00:00:00Z",
+				EndTime:      "2023-10-03T00:00:00Z",
+				Types:        []summary.TypeSummary{},
+				Contributors: []summary.Contributor{},
+				Gaps: []schedule.Occurrence{
+					{
+						ScheduleID: "Schedule#123",
+						Type:       "Shower",
+						Time:       "2023-10-02T12:00:00Z",
+						Status:     schedule.StatusMissed,
+					},
+				},
+				Status: response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - Invalid Period": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#Sync"},
+				QueryStringParameters: map[string]string{"userId": "User#Syncer", "period": "year"},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Invalid Date": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#Sync"},
+				QueryStringParameters: map[string]string{"userId": "User#Syncer", "period": "week", "date": "10/01/2023"},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Not A Care Giver": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#123"},
+				QueryStringParameters: map[string]string{"userId": "User#NotACareGiver", "period": "day"},
+			},
+			expectedResponse: response.CreateAccessDeniedResponse(),
+		},
+		"Sad Path - Error Getting Schedules": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#Error"},
+				QueryStringParameters: map[string]string{"userId": "User#123", "period": "month"},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg:           appconfig.NewAppConfig(),
+				Request:          tc.request,
+				UserRepo:         testUserRepo,
+				EventRepo:        testEventRepo,
+				RelationshipRepo: testRelationshipRepo,
+				ProfileRepo:      testProfileRepo,
+				ScheduleRepo:     testScheduleRepo,
+			}
+			resp, err := HandleGetReceiverSummary(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
@@ -0,0 +1,406 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	awsevents "github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/changelog"
+	"github.com/care-giver-app/care-giver-api/internal/eventbatch"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/tombstone"
+	"github.com/care-giver-app/care-giver-api/internal/validation"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/relationship"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/repository"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	syncReceiverEvents   = "sync receiver events"
+	uploadReceiverEvents = "upload receiver events"
+
+	syncTokenParam = "syncToken"
+
+	// syncOverlap re-sends changes written just before the previous sync so a change that
+	// was committed while that sync was running is not missed. Clients apply changes by
+	// event ID, so receiving one twice is harmless.
+	syncOverlap = 5 * time.Second
+
+	syncItemCreated   = "Created"
+	syncItemUnchanged = "Unchanged"
+	syncItemConflict  = "Conflict"
+
+	syncConflictDeleted  = "event was deleted on the server"
+	syncConflictModified = "event already exists on the server with different content"
+	syncInvalidIDError   = "eventId must be an Event# prefixed UUID"
+	syncDuplicateIDError = "eventId appears more than once in the upload"
+)
+
+type SyncResponse struct {
+	Events    []event.Entry  `json:"events"`
+	Deleted   []DeletedEvent `json:"deleted"`
+	SyncToken string         `json:"syncToken"`
+	FullSync  bool           `json:"fullSync"`
+	Status    string         `json:"status"`
+}
+
+type DeletedEvent struct {
+	EventID   string `json:"eventId"`
+	DeletedBy string `json:"deletedBy"`
+	DeletedAt string `json:"deletedAt"`
+}
+
+type SyncUploadRequest struct {
+	Events []SyncUploadEvent `json:"events" validate:"required,min=1"`
+}
+
+type SyncUploadEvent struct {
+	EventID   string            `json:"eventId" validate:"required"`
+	Type      string            `json:"type" validate:"required"`
+	StartTime string            `json:"startTime" validate:"required"`
+	EndTime   string            `json:"endTime" validate:"required"`
+	Data      []event.DataPoint `json:"data"`
+	Note      string            `json:"note" validate:"omitempty,max=2000,freetext"`
+}
+
+type SyncUploadResult struct {
+	EventID     string                `json:"eventId"`
+	Status      string                `json:"status"`
+	Error       string                `json:"error,omitempty"`
+	Errors      []response.FieldError `json:"errors,omitempty"`
+	ServerEvent *event.Entry          `json:"serverEvent,omitempty"`
+}
+
+type SyncUploadResponse struct {
+	Results []SyncUploadResult `json:"results"`
+	Status  string             `json:"status"`
+}
+
+// recordChange adds an event mutation to the change log read by sync clients. Like the
+// audit log, a failure is logged since the mutation has already been applied.
+func recordChange(params HandlerParams, op changelog.Operation, e event.Entry, actorID string) {
+	err := params.ChangeLogRepo.AddChange(changelog.NewChange(e, op, actorID, params.AppCfg.EventRetention()))
+	if err != nil {
+		params.AppCfg.Logger.Error("error adding change to db", zap.String(log.ReceiverIDLogKey, e.ReceiverID), zap.String(event.ParamID, e.EventID), zap.Error(err))
+	}
+}
+
+// HandleSyncReceiverEvents returns everything that changed for a receiver since the
+// client's sync token. Without a token, or with one older than the change log keeps,
+// the client gets a full snapshot and should replace its local copy.
+func HandleSyncReceiverEvents(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, syncReceiverEvents)
+
+	rid, uid, errResp, ok := authorizeSyncRequest(params)
+	if !ok {
+		return errResp, nil
+	}
+
+	now := time.Now().UTC()
+	fullSync := true
+	var since time.Time
+	if value := params.Request.QueryStringParameters[syncTokenParam]; value != "" {
+		token, err := changelog.ParseToken(value)
+		if err != nil || token.ReceiverID != rid {
+			params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, syncTokenParam), zap.Error(err))
+			return response.CreateBadRequestResponse(), nil
+		}
+		since = token.Since
+		fullSync = now.Sub(since) >= params.AppCfg.EventRetention()
+	}
+
+	resp := SyncResponse{
+		Events:    []event.Entry{},
+		Deleted:   []DeletedEvent{},
+		SyncToken: changelog.Token{ReceiverID: rid, Since: now}.String(),
+		FullSync:  fullSync,
+		Status:    response.Success,
+	}
+
+	if fullSync {
+		eventsList, err := params.EventRepo.GetEvents(rid, repository.TimestampBound{})
+		if err != nil {
+			params.AppCfg.Logger.Error(eventDatabaseError, zap.Error(err))
+			return response.CreateInternalServerErrorResponse(), nil
+		}
+
+		tombstones, err := params.TombstoneRepo.GetTombstones(rid)
+		if err != nil {
+			params.AppCfg.Logger.Error(tombstoneDatabaseError, zap.Error(err))
+			return response.CreateInternalServerErrorResponse(), nil
+		}
+
+		resp.Events = append(resp.Events, eventsList...)
+		for _, ts := range tombstones {
+			resp.Deleted = append(resp.Deleted, DeletedEvent{EventID: ts.EventID, DeletedBy: ts.DeletedBy, DeletedAt: ts.DeletedAt})
+		}
+	} else {
+		changes, err := params.ChangeLogRepo.GetChanges(rid, since.Add(-syncOverlap).Format(changelog.SequenceFormat))
+		if err != nil {
+			params.AppCfg.Logger.Error("error retrieving changes from db", zap.Error(err))
+			return response.CreateInternalServerErrorResponse(), nil
+		}
+
+		for _, c := range changelog.Latest(changes) {
+			switch c.Operation {
+			case changelog.OperationUpsert:
+				resp.Events = append(resp.Events, c.Event)
+			case changelog.OperationDelete:
+				resp.Deleted = append(resp.Deleted, DeletedEvent{EventID: c.EventID, DeletedBy: c.ChangedBy, DeletedAt: c.ChangedAt})
+			}
+		}
+	}
+
+	params.AppCfg.Logger.Info("synced receiver events", zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, uid), zap.Bool("fullSync", fullSync))
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, syncReceiverEvents)
+	return response.FormatResponse(resp, http.StatusOK), nil
+}
+
+// HandleUploadReceiverEvents saves events created offline under the IDs the client
+// generated. Conflicts are resolved in favour of the server:
+//   - an ID that was deleted on the server stays deleted
+//   - an ID that already exists with the same content is accepted as a retry
+//   - an ID that already exists with different content keeps the server copy, which is returned
+func HandleUploadReceiverEvents(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, uploadReceiverEvents)
+
+	rid, uid, errResp, ok := authorizeSyncRequest(params)
+	if !ok {
+		return errResp, nil
+	}
+
+	var sur SyncUploadRequest
+	err := readRequestBody(params.Request.Body, &sur)
+	if err != nil {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		return createRequestBodyErrorResponse(err), nil
+	}
+
+	if len(sur.Events) > params.AppCfg.MaxBatchEvents {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Int("count", len(sur.Events)), zap.Int("max", params.AppCfg.MaxBatchEvents))
+		return response.CreateValidationErrorResponse([]response.FieldError{
+			{Field: "events", Message: fmt.Sprintf("must contain at most %d events", params.AppCfg.MaxBatchEvents)},
+		}), nil
+	}
+
+	eventsList, err := params.EventRepo.GetEvents(rid, repository.TimestampBound{})
+	if err != nil {
+		params.AppCfg.Logger.Error(eventDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	tombstones, err := params.TombstoneRepo.GetTombstones(rid)
+	if err != nil {
+		params.AppCfg.Logger.Error(tombstoneDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	existing := make(map[string]event.Entry, len(eventsList))
+	for _, e := range eventsList {
+		existing[e.EventID] = e
+	}
+
+	deleted := make(map[string]tombstone.Tombstone, len(tombstones))
+	for _, ts := range tombstones {
+		deleted[ts.EventID] = ts
+	}
+
+	results := make([]SyncUploadResult, len(sur.Events))
+	resultIndex := make(map[string]int, len(sur.Events))
+	entries := []*event.Entry{}
+
+	for i, item := range sur.Events {
+		results[i] = SyncUploadResult{EventID: item.EventID, Status: batchItemFailed}
+
+		if err := validation.Struct(item); err != nil {
+			results[i].Errors = validation.FieldErrors(err)
+			continue
+		}
+
+		if !isClientEventID(item.EventID) {
+			results[i].Error = syncInvalidIDError
+			continue
+		}
+
+		if _, seen := resultIndex[item.EventID]; seen {
+			results[i].Error = syncDuplicateIDError
+			continue
+		}
+		resultIndex[item.EventID] = i
+
+		if err := validateTimestamps(item.StartTime, item.EndTime); err != nil {
+			results[i].Error = batchInvalidTimestampsError
+			continue
+		}
+
+		if _, found := deleted[item.EventID]; found {
+			results[i].Status = syncItemConflict
+			results[i].Error = syncConflictDeleted
+			continue
+		}
+
+		if e, found := existing[item.EventID]; found {
+			compareWithServer(&results[i], e, item)
+			continue
+		}
+
+		newEvent, err := event.NewEntry(rid, uid, item.Type, item.StartTime, item.EndTime, entryOptions(item.Data, item.Note)...)
+		if err != nil {
+			params.AppCfg.Logger.Error("error creating new event entry", zap.String(event.ParamID, item.EventID), zap.Error(err))
+			results[i].Error = batchInvalidEventError
+			continue
+		}
+		newEvent.EventID = item.EventID
+
+		results[i].Status = syncItemCreated
+		entries = append(entries, newEvent)
+	}
+
+	if len(entries) > 0 {
+		// Each event is only written if its ID is still free, so one created since the
+		// events were read above is reported as a conflict rather than overwritten.
+		failed := params.EventBatchRepo.CreateEvents(entries)
+		raced := []string{}
+		for eid, err := range failed {
+			i := resultIndex[eid]
+			if errors.Is(err, eventbatch.ErrEventExists) {
+				raced = append(raced, eid)
+				continue
+			}
+			params.AppCfg.Logger.Error("error adding uploaded event to db", zap.String(event.ParamID, eid), zap.Error(err))
+			results[i].Status = batchItemFailed
+			results[i].Error = batchWriteError
+		}
+
+		if len(raced) > 0 {
+			resolveRacedUploads(params, rid, raced, sur.Events, resultIndex, results)
+		}
+
+		for _, e := range entries {
+			if results[resultIndex[e.EventID]].Status != syncItemCreated {
+				continue
+			}
+			recordChange(params, changelog.OperationUpsert, *e, uid)
+			recordAudit(params, rid, uid, audit.ActionAddEvent,
+				audit.WithTarget(event.ParamID, e.EventID),
+				audit.WithAfter(e),
+			)
+		}
+	}
+
+	resp := SyncUploadResponse{Results: results, Status: response.Success}
+	statusCode := http.StatusOK
+	for _, result := range results {
+		if result.Status == batchItemFailed {
+			resp.Status = batchPartialSuccess
+			statusCode = http.StatusMultiStatus
+			break
+		}
+	}
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, uploadReceiverEvents)
+	return response.FormatResponse(resp, statusCode), nil
+}
+
+// resolveRacedUploads sets the results for uploaded events whose IDs were taken between
+// reading the receiver's events and writing them. When the server copy cannot be read
+// they are still reported as conflicts, and the next sync returns the server copy.
+func resolveRacedUploads(params HandlerParams, rid string, raced []string, items []SyncUploadEvent, resultIndex map[string]int, results []SyncUploadResult) {
+	params.AppCfg.Logger.Warn("uploaded events were created concurrently", zap.String(log.ReceiverIDLogKey, rid), zap.Strings("eventIds", raced))
+
+	current := map[string]event.Entry{}
+	eventsList, err := params.EventRepo.GetEvents(rid, repository.TimestampBound{})
+	if err != nil {
+		params.AppCfg.Logger.Error(eventDatabaseError, zap.Error(err))
+	}
+	for _, e := range eventsList {
+		current[e.EventID] = e
+	}
+
+	for _, eid := range raced {
+		i := resultIndex[eid]
+		results[i] = SyncUploadResult{EventID: eid}
+		e, found := current[eid]
+		if !found {
+			results[i].Status = syncItemConflict
+			results[i].Error = syncConflictModified
+			continue
+		}
+		compareWithServer(&results[i], e, items[i])
+	}
+}
+
+// compareWithServer sets the result for an uploaded event whose ID already exists on the server.
+func compareWithServer(result *SyncUploadResult, e event.Entry, item SyncUploadEvent) {
+	if sameEventContent(e, item) {
+		result.Status = syncItemUnchanged
+		return
+	}
+	result.Status = syncItemConflict
+	result.Error = syncConflictModified
+	result.ServerEvent = &e
+}
+
+// authorizeSyncRequest checks the receiver and user parameters shared by the sync
+// endpoints and that the user is a caregiver for the receiver.
+func authorizeSyncRequest(params HandlerParams) (string, string, awsevents.APIGatewayProxyResponse, bool) {
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return "", "", response.CreateBadRequestResponse(), false
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return "", "", response.CreateBadRequestResponse(), false
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return "", "", response.CreateInternalServerErrorResponse(), false
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return "", "", response.CreateInternalServerErrorResponse(), false
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return "", "", response.CreateAccessDeniedResponse(), false
+	}
+	return rid, u.UserID, awsevents.APIGatewayProxyResponse{}, true
+}
+
+// isClientEventID accepts IDs in the same Event#<uuid> form the server generates, so
+// uploaded events cannot collide with other kinds of records.
+func isClientEventID(eid string) bool {
+	id, found := strings.CutPrefix(eid, event.DBPrefix+idSeparator)
+	if !found {
+		return false
+	}
+	_, err := uuid.Parse(id)
+	return err == nil
+}
+
+func sameEventContent(e event.Entry, item SyncUploadEvent) bool {
+	return e.Type == item.Type &&
+		e.StartTime == item.StartTime &&
+		e.EndTime == item.EndTime &&
+		e.Note == item.Note &&
+		slices.Equal(e.Data, item.Data)
+}
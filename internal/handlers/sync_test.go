@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/changelog"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleSyncReceiverEvents(t *testing.T) {
+	recentToken := changelog.Token{ReceiverID: "Receiver#123", Since: time.Now().Add(-time.Hour)}.String()
+	expiredToken := changelog.Token{ReceiverID: "Receiver#123", Since: time.Now().Add(-365 * 24 * time.Hour)}.String()
+
+	tests := map[string]struct {
+		request            events.APIGatewayProxyRequest
+		expectedStatusCode int
+		expectedEvents     []event.Entry
+		expectedDeleted    []DeletedEvent
+		expectedFullSync   bool
+	}{
+		"Happy Path - Full Sync Without Token": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#123"},
+				QueryStringParameters: map[string]string{"userId": "User#123"},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedEvents: []event.Entry{
+				{EventID: "Event#123", ReceiverID: "Receiver#123"},
+			},
+			expectedDeleted: []DeletedEvent{
+				{EventID: "Event#Deleted", DeletedBy: "User#123", DeletedAt: "2026-04-23T12:00:00Z"},
+			},
+			expectedFullSync: true,
+		},
+		"Happy Path - Delta Sync": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#123"},
+				QueryStringParameters: map[string]string{"userId": "User#123", "syncToken": recentToken},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedEvents: []event.Entry{
+				{EventID: "Event#123", ReceiverID: "Receiver#123"},
+			},
+			expectedDeleted: []DeletedEvent{
+				{EventID: "Event#456", DeletedBy: "User#123", DeletedAt: "2026-04-23T12:00:00Z"},
+			},
+		},
+		"Happy Path - Expired Token Falls Back To Full Sync": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#123"},
+				QueryStringParameters: map[string]string{"userId": "User#123", "syncToken": expiredToken},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedEvents: []event.Entry{
+				{EventID: "Event#123", ReceiverID: "Receiver#123"},
+			},
+			expectedDeleted: []DeletedEvent{
+				{EventID: "Event#Deleted", DeletedBy: "User#123", DeletedAt: "2026-04-23T12:00:00Z"},
+			},
+			expectedFullSync: true,
+		},
+		"Sad Path - Invalid Token": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#123"},
+				QueryStringParameters: map[string]string{"userId": "User#123", "syncToken": "not-a-token"},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Token For Another Receiver": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#Error"},
+				QueryStringParameters: map[string]string{"userId": "User#123", "syncToken": recentToken},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - Bad Path Parameters": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				QueryStringParameters: map[string]string{"userId": "User#123"},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - User Is Not A Care Giver": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#123"},
+				QueryStringParameters: map[string]string{"userId": "User#NotACareGiver"},
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - Error Getting Events": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				PathParameters:        map[string]string{"receiverId": "Receiver#Error"},
+				QueryStringParameters: map[string]string{"userId": "User#123"},
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		"Sad Path - Error Getting Changes": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				PathParameters: map[string]string{"receiverId": "Receiver#Error"},
+				QueryStringParameters: map[string]string{
+					"userId":    "User#123",
+					"syncToken": changelog.Token{ReceiverID: "Receiver#Error", Since: time.Now()}.String(),
+				},
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg:           appconfig.NewAppConfig(),
+				Request:          tc.request,
+				UserRepo:         testUserRepo,
+				EventRepo:        testEventRepo,
+				RelationshipRepo: testRelationshipRepo,
+				TombstoneRepo:    testTombstoneRepo,
+				ChangeLogRepo:    testChangeLogRepo,
+			}
+			resp, err := HandleSyncReceiverEvents(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var responseBody SyncResponse
+			err = json.Unmarshal([]byte(resp.Body), &responseBody)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedEvents, responseBody.Events)
+			assert.Equal(t, tc.expectedDeleted, responseBody.Deleted)
+			assert.Equal(t, tc.expectedFullSync, responseBody.FullSync)
+
+			token, err := changelog.ParseToken(responseBody.SyncToken)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.request.PathParameters["receiverId"], token.ReceiverID)
+		})
+	}
+}
+
+func TestHandleUploadReceiverEvents(t *testing.T) {
+	newID := "Event#0f8e6b4a-1c2d-4e3f-8a9b-7c6d5e4f3a2b"
+	existingID := "Event#9c1f3a52-5d0e-4d8c-8f6e-0a4b1c2d3e4f"
+	deletedID := "Event#4b7e2d10-8a3c-4f5e-9b1d-2c6a8e0f1d3b"
+
+	uploadEvent := func(eid string) map[string]interface{} {
+		return map[string]interface{}{
+			"eventId":   eid,
+			"type":      "Shower",
+			"startTime": "2023-10-01T12:00:00Z",
+			"endTime":   "2023-10-01T12:30:00Z",
+		}
+	}
+
+	modified := uploadEvent(existingID)
+	modified["note"] = "edited offline"
+
+	tests := map[string]struct {
+		userID             string
+		receiverID         string
+		requestBody        map[string]interface{}
+		expectedStatusCode int
+		expectedResults    []SyncUploadResult
+	}{
+		"Happy Path - Conflict Rules Applied": {
+			userID:     "User#Syncer",
+			receiverID: "Receiver#Sync",
+			requestBody: map[string]interface{}{
+				"events": []interface{}{
+					uploadEvent(newID),
+					uploadEvent(existingID),
+					modified,
+					uploadEvent(deletedID),
+				},
+			},
+			expectedStatusCode: http.StatusMultiStatus,
+			expectedResults: []SyncUploadResult{
+				{EventID: newID, Status: syncItemCreated},
+				{EventID: existingID, Status: syncItemUnchanged},
+				{EventID: existingID, Status: batchItemFailed, Error: syncDuplicateIDError},
+				{EventID: deletedID, Status: syncItemConflict, Error: syncConflictDeleted},
+			},
+		},
+		"Happy Path - Modified Event Keeps Server Copy": {
+			userID:     "User#Syncer",
+			receiverID: "Receiver#Sync",
+			requestBody: map[string]interface{}{
+				"events": []interface{}{modified},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResults: []SyncUploadResult{
+				{EventID: existingID, Status: syncItemConflict, Error: syncConflictModified, ServerEvent: &event.Entry{
+					EventID:    existingID,
+					ReceiverID: "Receiver#Sync",
+					UserID:     "User#Syncer",
+					Type:       "Shower",
+					StartTime:  "2023-10-01T12:00:00Z",
+					EndTime:    "2023-10-01T12:30:00Z",
+				}},
+			},
+		},
+		"Happy Path - Event Created Concurrently Is A Conflict": {
+			userID:     "User#Syncer",
+			receiverID: "Receiver#Sync",
+			requestBody: map[string]interface{}{
+				"events": []interface{}{uploadEvent(newID), uploadEvent(racedEventID)},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResults: []SyncUploadResult{
+				{EventID: newID, Status: syncItemCreated},
+				{EventID: racedEventID, Status: syncItemConflict, Error: syncConflictModified},
+			},
+		},
+		"Sad Path - Error Creating Event": {
+			userID:     "User#Syncer",
+			receiverID: "Receiver#Sync",
+			requestBody: map[string]interface{}{
+				"events": []interface{}{uploadEvent("Event#5e2c8d41-7b9a-4f0e-a6d3-1f8b2c9e0a7d")},
+			},
+			expectedStatusCode: http.StatusMultiStatus,
+			expectedResults: []SyncUploadResult{
+				{EventID: "Event#5e2c8d41-7b9a-4f0e-a6d3-1f8b2c9e0a7d", Status: batchItemFailed, Error: batchWriteError},
+			},
+		},
+		"Sad Path - Invalid Event IDs": {
+			userID:     "User#Syncer",
+			receiverID: "Receiver#Sync",
+			requestBody: map[string]interface{}{
+				"events": []interface{}{uploadEvent("Event#123"), uploadEvent("Receiver#0f8e6b4a-1c2d-4e3f-8a9b-7c6d5e4f3a2b")},
+			},
+			expectedStatusCode: http.StatusMultiStatus,
+			expectedResults: []SyncUploadResult{
+				{EventID: "Event#123", Status: batchItemFailed, Error: syncInvalidIDError},
+				{EventID: "Receiver#0f8e6b4a-1c2d-4e3f-8a9b-7c6d5e4f3a2b", Status: batchItemFailed, Error: syncInvalidIDError},
+			},
+		},
+		"Sad Path - Bad Request Body": {
+			userID:             "User#Syncer",
+			receiverID:         "Receiver#Sync",
+			requestBody:        map[string]interface{}{"events": []interface{}{}},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"Sad Path - User Is Not A Care Giver": {
+			userID:     "User#NotACareGiver",
+			receiverID: "Receiver#Sync",
+			requestBody: map[string]interface{}{
+				"events": []interface{}{uploadEvent(newID)},
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Sad Path - Error Getting Events": {
+			userID:     "User#123",
+			receiverID: "Receiver#Error",
+			requestBody: map[string]interface{}{
+				"events": []interface{}{uploadEvent(newID)},
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			requestBody, err := json.Marshal(tc.requestBody)
+			assert.Nil(t, err)
+
+			params := HandlerParams{
+				AppCfg: appconfig.NewAppConfig(),
+				Request: events.APIGatewayProxyRequest{
+					HTTPMethod:            http.MethodPost,
+					PathParameters:        map[string]string{"receiverId": tc.receiverID},
+					QueryStringParameters: map[string]string{"userId": tc.userID},
+					Body:                  string(requestBody),
+				},
+				UserRepo:         testUserRepo,
+				EventRepo:        testEventRepo,
+				RelationshipRepo: testRelationshipRepo,
+				TombstoneRepo:    testTombstoneRepo,
+				AuditRepo:        testAuditRepo,
+				EventBatchRepo:   testEventBatchRepo,
+				ChangeLogRepo:    testChangeLogRepo,
+			}
+			resp, err := HandleUploadReceiverEvents(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedResults == nil {
+				return
+			}
+
+			var responseBody SyncUploadResponse
+			err = json.Unmarshal([]byte(resp.Body), &responseBody)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResults, responseBody.Results)
+		})
+	}
+}
+
+func TestIsClientEventID(t *testing.T) {
+	assert.True(t, isClientEventID("Event#0f8e6b4a-1c2d-4e3f-8a9b-7c6d5e4f3a2b"))
+	assert.False(t, isClientEventID("Event#123"))
+	assert.False(t, isClientEventID("0f8e6b4a-1c2d-4e3f-8a9b-7c6d5e4f3a2b"))
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	awsevents "github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/summary"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/relationship"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/repository"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
+	"go.uber.org/zap"
+)
+
+const (
+	getReceiverTrend = "get receiver trend"
+
+	typeParam        = "type"
+	dataPointParam   = "dataPoint"
+	bucketParam      = "bucket"
+	aggregationParam = "aggregation"
+
+	// maxTrendBuckets keeps a single series to roughly a month of hourly points.
+	maxTrendBuckets = 750
+)
+
+type TrendResponse struct {
+	ReceiverID  string              `json:"receiverId"`
+	Type        string              `json:"type"`
+	DataPoint   string              `json:"dataPoint"`
+	Bucket      summary.Bucket      `json:"bucket"`
+	Aggregation summary.Aggregation `json:"aggregation"`
+	Timezone    string              `json:"timezone"`
+	Points      []summary.Point     `json:"points"`
+	Status      string              `json:"status"`
+}
+
+// HandleGetReceiverTrend returns a bucketed series of one numeric data point, for example the
+// daily average of "weight" on Weight events. Buckets follow the requesting user's timezone.
+func HandleGetReceiverTrend(ctx context.Context, params HandlerParams) (awsevents.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, getReceiverTrend)
+
+	rid, err := validatePathParameters(params.Request, receiver.ParamID, receiver.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, receiver.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	uid, err := validateQueryParameters(params.Request, user.ParamID)
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.QueryParametersLogKey, params.Request.QueryStringParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	query := params.Request.QueryStringParameters
+	eventType := query[typeParam]
+	dataPoint := query[dataPointParam]
+	if eventType == "" || dataPoint == "" {
+		params.AppCfg.Logger.Error("type and dataPoint query params are required", zap.Any(log.QueryParametersLogKey, query))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	bucket := summary.BucketDaily
+	if value := query[bucketParam]; value != "" {
+		bucket, err = summary.ParseBucket(value)
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, bucketParam), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	agg := summary.AggregationAvg
+	if value := query[aggregationParam]; value != "" {
+		agg, err = summary.ParseAggregation(value)
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error(queryParamsError, zap.String(log.ParamIDLogKey, aggregationParam), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	startTime := query["startTime"]
+	endTime := query["endTime"]
+	if err := validateTimestamps(startTime, endTime); err != nil {
+		params.AppCfg.Logger.Error("invalid date bound query params", zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+	from, _ := time.Parse(time.RFC3339, startTime)
+	to, _ := time.Parse(time.RFC3339, endTime)
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	if !relationship.IsACareGiver(u.UserID, rid, relationships) {
+		params.AppCfg.Logger.Error(userNotCareGiverError, zap.String(log.ReceiverIDLogKey, rid), zap.String(log.UserIDLogKey, u.UserID))
+		return response.CreateAccessDeniedResponse(), nil
+	}
+
+	loc := time.UTC
+	p, err := params.ProfileRepo.GetProfile(u.UserID)
+	if err != nil {
+		params.AppCfg.Logger.Warn("unable to load profile timezone, bucketing in UTC", zap.String(log.UserIDLogKey, u.UserID), zap.Error(err))
+	} else {
+		loc = p.Location()
+	}
+
+	if summary.BucketCount(bucket, from, to, loc, maxTrendBuckets) > maxTrendBuckets {
+		params.AppCfg.Logger.Error("trend range has too many buckets", zap.String("startTime", startTime), zap.String("endTime", endTime), zap.String(bucketParam, string(bucket)))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	eventsList, err := params.EventRepo.GetEvents(rid, repository.TimestampBound{
+		Lower: startTime,
+		Upper: endTime,
+	})
+	if err != nil {
+		params.AppCfg.Logger.Error(eventDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getReceiverTrend)
+	return response.FormatResponse(TrendResponse{
+		ReceiverID:  rid,
+		Type:        eventType,
+		DataPoint:   dataPoint,
+		Bucket:      bucket,
+		Aggregation: agg,
+		Timezone:    loc.String(),
+		Points:      summary.Series(eventsList, eventType, dataPoint, bucket, agg, from, to, loc),
+		Status:      response.Success,
+	}, http.StatusOK), nil
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/summary"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleGetReceiverTrend(t *testing.T) {
+	value := func(v float64) *float64 { return &v }
+
+	tests := map[string]struct {
+		request          events.APIGatewayProxyRequest
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - Daily Average": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				PathParameters: map[string]string{"receiverId": "Receiver#Vitals"},
+				QueryStringParameters: map[string]string{
+					"userId":    "User#Syncer",
+					"type":      "Weight",
+					"dataPoint": "weight",
+					"startTime": "2023-10-01T00:00:00Z",
+					"endTime":   "2023-10-03T00:00:00Z",
+				},
+			},
+			expectedResponse: response.FormatResponse(TrendResponse{
+				ReceiverID:  "Receiver#Vitals",
+				Type:        "Weight",
+				DataPoint:   "weight",
+				Bucket:      summary.BucketDaily,
+				Aggregation: summary.AggregationAvg,
+				Timezone:    "UTC",
+				Points: []summary.Point{
+					{StartTime: "2023-10-01T00:00:00Z", EndTime: "2023-10-02T00:00:00Z", Value: value(150.5), Count: 2},
+					{StartTime: "2023-10-02T00:00:00Z", EndTime: "2023-10-03T00:00:00Z", Count: 0},
+				},
+				Status: response.Success,
+			}, http.StatusOK),
+		},
+		"Happy Path - Last Reading Per Week": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				PathParameters: map[string]string{"receiverId": "Receiver#Vitals"},
+				QueryStringParameters: map[string]string{
+					"userId":      "User#Syncer",
+					"type":        "Weight",
+					"dataPoint":   "weight",
+					"bucket":      "weekly",
+					"aggregation": "last",
+					"startTime":   "2023-10-01T00:00:00Z",
+					"endTime":     "2023-10-02T00:00:00Z",
+				},
+			},
+			expectedResponse: response.FormatResponse(TrendResponse{
+				ReceiverID:  "Receiver#Vitals",
+				Type:        "Weight",
+				DataPoint:   "weight",
+				Bucket:      summary.BucketWeekly,
+				Aggregation: summary.AggregationLast,
+				Timezone:    "UTC",
+				Points: []summary.Point{
+					{StartTime: "2023-09-25T00:00:00Z", EndTime: "2023-10-02T00:00:00Z", Value: value(151), Count: 2},
+				},
+				Status: response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - Missing Data Point": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				PathParameters: map[string]string{"receiverId": "Receiver#Vitals"},
+				QueryStringParameters: map[string]string{
+					"userId":    "User#Syncer",
+					"type":      "Weight",
+					"startTime": "2023-10-01T00:00:00Z",
+					"endTime":   "2023-10-03T00:00:00Z",
+				},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Unsupported Aggregation": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				PathParameters: map[string]string{"receiverId": "Receiver#Vitals"},
+				QueryStringParameters: map[string]string{
+					"userId":      "User#Syncer",
+					"type":        "Weight",
+					"dataPoint":   "weight",
+					"aggregation": "median",
+					"startTime":   "2023-10-01T00:00:00Z",
+					"endTime":     "2023-10-03T00:00:00Z",
+				},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Too Many Buckets": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				PathParameters: map[string]string{"receiverId": "Receiver#Vitals"},
+				QueryStringParameters: map[string]string{
+					"userId":    "User#Syncer",
+					"type":      "Weight",
+					"dataPoint": "weight",
+					"bucket":    "hourly",
+					"startTime": "2023-01-01T00:00:00Z",
+					"endTime":   "2023-12-31T00:00:00Z",
+				},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Error Getting Events": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				PathParameters: map[string]string{"receiverId": "Receiver#Error"},
+				QueryStringParameters: map[string]string{
+					"userId":    "User#123",
+					"type":      "Weight",
+					"dataPoint": "weight",
+					"startTime": "2023-10-01T00:00:00Z",
+					"endTime":   "2023-10-03T00:00:00Z",
+				},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg:           appconfig.NewAppConfig(),
+				Request:          tc.request,
+				UserRepo:         testUserRepo,
+				EventRepo:        testEventRepo,
+				RelationshipRepo: testRelationshipRepo,
+				ProfileRepo:      testProfileRepo,
+			}
+			resp, err := HandleGetReceiverTrend(context.Background(), params)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
@@ -2,9 +2,14 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/emailclaim"
+	"github.com/care-giver-app/care-giver-api/internal/profile"
 	"github.com/care-giver-app/care-giver-api/internal/response"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/receiver"
@@ -16,25 +21,29 @@ import (
 const (
 	createUser            = "create user"
 	getUser               = "get user"
+	updateUser            = "update user"
+	deleteUser            = "delete user"
 	addPrimaryReceiver    = "add primary receiver"
 	addAdditionalReceiver = "add additional receiver"
+
+	newPrimaryUserIDParam = "newPrimaryUserId"
 )
 
 type CreateUserRequest struct {
-	Email     string `json:"email" validate:"required"`
-	FirstName string `json:"firstName" validate:"required"`
-	LastName  string `json:"lastName" validate:"required"`
+	Email     string `json:"email" validate:"required,max=254,emailaddress"`
+	FirstName string `json:"firstName" validate:"required,max=100,personname"`
+	LastName  string `json:"lastName" validate:"required,max=100,personname"`
 }
 
 type CreateUserResponse struct {
-	UserID string `json:"userId"`
+	UserID string `json:"userId,omitempty"`
 	Status string `json:"status"`
 }
 
 type PrimaryReceiverRequest struct {
 	UserID    string `json:"userId" validate:"required"`
-	FirstName string `json:"firstName" validate:"required"`
-	LastName  string `json:"lastName" validate:"required"`
+	FirstName string `json:"firstName" validate:"required,max=100,personname"`
+	LastName  string `json:"lastName" validate:"required,max=100,personname"`
 }
 
 type PrimaryReceiverResponse struct {
@@ -45,7 +54,28 @@ type PrimaryReceiverResponse struct {
 type AdditionalReceiverRequest struct {
 	UserID     string `json:"userId" validate:"required"`
 	ReceiverID string `json:"receiverId" validate:"required"`
-	Email      string `json:"email" validate:"required"`
+	Email      string `json:"email" validate:"required,max=254,emailaddress"`
+}
+
+type UserResponse struct {
+	user.User
+	Phone                   string                           `json:"phone,omitempty"`
+	Timezone                string                           `json:"timezone,omitempty"`
+	NotificationPreferences *profile.NotificationPreferences `json:"notificationPreferences,omitempty"`
+}
+
+type UpdateUserRequest struct {
+	FirstName               string                          `json:"firstName" validate:"required,max=100,personname"`
+	LastName                string                          `json:"lastName" validate:"required,max=100,personname"`
+	Phone                   string                          `json:"phone" validate:"omitempty,max=32,freetext"`
+	Timezone                string                          `json:"timezone"`
+	NotificationPreferences profile.NotificationPreferences `json:"notificationPreferences"`
+}
+
+type DeleteUserConflictResponse struct {
+	ReceiverIDs              []string `json:"receiverIds"`
+	SoleCareGiverReceiverIDs []string `json:"soleCareGiverReceiverIds,omitempty"`
+	Status                   string   `json:"status"`
 }
 
 type GetUserRelationshipsResponse struct {
@@ -60,22 +90,47 @@ func HandleCreateUser(ctx context.Context, params HandlerParams) (events.APIGate
 	err := readRequestBody(params.Request.Body, &createUserRequest)
 	if err != nil {
 		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
-		return response.CreateBadRequestResponse(), nil
+		return createRequestBodyErrorResponse(err), nil
 	}
 
-	user, err := user.NewUser(createUserRequest.Email, createUserRequest.FirstName, createUserRequest.LastName)
+	// The email claim table is the only record of which addresses are taken. Accounts that
+	// predate it are claimed by the backfill-email-claims task.
+	email := normalizeEmail(createUserRequest.Email)
+
+	user, err := user.NewUser(email, createUserRequest.FirstName, createUserRequest.LastName)
 	if err != nil {
 		params.AppCfg.Logger.Error("error creating new user", zap.Error(err))
 		return response.CreateInternalServerErrorResponse(), nil
 	}
 	params.AppCfg.Logger = params.AppCfg.Logger.With(zap.Any(log.UserIDLogKey, user.UserID))
 
+	err = params.EmailClaimRepo.ClaimEmail(email, user.UserID)
+	if errors.Is(err, emailclaim.ErrEmailTaken) {
+		claim, err := params.EmailClaimRepo.GetClaim(email)
+		if err != nil {
+			params.AppCfg.Logger.Error("error retrieving email claim from db", zap.Error(err))
+			return response.CreateInternalServerErrorResponse(), nil
+		}
+
+		params.AppCfg.Logger.Warn("email already claimed", zap.String("existingUserId", claim.UserID))
+		return duplicateUserResponse(params.Request, email, claim.UserID), nil
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error("error claiming email in db", zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
 	err = params.UserRepo.CreateUser(*user)
 	if err != nil {
 		params.AppCfg.Logger.Error("error creating new user in db", zap.Error(err))
+		if err := params.EmailClaimRepo.ReleaseEmail(email, user.UserID); err != nil {
+			params.AppCfg.Logger.Error("error releasing email claim for user that was not created", zap.Error(err))
+		}
 		return response.CreateInternalServerErrorResponse(), nil
 	}
 
+	recordAudit(params, user.UserID, user.UserID, audit.ActionCreateUser, audit.WithAfter(user))
+
 	resp := CreateUserResponse{
 		UserID: user.UserID,
 		Status: response.Success,
@@ -85,6 +140,19 @@ func HandleCreateUser(ctx context.Context, params HandlerParams) (events.APIGate
 	return response.FormatResponse(resp, http.StatusOK), nil
 }
 
+// duplicateUserResponse only reveals the existing user's ID to a caller who is
+// authenticated as the owner of that email, since user IDs grant access elsewhere.
+func duplicateUserResponse(request events.APIGatewayProxyRequest, email, existingUID string) events.APIGatewayProxyResponse {
+	resp := CreateUserResponse{
+		Status: "Conflict",
+	}
+
+	if normalizeEmail(authorizerClaim(request, "email")) == email {
+		resp.UserID = existingUID
+	}
+	return response.FormatResponse(resp, http.StatusConflict)
+}
+
 func HandleGetUser(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
 	params.AppCfg.Logger.Sugar().Infof(handlerStart, getUser)
 
@@ -100,8 +168,318 @@ func HandleGetUser(ctx context.Context, params HandlerParams) (events.APIGateway
 		return response.CreateInternalServerErrorResponse(), nil
 	}
 
+	p, err := params.ProfileRepo.GetProfile(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(profileDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	resp := UserResponse{
+		User:     u,
+		Phone:    p.Phone,
+		Timezone: p.Timezone,
+	}
+	if p.NotificationPreferences != (profile.NotificationPreferences{}) {
+		resp.NotificationPreferences = &p.NotificationPreferences
+	}
+
 	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, getUser)
-	return response.FormatResponse(u, http.StatusOK), nil
+	return response.FormatResponse(resp, http.StatusOK), nil
+}
+
+func HandleUpdateUser(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, updateUser)
+
+	uid, err := validatePathParameters(params.Request, user.ParamID, user.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	if resp, ok := authorizeSelf(params, uid); !ok {
+		return resp, nil
+	}
+
+	var updateUserRequest UpdateUserRequest
+	err = readRequestBody(params.Request.Body, &updateUserRequest)
+	if err != nil {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		return createRequestBodyErrorResponse(err), nil
+	}
+
+	updated := profile.Profile{
+		FirstName:               updateUserRequest.FirstName,
+		LastName:                updateUserRequest.LastName,
+		Phone:                   updateUserRequest.Phone,
+		Timezone:                updateUserRequest.Timezone,
+		NotificationPreferences: updateUserRequest.NotificationPreferences,
+	}
+	err = updated.Validate()
+	if err != nil {
+		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+
+	current, err := params.ProfileRepo.GetProfile(uid)
+	if errors.Is(err, profile.ErrUserNotFound) {
+		params.AppCfg.Logger.Error("user not found", zap.String(log.UserIDLogKey, uid))
+		return response.CreateResourceNotFoundResponse(), nil
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error(profileDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	err = params.ProfileRepo.UpdateProfile(uid, updated)
+	if err != nil {
+		params.AppCfg.Logger.Error("error updating user profile in db", zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	recordAudit(params, uid, uid, audit.ActionUpdateUser, audit.WithBefore(current), audit.WithAfter(updated))
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, updateUser)
+	return response.FormatResponse(map[string]string{
+		"status": response.Success,
+	}, http.StatusOK), nil
+}
+
+// HandleDeleteUser removes a user's relationships and anonymizes their record. A user
+// who is the sole primary care giver of a receiver that still has other care givers
+// must hand that role to one of them via newPrimaryUserId, otherwise the request is
+// rejected with the receivers that block it. A user who is the only care giver of a
+// receiver is rejected as well, since nobody could reach that receiver's history
+// afterwards; they have to invite someone to take it over first.
+func HandleDeleteUser(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+	params.AppCfg.Logger.Sugar().Infof(handlerStart, deleteUser)
+
+	uid, err := validatePathParameters(params.Request, user.ParamID, user.DBPrefix)
+	if err != nil {
+		params.AppCfg.Logger.Error(pathParametersError, zap.String(log.ParamIDLogKey, user.ParamID), zap.Any(log.PathParametersLogKey, params.Request.PathParameters), zap.Error(err))
+		return response.CreateBadRequestResponse(), nil
+	}
+	newPrimaryUID := params.Request.QueryStringParameters[newPrimaryUserIDParam]
+
+	if resp, ok := authorizeSelf(params, uid); !ok {
+		return resp, nil
+	}
+
+	u, err := params.UserRepo.GetUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error(relationshipDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	promotions := []relationship.Relationship{}
+	blocked := []string{}
+	soleCareGiver := []string{}
+	for _, rel := range relationships {
+		receiverRelationships, err := params.RelationshipRepo.GetRelationshipsByReceiver(rel.ReceiverID)
+		if err != nil {
+			params.AppCfg.Logger.Error(relationshipDatabaseError, zap.String(log.ReceiverIDLogKey, rel.ReceiverID), zap.Error(err))
+			return response.CreateInternalServerErrorResponse(), nil
+		}
+
+		if !hasOtherCareGivers(uid, receiverRelationships) {
+			soleCareGiver = append(soleCareGiver, rel.ReceiverID)
+			continue
+		}
+		if !rel.PrimaryCareGiver {
+			continue
+		}
+
+		promotion, ok := primaryHandOff(uid, newPrimaryUID, receiverRelationships)
+		if !ok {
+			blocked = append(blocked, rel.ReceiverID)
+			continue
+		}
+		if promotion != nil {
+			promotions = append(promotions, *promotion)
+		}
+	}
+
+	if len(blocked) > 0 || len(soleCareGiver) > 0 {
+		params.AppCfg.Logger.Error("user cannot leave receivers without a care giver", zap.String(log.UserIDLogKey, uid), zap.Strings("receiverIds", blocked), zap.Strings("soleCareGiverReceiverIds", soleCareGiver))
+		resp := DeleteUserConflictResponse{
+			ReceiverIDs: blocked,
+			Status:      "Conflict",
+		}
+		if len(soleCareGiver) > 0 {
+			resp.SoleCareGiverReceiverIDs = soleCareGiver
+		}
+		return response.FormatResponse(resp, http.StatusConflict), nil
+	}
+
+	for _, promotion := range promotions {
+		promotion.PrimaryCareGiver = true
+		err = params.RelationshipRepo.AddRelationship(&promotion)
+		if err != nil {
+			params.AppCfg.Logger.Error("error promoting new primary care giver in db", zap.String(log.ReceiverIDLogKey, promotion.ReceiverID), zap.Error(err))
+			return response.CreateInternalServerErrorResponse(), nil
+		}
+
+		recordAudit(params, promotion.ReceiverID, uid, audit.ActionAddCareGiver,
+			audit.WithTarget(user.ParamID, promotion.UserID),
+			audit.WithAfter(promotion),
+		)
+	}
+
+	for _, rel := range relationships {
+		err = params.RelationshipRepo.DeleteRelationship(uid, rel.ReceiverID)
+		if err != nil {
+			params.AppCfg.Logger.Error("error deleting relationship from db", zap.String(log.ReceiverIDLogKey, rel.ReceiverID), zap.Error(err))
+			return response.CreateInternalServerErrorResponse(), nil
+		}
+	}
+
+	err = params.ProfileRepo.AnonymizeUser(uid)
+	if err != nil {
+		params.AppCfg.Logger.Error("error anonymizing user in db", zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), nil
+	}
+
+	err = params.EmailClaimRepo.ReleaseEmail(normalizeEmail(u.Email), uid)
+	if err != nil && !errors.Is(err, emailclaim.ErrClaimNotFound) {
+		params.AppCfg.Logger.Error("error releasing email claim", zap.String(log.UserIDLogKey, uid), zap.Error(err))
+	}
+
+	recordAudit(params, uid, uid, audit.ActionDeleteUser, audit.WithBefore(u))
+
+	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, deleteUser)
+	return response.FormatResponse(map[string]string{
+		"status": response.Success,
+	}, http.StatusOK), nil
+}
+
+// authorizeSelf checks that the authenticated caller is the user the request acts on.
+// When it returns false, the response is ready to send back as is.
+func authorizeSelf(params HandlerParams, uid string) (events.APIGatewayProxyResponse, bool) {
+	callerUID, err := callerUserID(params)
+	if errors.Is(err, errNoCaller) {
+		params.AppCfg.Logger.Error(userNotCallerError, zap.String(log.UserIDLogKey, uid))
+		return response.CreateAccessDeniedResponse(), false
+	}
+	if err != nil {
+		params.AppCfg.Logger.Error(userDatabaseError, zap.Error(err))
+		return response.CreateInternalServerErrorResponse(), false
+	}
+
+	if callerUID != uid {
+		params.AppCfg.Logger.Error(userNotCallerError, zap.String(log.UserIDLogKey, uid), zap.String("callerUserId", callerUID))
+		return response.CreateAccessDeniedResponse(), false
+	}
+	return events.APIGatewayProxyResponse{}, true
+}
+
+var errNoCaller = errors.New("request has no authenticated email claim")
+
+// callerUserID resolves the authenticated caller to a user.
+func callerUserID(params HandlerParams) (string, error) {
+	email := authorizerClaim(params.Request, "email")
+	if normalizeEmail(email) == "" {
+		return "", errNoCaller
+	}
+	return lookupUserID(params, email)
+}
+
+// lookupUserID resolves an email address to a user through the email claim table. Accounts
+// that have not been claimed by the backfill yet are looked up in the user table with the
+// address as given, since they were stored before emails were normalized.
+func lookupUserID(params HandlerParams, email string) (string, error) {
+	claim, err := params.EmailClaimRepo.GetClaim(normalizeEmail(email))
+	if err == nil {
+		return claim.UserID, nil
+	}
+	if !errors.Is(err, emailclaim.ErrClaimNotFound) {
+		return "", err
+	}
+
+	u, err := params.UserRepo.GetUserByEmail(strings.TrimSpace(email))
+	if err != nil {
+		return "", err
+	}
+	return u.UserID, nil
+}
+
+// BackfillEmailClaims claims the normalized email of every existing user, so accounts created
+// before the email claim table are protected from duplicates too. Running it again only
+// skips addresses that are already claimed.
+func BackfillEmailClaims(ctx context.Context, params HandlerParams) error {
+	userEmails, err := params.ProfileRepo.ListUserEmails()
+	if err != nil {
+		params.AppCfg.Logger.Error("error listing user emails from db", zap.Error(err))
+		return err
+	}
+
+	claimed := 0
+	for _, ue := range userEmails {
+		email := normalizeEmail(ue.Email)
+		if email == "" {
+			continue
+		}
+
+		err = params.EmailClaimRepo.ClaimEmail(email, ue.UserID)
+		if errors.Is(err, emailclaim.ErrEmailTaken) {
+			claim, err := params.EmailClaimRepo.GetClaim(email)
+			if err != nil {
+				params.AppCfg.Logger.Error("error retrieving email claim from db", zap.String(log.UserIDLogKey, ue.UserID), zap.Error(err))
+				return err
+			}
+			if claim.UserID != ue.UserID {
+				params.AppCfg.Logger.Warn("email belongs to more than one user", zap.String(log.UserIDLogKey, ue.UserID), zap.String("existingUserId", claim.UserID))
+			}
+			continue
+		}
+		if err != nil {
+			params.AppCfg.Logger.Error("error claiming email in db", zap.String(log.UserIDLogKey, ue.UserID), zap.Error(err))
+			return err
+		}
+		claimed++
+	}
+
+	params.AppCfg.Logger.Info("backfilled email claims", zap.Int("users", len(userEmails)), zap.Int("claimed", claimed))
+	return nil
+}
+
+// hasOtherCareGivers reports whether anyone besides uid cares for the receiver.
+func hasOtherCareGivers(uid string, receiverRelationships []relationship.Relationship) bool {
+	for _, rel := range receiverRelationships {
+		if rel.UserID != uid {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryHandOff decides what happens to a receiver whose primary care giver is leaving.
+// It returns the relationship to promote, if any, and false when the departure has to
+// be blocked.
+func primaryHandOff(uid, newPrimaryUID string, receiverRelationships []relationship.Relationship) (*relationship.Relationship, bool) {
+	var candidate *relationship.Relationship
+	others := 0
+	for i, rel := range receiverRelationships {
+		if rel.UserID == uid {
+			continue
+		}
+		if rel.PrimaryCareGiver {
+			return nil, true
+		}
+		if rel.UserID == newPrimaryUID {
+			candidate = &receiverRelationships[i]
+		}
+		others++
+	}
+
+	if others == 0 {
+		return nil, true
+	}
+	return candidate, candidate != nil
 }
 
 func HandleUserPrimaryReceiver(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
@@ -111,7 +489,7 @@ func HandleUserPrimaryReceiver(ctx context.Context, params HandlerParams) (event
 	err := readRequestBody(params.Request.Body, &primaryReceiverRequest)
 	if err != nil {
 		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
-		return response.CreateBadRequestResponse(), nil
+		return createRequestBodyErrorResponse(err), nil
 	}
 
 	receiver := receiver.NewReceiver(primaryReceiverRequest.FirstName, primaryReceiverRequest.LastName)
@@ -131,6 +509,13 @@ func HandleUserPrimaryReceiver(ctx context.Context, params HandlerParams) (event
 		return response.CreateInternalServerErrorResponse(), nil
 	}
 
+	recordAudit(params, receiver.ReceiverID, primaryReceiverRequest.UserID, audit.ActionAddPrimaryReceiver,
+		audit.WithAfter(map[string]any{
+			"receiver":     receiver,
+			"relationship": newRelationship,
+		}),
+	)
+
 	resp := PrimaryReceiverResponse{
 		ReceiverID: string(receiver.ReceiverID),
 		Status:     response.Success,
@@ -147,7 +532,7 @@ func HandleUserAdditionalReceiver(ctx context.Context, params HandlerParams) (ev
 	err := readRequestBody(params.Request.Body, &additionalReceiverRequest)
 	if err != nil {
 		params.AppCfg.Logger.Error(requestBodyError, zap.Error(err))
-		return response.CreateBadRequestResponse(), nil
+		return createRequestBodyErrorResponse(err), nil
 	}
 
 	relationships, err := params.RelationshipRepo.GetRelationshipsByUser(additionalReceiverRequest.UserID)
@@ -161,19 +546,24 @@ func HandleUserAdditionalReceiver(ctx context.Context, params HandlerParams) (ev
 		return response.CreateAccessDeniedResponse(), nil
 	}
 
-	additionalUser, err := params.UserRepo.GetUserByEmail(additionalReceiverRequest.Email)
+	additionalUID, err := lookupUserID(params, additionalReceiverRequest.Email)
 	if err != nil {
 		params.AppCfg.Logger.Error(userDatabaseError, zap.Error(err))
 		return response.CreateInternalServerErrorResponse(), nil
 	}
 
-	newRelationship := relationship.NewRelationship(additionalUser.UserID, additionalReceiverRequest.ReceiverID, false, false)
+	newRelationship := relationship.NewRelationship(additionalUID, additionalReceiverRequest.ReceiverID, false, false)
 	err = params.RelationshipRepo.AddRelationship(newRelationship)
 	if err != nil {
 		params.AppCfg.Logger.Error("error creating relationship in db", zap.Error(err))
 		return response.CreateInternalServerErrorResponse(), nil
 	}
 
+	recordAudit(params, additionalReceiverRequest.ReceiverID, additionalReceiverRequest.UserID, audit.ActionAddCareGiver,
+		audit.WithTarget(user.ParamID, additionalUID),
+		audit.WithAfter(newRelationship),
+	)
+
 	params.AppCfg.Logger.Sugar().Infof(handlerSuccessful, addAdditionalReceiver)
 	return response.FormatResponse(map[string]string{
 		"status": response.Success,
@@ -3,11 +3,13 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/profile"
 	"github.com/care-giver-app/care-giver-api/internal/response"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/relationship"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/user"
@@ -27,6 +29,55 @@ func TestHandleCreateUser(t *testing.T) {
 			},
 			isSuccess: true,
 		},
+		"Happy Path - User Added With Normalized Email": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Body:       "{\"email\": \"  Good@Test.COM \", \"firstName\":\"Demo\", \"lastName\":\"Daniel\"}",
+			},
+			isSuccess: true,
+		},
+		"Sad Path - User Already Exists": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Body:       "{\"email\": \"Valid@Example.com\", \"firstName\":\"Demo\", \"lastName\":\"Daniel\"}",
+			},
+			expectedResponse: response.FormatResponse(CreateUserResponse{
+				Status: "Conflict",
+			}, http.StatusConflict),
+		},
+		"Sad Path - User Already Exists For Authenticated Owner": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Body:       "{\"email\": \"valid@example.com\", \"firstName\":\"Demo\", \"lastName\":\"Daniel\"}",
+				RequestContext: events.APIGatewayProxyRequestContext{
+					Authorizer: map[string]interface{}{
+						"claims": map[string]interface{}{
+							"email": "VALID@example.com",
+						},
+					},
+				},
+			},
+			expectedResponse: response.FormatResponse(CreateUserResponse{
+				UserID: "User#123",
+				Status: "Conflict",
+			}, http.StatusConflict),
+		},
+		"Sad Path - Email Already Claimed": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Body:       "{\"email\": \"taken@test.com\", \"firstName\":\"Demo\", \"lastName\":\"Daniel\"}",
+			},
+			expectedResponse: response.FormatResponse(CreateUserResponse{
+				Status: "Conflict",
+			}, http.StatusConflict),
+		},
+		"Sad Path - Error Claiming Email": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Body:       "{\"email\": \"claimerror@test.com\", \"firstName\":\"Demo\", \"lastName\":\"Daniel\"}",
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
 		"Sad Path - Wrong Method": {
 			request: events.APIGatewayProxyRequest{
 				HTTPMethod: "BadMethod",
@@ -40,6 +91,25 @@ func TestHandleCreateUser(t *testing.T) {
 			},
 			expectedResponse: response.CreateBadRequestResponse(),
 		},
+		"Sad Path - Invalid Email": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Body:       "{\"email\":\"not-an-email\", \"firstName\":\"John\", \"lastName\":\"Doe\"}",
+			},
+			expectedResponse: response.CreateValidationErrorResponse([]response.FieldError{
+				{Field: "email", Message: "must be a valid email address"},
+			}),
+		},
+		"Sad Path - Invalid Name": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Body:       "{\"email\":\"good@test.com\", \"firstName\":\"J0hn\", \"lastName\":\"<Doe>\"}",
+			},
+			expectedResponse: response.CreateValidationErrorResponse([]response.FieldError{
+				{Field: "firstName", Message: "may only contain letters, spaces, hyphens, apostrophes and periods"},
+				{Field: "lastName", Message: "may only contain letters, spaces, hyphens, apostrophes and periods"},
+			}),
+		},
 		"Sad Path - Error Add User To DB": {
 			request: events.APIGatewayProxyRequest{
 				HTTPMethod: http.MethodPost,
@@ -51,10 +121,12 @@ func TestHandleCreateUser(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			params := HandlerParams{
-				AppCfg:       appconfig.NewAppConfig(),
-				Request:      tc.request,
-				UserRepo:     testUserRepo,
-				ReceiverRepo: testReceiverRepo,
+				AppCfg:         appconfig.NewAppConfig(),
+				Request:        tc.request,
+				UserRepo:       testUserRepo,
+				ReceiverRepo:   testReceiverRepo,
+				AuditRepo:      testAuditRepo,
+				EmailClaimRepo: testEmailClaimRepo,
 			}
 			resp, err := HandleCreateUser(context.Background(), params)
 
@@ -96,12 +168,39 @@ func TestHandleGetUser(t *testing.T) {
 				LastName:  "Doe",
 			}, http.StatusOK),
 		},
+		"Happy Path - User Retrieved With Profile": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"userId": "User#WithProfile",
+				},
+			},
+			expectedResponse: response.FormatResponse(UserResponse{
+				User: user.User{
+					UserID: "User#WithProfile",
+				},
+				Phone:    "555-0100",
+				Timezone: "America/Chicago",
+				NotificationPreferences: &profile.NotificationPreferences{
+					Email: true,
+				},
+			}, http.StatusOK),
+		},
 		"Sad Path - Wrong Method": {
 			request: events.APIGatewayProxyRequest{
 				HTTPMethod: "BadMethod",
 			},
 			expectedResponse: response.CreateBadRequestResponse(),
 		},
+		"Sad Path - Error Getting Profile From DB": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				PathParameters: map[string]string{
+					"userId": "User#456",
+				},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
 		"Sad Path - Bad Path Parameters": {
 			request: events.APIGatewayProxyRequest{
 				HTTPMethod: http.MethodGet,
@@ -128,6 +227,7 @@ func TestHandleGetUser(t *testing.T) {
 				Request:      tc.request,
 				UserRepo:     testUserRepo,
 				ReceiverRepo: testReceiverRepo,
+				ProfileRepo:  testProfileRepo,
 			}
 			resp, err := HandleGetUser(context.Background(), params)
 
@@ -186,6 +286,7 @@ func TestHandleUserPrimaryReceiver(t *testing.T) {
 				UserRepo:         testUserRepo,
 				ReceiverRepo:     testReceiverRepo,
 				RelationshipRepo: testRelationshipRepo,
+				AuditRepo:        testAuditRepo,
 			}
 			resp, err := HandleUserPrimaryReceiver(context.Background(), params)
 
@@ -251,6 +352,22 @@ func TestHandleUserAdditionalReceiver(t *testing.T) {
 			},
 			expectedResponse: response.CreateInternalServerErrorResponse(),
 		},
+		"Happy Path - Unclaimed Mixed Case Email": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Body:       "{\"userId\": \"User#123\", \"receiverId\":\"Receiver#123\", \"email\":\" Legacy@Example.com \"}",
+			},
+			expectedResponse: response.FormatResponse(map[string]string{
+				"status": "Success",
+			}, http.StatusOK),
+		},
+		"Sad Path - Error Getting Email Claim": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Body:       "{\"userId\": \"User#123\", \"receiverId\":\"Receiver#123\", \"email\":\"claimerror@example.com\"}",
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
 		"Sad Path - Error Updating Receiver List": {
 			request: events.APIGatewayProxyRequest{
 				HTTPMethod: http.MethodPost,
@@ -267,6 +384,8 @@ func TestHandleUserAdditionalReceiver(t *testing.T) {
 				UserRepo:         testUserRepo,
 				ReceiverRepo:     testReceiverRepo,
 				RelationshipRepo: testRelationshipRepo,
+				AuditRepo:        testAuditRepo,
+				EmailClaimRepo:   testEmailClaimRepo,
 			}
 			resp, err := HandleUserAdditionalReceiver(context.Background(), params)
 
@@ -351,3 +470,323 @@ func TestHandlerGetUserRelationships(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleUpdateUser(t *testing.T) {
+	tests := map[string]struct {
+		request          events.APIGatewayProxyRequest
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - User Updated": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodPut,
+				RequestContext: claimsRequest("valid@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#123",
+				},
+				Body: "{\"firstName\":\"Johnny\", \"lastName\":\"Doe\", \"phone\":\"555-0100\", \"timezone\":\"America/New_York\", \"notificationPreferences\":{\"email\":true}}",
+			},
+			expectedResponse: response.FormatResponse(map[string]string{
+				"status": response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - Bad Path Parameters": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodPut,
+				RequestContext: claimsRequest("valid@example.com"),
+				PathParameters: map[string]string{
+					"userId": "BadValue",
+				},
+				Body: "{\"firstName\":\"Johnny\", \"lastName\":\"Doe\"}",
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Missing Name": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodPut,
+				RequestContext: claimsRequest("valid@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#123",
+				},
+				Body: "{\"firstName\":\"Johnny\"}",
+			},
+			expectedResponse: response.CreateValidationErrorResponse([]response.FieldError{
+				{Field: "lastName", Message: "is required"},
+			}),
+		},
+		"Sad Path - Unknown Timezone": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodPut,
+				RequestContext: claimsRequest("valid@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#123",
+				},
+				Body: "{\"firstName\":\"Johnny\", \"lastName\":\"Doe\", \"timezone\":\"Mars/Olympus\"}",
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - User Not Found": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodPut,
+				RequestContext: claimsRequest("notfound@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#NotFound",
+				},
+				Body: "{\"firstName\":\"Johnny\", \"lastName\":\"Doe\"}",
+			},
+			expectedResponse: response.CreateResourceNotFoundResponse(),
+		},
+		"Sad Path - Error Getting Profile": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodPut,
+				RequestContext: claimsRequest("usererror@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#Error",
+				},
+				Body: "{\"firstName\":\"Johnny\", \"lastName\":\"Doe\"}",
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+		"Sad Path - Error Updating Profile": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodPut,
+				RequestContext: claimsRequest("updateerror@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#UpdateError",
+				},
+				Body: "{\"firstName\":\"Johnny\", \"lastName\":\"Doe\"}",
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg:         appconfig.NewAppConfig(),
+				Request:        tc.request,
+				UserRepo:       testUserRepo,
+				ProfileRepo:    testProfileRepo,
+				AuditRepo:      testAuditRepo,
+				EmailClaimRepo: testEmailClaimRepo,
+			}
+			resp, err := HandleUpdateUser(context.Background(), params)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
+
+func TestHandleDeleteUser(t *testing.T) {
+	tests := map[string]struct {
+		request          events.APIGatewayProxyRequest
+		expectedResponse events.APIGatewayProxyResponse
+	}{
+		"Happy Path - User Deleted": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodDelete,
+				RequestContext: claimsRequest("deletable@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#Deletable",
+				},
+			},
+			expectedResponse: response.FormatResponse(map[string]string{
+				"status": response.Success,
+			}, http.StatusOK),
+		},
+		"Happy Path - Sole Primary Reassigned": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodDelete,
+				RequestContext: claimsRequest("soleprimary@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#SolePrimary",
+				},
+				QueryStringParameters: map[string]string{
+					"newPrimaryUserId": "User#456",
+				},
+			},
+			expectedResponse: response.FormatResponse(map[string]string{
+				"status": response.Success,
+			}, http.StatusOK),
+		},
+		"Sad Path - Sole Primary Without Reassignment": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodDelete,
+				RequestContext: claimsRequest("soleprimary@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#SolePrimary",
+				},
+			},
+			expectedResponse: response.FormatResponse(DeleteUserConflictResponse{
+				ReceiverIDs: []string{"Receiver#SolePrimary"},
+				Status:      "Conflict",
+			}, http.StatusConflict),
+		},
+		"Sad Path - Reassigned To Non Care Giver": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodDelete,
+				RequestContext: claimsRequest("soleprimary@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#SolePrimary",
+				},
+				QueryStringParameters: map[string]string{
+					"newPrimaryUserId": "User#NotACareGiver",
+				},
+			},
+			expectedResponse: response.FormatResponse(DeleteUserConflictResponse{
+				ReceiverIDs: []string{"Receiver#SolePrimary"},
+				Status:      "Conflict",
+			}, http.StatusConflict),
+		},
+		"Sad Path - Bad Path Parameters": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodDelete,
+				RequestContext: claimsRequest("valid@example.com"),
+				PathParameters: map[string]string{
+					"userId": "BadValue",
+				},
+			},
+			expectedResponse: response.CreateBadRequestResponse(),
+		},
+		"Sad Path - Error Getting User": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodDelete,
+				RequestContext: claimsRequest("usererror@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#Error",
+				},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+		"Sad Path - Error Getting Relationships": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodDelete,
+				RequestContext: claimsRequest("relationshiperror@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#RelationshipError",
+				},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+		"Sad Path - Error Getting Receiver Relationships": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodDelete,
+				RequestContext: claimsRequest("valid@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#123",
+				},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+		"Sad Path - Error Anonymizing User": {
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodDelete,
+				RequestContext: claimsRequest("anonymizeerror@example.com"),
+				PathParameters: map[string]string{
+					"userId": "User#AnonymizeError",
+				},
+			},
+			expectedResponse: response.CreateInternalServerErrorResponse(),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg:           appconfig.NewAppConfig(),
+				Request:          tc.request,
+				UserRepo:         testUserRepo,
+				RelationshipRepo: testRelationshipRepo,
+				ProfileRepo:      testProfileRepo,
+				AuditRepo:        testAuditRepo,
+				EmailClaimRepo:   testEmailClaimRepo,
+			}
+			resp, err := HandleDeleteUser(context.Background(), params)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, resp)
+		})
+	}
+}
+
+func TestBackfillEmailClaims(t *testing.T) {
+	tests := map[string]struct {
+		userEmails  []profile.UserEmail
+		listErr     error
+		expectError bool
+	}{
+		"Happy Path - Emails Claimed": {
+			userEmails: []profile.UserEmail{
+				{UserID: "User#456", Email: "Legacy@Example.com"},
+				{UserID: "User#123", Email: "Valid@Example.com"},
+				{UserID: "User#Duplicate", Email: "valid@example.com"},
+				{UserID: "User#Anonymized"},
+			},
+		},
+		"Sad Path - Error Listing Emails": {
+			listErr:     errors.New("error scanning user table"),
+			expectError: true,
+		},
+		"Sad Path - Error Claiming Email": {
+			userEmails: []profile.UserEmail{
+				{UserID: "User#ClaimError", Email: "ClaimError@Test.com"},
+			},
+			expectError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := HandlerParams{
+				AppCfg:         appconfig.NewAppConfig(),
+				ProfileRepo:    &MockProfileRepo{userEmails: tc.userEmails, listErr: tc.listErr},
+				EmailClaimRepo: testEmailClaimRepo,
+			}
+			err := BackfillEmailClaims(context.Background(), params)
+
+			assert.Equal(t, tc.expectError, err != nil)
+		})
+	}
+}
+
+func TestPrimaryHandOff(t *testing.T) {
+	tests := map[string]struct {
+		newPrimaryUID         string
+		receiverRelationships []relationship.Relationship
+		expectedPromotion     *relationship.Relationship
+		expectedOK            bool
+	}{
+		"Only Care Giver": {
+			receiverRelationships: []relationship.Relationship{
+				{UserID: "User#123", ReceiverID: "Receiver#123", PrimaryCareGiver: true},
+			},
+			expectedOK: true,
+		},
+		"Another Primary Care Giver Exists": {
+			receiverRelationships: []relationship.Relationship{
+				{UserID: "User#123", ReceiverID: "Receiver#123", PrimaryCareGiver: true},
+				{UserID: "User#456", ReceiverID: "Receiver#123", PrimaryCareGiver: true},
+			},
+			expectedOK: true,
+		},
+		"Reassigned To Existing Care Giver": {
+			newPrimaryUID: "User#456",
+			receiverRelationships: []relationship.Relationship{
+				{UserID: "User#123", ReceiverID: "Receiver#123", PrimaryCareGiver: true},
+				{UserID: "User#456", ReceiverID: "Receiver#123"},
+			},
+			expectedPromotion: &relationship.Relationship{UserID: "User#456", ReceiverID: "Receiver#123"},
+			expectedOK:        true,
+		},
+		"Other Care Givers But No Reassignment": {
+			receiverRelationships: []relationship.Relationship{
+				{UserID: "User#123", ReceiverID: "Receiver#123", PrimaryCareGiver: true},
+				{UserID: "User#456", ReceiverID: "Receiver#123"},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			promotion, ok := primaryHandOff("User#123", tc.newPrimaryUID, tc.receiverRelationships)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedPromotion, promotion)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const (
+	APIVersion1 = 1
+	APIVersion2 = 2
+
+	// defaultAPIVersion is served when a request names no version, which is how app builds
+	// released before versioning call the API.
+	defaultAPIVersion = APIVersion1
+	latestAPIVersion  = APIVersion2
+
+	acceptVersionHeader = "Accept-Version"
+)
+
+var versionPrefix = regexp.MustCompile(`^/v([0-9]+)(/.*)$`)
+
+// v2HandlersMap holds only the endpoints whose contract changed in v2. Every other endpoint
+// is served by its v1 handler. An endpoint added here also needs /v1 and /v2 routes in
+// template.yaml, since API Gateway only forwards the prefixed paths it declares.
+var v2HandlersMap = map[Endpoint]HandlerFunc{
+	{"/events/{receiverId}", http.MethodGet}: HandleGetReceiverEventsV2,
+}
+
+func handlerVersions() map[int]map[Endpoint]HandlerFunc {
+	return map[int]map[Endpoint]HandlerFunc{
+		APIVersion1: handlersMap,
+		APIVersion2: v2HandlersMap,
+	}
+}
+
+// resolveEndpoint works out which endpoint and API version a request is for. The
+// Accept-Version header selects the version for any endpoint. Only endpoints whose contract
+// differs between versions also have /v1 and /v2 routes, and on those the path prefix takes
+// precedence over the header.
+func resolveEndpoint(request events.APIGatewayProxyRequest) (Endpoint, int, error) {
+	path := removePathPrefix(request.RequestContext.ResourcePath)
+	endpoint := Endpoint{Path: path, Method: request.HTTPMethod}
+
+	if match := versionPrefix.FindStringSubmatch(path); match != nil {
+		endpoint.Path = match[2]
+		version, err := parseAPIVersion(match[1])
+		return endpoint, version, err
+	}
+
+	if header := requestHeader(request, acceptVersionHeader); header != "" {
+		version, err := parseAPIVersion(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(header)), "v"))
+		return endpoint, version, err
+	}
+	return endpoint, defaultAPIVersion, nil
+}
+
+func parseAPIVersion(value string) (int, error) {
+	version, err := strconv.Atoi(value)
+	if err != nil || version < APIVersion1 || version > latestAPIVersion {
+		return 0, fmt.Errorf("unsupported api version %q", value)
+	}
+	return version, nil
+}
+
+// lookupHandler finds the handler for the endpoint at the requested version, falling back
+// to the newest earlier version that serves it. It also returns the version it found.
+func lookupHandler(endpoint Endpoint, version int) (HandlerFunc, int, bool) {
+	versions := handlerVersions()
+	for v := version; v >= APIVersion1; v-- {
+		if handler, ok := versions[v][endpoint]; ok {
+			return handler, v, true
+		}
+	}
+	return nil, 0, false
+}
+
+// successorVersion is the newest version that replaced the handler served at version, or
+// zero if it is still current.
+func successorVersion(endpoint Endpoint, version int) int {
+	versions := handlerVersions()
+	for v := latestAPIVersion; v > version; v-- {
+		if _, ok := versions[v][endpoint]; ok {
+			return v
+		}
+	}
+	return 0
+}
+
+// deprecated marks responses from a handler that a newer version has replaced, pointing
+// clients at its successor.
+func deprecated(handler HandlerFunc, successor int) HandlerFunc {
+	return func(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+		resp, err := handler(ctx, params)
+		if err != nil {
+			return resp, err
+		}
+
+		if resp.Headers == nil {
+			resp.Headers = map[string]string{}
+		}
+		resp.Headers["Deprecation"] = "true"
+		if path := unversionedPath(params.Request.Path); path != "" {
+			resp.Headers["Link"] = fmt.Sprintf(`</v%d%s>; rel="successor-version"`, successor, path)
+		}
+		return resp, nil
+	}
+}
+
+func unversionedPath(path string) string {
+	path = removePathPrefix(path)
+	if match := versionPrefix.FindStringSubmatch(path); match != nil {
+		return match[2]
+	}
+	return path
+}
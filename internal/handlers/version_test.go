@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveEndpoint(t *testing.T) {
+	tests := map[string]struct {
+		path             string
+		headers          map[string]string
+		expectedPath     string
+		expectedVersion  int
+		expectedErrorNil bool
+	}{
+		"Happy Path - Unversioned Defaults To V1": {
+			path:             "/Prod/events/{receiverId}",
+			expectedPath:     "/events/{receiverId}",
+			expectedVersion:  APIVersion1,
+			expectedErrorNil: true,
+		},
+		"Happy Path - Path Prefix": {
+			path:             "/Stage/v2/events/{receiverId}",
+			expectedPath:     "/events/{receiverId}",
+			expectedVersion:  APIVersion2,
+			expectedErrorNil: true,
+		},
+		"Happy Path - Header": {
+			path:             "/events/{receiverId}",
+			headers:          map[string]string{"accept-version": "v2"},
+			expectedPath:     "/events/{receiverId}",
+			expectedVersion:  APIVersion2,
+			expectedErrorNil: true,
+		},
+		"Happy Path - Path Prefix Wins Over Header": {
+			path:             "/v1/events/{receiverId}",
+			headers:          map[string]string{"Accept-Version": "2"},
+			expectedPath:     "/events/{receiverId}",
+			expectedVersion:  APIVersion1,
+			expectedErrorNil: true,
+		},
+		"Sad Path - Unknown Version": {
+			path:         "/v9/events/{receiverId}",
+			expectedPath: "/events/{receiverId}",
+		},
+		"Sad Path - Invalid Header": {
+			path:         "/events/{receiverId}",
+			headers:      map[string]string{"Accept-Version": "latest"},
+			expectedPath: "/events/{receiverId}",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			endpoint, version, err := resolveEndpoint(events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				Headers:    tc.headers,
+				RequestContext: events.APIGatewayProxyRequestContext{
+					ResourcePath: tc.path,
+				},
+			})
+			assert.Equal(t, tc.expectedErrorNil, err == nil)
+			assert.Equal(t, tc.expectedPath, endpoint.Path)
+			if tc.expectedErrorNil {
+				assert.Equal(t, tc.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestGetVersionedHandler(t *testing.T) {
+	originalV1, originalV2 := handlersMap, v2HandlersMap
+	defer func() { handlersMap, v2HandlersMap = originalV1, originalV2 }()
+
+	respondWith := func(body string) HandlerFunc {
+		return func(ctx context.Context, params HandlerParams) (events.APIGatewayProxyResponse, error) {
+			return events.APIGatewayProxyResponse{Body: body}, nil
+		}
+	}
+	handlersMap = map[Endpoint]HandlerFunc{
+		{Path: "/events/{receiverId}", Method: http.MethodGet}: respondWith("v1 events"),
+		{Path: "/user/{userId}", Method: http.MethodGet}:       respondWith("v1 user"),
+	}
+	v2HandlersMap = map[Endpoint]HandlerFunc{
+		{Path: "/events/{receiverId}", Method: http.MethodGet}: respondWith("v2 events"),
+	}
+
+	tests := map[string]struct {
+		resourcePath       string
+		path               string
+		headers            map[string]string
+		expectedBody       string
+		expectedDeprecated bool
+		expectedLink       string
+	}{
+		"Happy Path - V1 Default Is Deprecated": {
+			resourcePath:       "/events/{receiverId}",
+			path:               "/events/Receiver%23123",
+			expectedBody:       "v1 events",
+			expectedDeprecated: true,
+			expectedLink:       `</v2/events/Receiver%23123>; rel="successor-version"`,
+		},
+		"Happy Path - V2 Path": {
+			resourcePath: "/v2/events/{receiverId}",
+			path:         "/v2/events/Receiver%23123",
+			expectedBody: "v2 events",
+		},
+		"Happy Path - V2 Header": {
+			resourcePath: "/events/{receiverId}",
+			headers:      map[string]string{"Accept-Version": "2"},
+			expectedBody: "v2 events",
+		},
+		"Happy Path - V2 Falls Back To Unchanged V1 Handler": {
+			resourcePath: "/v2/user/{userId}",
+			expectedBody: "v1 user",
+		},
+	}
+
+	testRegistry := NewRegistry(nil, nil, nil, nil, nil)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				Path:       tc.path,
+				Headers:    tc.headers,
+				RequestContext: events.APIGatewayProxyRequestContext{
+					ResourcePath: tc.resourcePath,
+				},
+			}
+			handler, ok := testRegistry.GetHandler(request)
+			assert.True(t, ok)
+
+			resp, err := handler(context.Background(), HandlerParams{Request: request})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedBody, resp.Body)
+			if tc.expectedDeprecated {
+				assert.Equal(t, "true", resp.Headers["Deprecation"])
+				assert.Equal(t, tc.expectedLink, resp.Headers["Link"])
+			} else {
+				assert.NotContains(t, resp.Headers, "Deprecation")
+			}
+		})
+	}
+
+	_, ok := testRegistry.GetHandler(events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		RequestContext: events.APIGatewayProxyRequestContext{
+			ResourcePath: "/v3/events/{receiverId}",
+		},
+	})
+	assert.False(t, ok)
+}
@@ -0,0 +1,134 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	dateTimeLayout = "20060102T150405Z"
+
+	// RFC 5545 lines are folded at 75 octets, not counting the CRLF.
+	maxLineOctets = 75
+)
+
+// Event is one VEVENT. Times are written in UTC so calendar apps show them in their own
+// timezone. End is optional; an event without one is a point in time.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Categories  []string
+	Start       time.Time
+	End         time.Time
+}
+
+// Calendar is a subscribable feed. RefreshInterval hints how often clients should poll for
+// changes.
+type Calendar struct {
+	Name            string
+	RefreshInterval time.Duration
+	Stamp           time.Time
+	Events          []Event
+}
+
+// Write serializes c as an RFC 5545 VCALENDAR.
+func Write(w io.Writer, c Calendar) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//CareGiver//Care Calendar//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+	if c.RefreshInterval > 0 {
+		line("REFRESH-INTERVAL;VALUE=DURATION", duration(c.RefreshInterval))
+		line("X-PUBLISHED-TTL", duration(c.RefreshInterval))
+	}
+
+	stamp := c.Stamp.UTC().Format(dateTimeLayout)
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(e.UID))
+		line("DTSTAMP", stamp)
+		line("DTSTART", e.Start.UTC().Format(dateTimeLayout))
+		if e.End.After(e.Start) {
+			line("DTEND", e.End.UTC().Format(dateTimeLayout))
+		}
+		line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escape(e.Description))
+		}
+		if len(e.Categories) > 0 {
+			categories := make([]string, len(e.Categories))
+			for i, category := range e.Categories {
+				categories[i] = escape(category)
+			}
+			line("CATEGORIES", strings.Join(categories, ","))
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// escape quotes the characters RFC 5545 reserves in TEXT values.
+func escape(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// writeFolded splits long content lines, continuing each with a single space. Splits never
+// fall inside a multi-byte character.
+func writeFolded(w *bufio.Writer, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		// The leading space counts towards the continuation line's length.
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
+
+// duration formats d as an RFC 5545 DURATION, to the second.
+func duration(d time.Duration) string {
+	seconds := int64(d / time.Second)
+	var b strings.Builder
+	b.WriteString("PT")
+	if hours := seconds / 3600; hours > 0 {
+		b.WriteString(strconv.FormatInt(hours, 10) + "H")
+	}
+	if minutes := seconds % 3600 / 60; minutes > 0 {
+		b.WriteString(strconv.FormatInt(minutes, 10) + "M")
+	}
+	if secs := seconds % 60; secs > 0 || seconds == 0 {
+		b.WriteString(strconv.FormatInt(secs, 10) + "S")
+	}
+	return b.String()
+}
@@ -0,0 +1,100 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	cal := Calendar{
+		Name:            "Care, Calendar",
+		RefreshInterval: time.Hour,
+		Stamp:           time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC),
+		Events: []Event{
+			{
+				UID:         "Event#123@caregiver",
+				Summary:     "Shower",
+				Description: "Receiver: Mary Jones\nNote: Used the chair; went well",
+				Categories:  []string{"Logged"},
+				Start:       time.Date(2025, 3, 10, 8, 0, 0, 0, time.FixedZone("CDT", -5*60*60)),
+				End:         time.Date(2025, 3, 10, 8, 30, 0, 0, time.FixedZone("CDT", -5*60*60)),
+			},
+			{
+				UID:     "Event#456@caregiver",
+				Summary: "Weight",
+				Start:   time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC),
+				End:     time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := Write(&buf, cal)
+	assert.Nil(t, err)
+
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//CareGiver//Care Calendar//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Care\\, Calendar",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT1H",
+		"X-PUBLISHED-TTL:PT1H",
+		"BEGIN:VEVENT",
+		"UID:Event#123@caregiver",
+		"DTSTAMP:20250331T120000Z",
+		"DTSTART:20250310T130000Z",
+		"DTEND:20250310T133000Z",
+		"SUMMARY:Shower",
+		"DESCRIPTION:Receiver: Mary Jones\\nNote: Used the chair\\; went well",
+		"CATEGORIES:Logged",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:Event#456@caregiver",
+		"DTSTAMP:20250331T120000Z",
+		"DTSTART:20250310T140000Z",
+		"SUMMARY:Weight",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteFolded(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeFolded(w, "DESCRIPTION:"+strings.Repeat("é", 80))
+	assert.Nil(t, w.Flush())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	assert.Greater(t, len(lines), 1)
+	for i, line := range lines {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+		assert.True(t, utf8.ValidString(line))
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, " "))
+		}
+	}
+
+	unfolded := strings.ReplaceAll(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n ", "")
+	assert.Equal(t, "DESCRIPTION:"+strings.Repeat("é", 80), unfolded)
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, `a\\b\;c\,d\ne\nf`, escape("a\\b;c,d\ne\r\nf"))
+}
+
+func TestDuration(t *testing.T) {
+	assert.Equal(t, "PT1H", duration(time.Hour))
+	assert.Equal(t, "PT1H30M", duration(90*time.Minute))
+	assert.Equal(t, "PT45S", duration(45*time.Second))
+	assert.Equal(t, "PT0S", duration(0))
+}
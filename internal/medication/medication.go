@@ -0,0 +1,106 @@
+package medication
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/google/uuid"
+)
+
+const (
+	ParamID  = "medicationId"
+	DBPrefix = "Medication"
+
+	// EventType is the event config type dose events are logged under.
+	EventType = "Medication"
+
+	DataPointMedicationID = "medicationId"
+	DataPointName         = "name"
+	DataPointDose         = "dose"
+	DataPointRoute        = "route"
+	DataPointQuantity     = "quantity"
+)
+
+// Medication is one entry on a receiver's medication list. Inventory counts the units
+// on hand (pills, patches, ...) and UnitsPerDose is how many a normal dose uses.
+type Medication struct {
+	ReceiverID      string `json:"receiverId" dynamodbav:"receiver_id"`
+	MedicationID    string `json:"medicationId" dynamodbav:"medication_id"`
+	Name            string `json:"name" dynamodbav:"name"`
+	Dose            string `json:"dose" dynamodbav:"dose"`
+	Route           string `json:"route,omitempty" dynamodbav:"route,omitempty"`
+	Prescriber      string `json:"prescriber,omitempty" dynamodbav:"prescriber,omitempty"`
+	Instructions    string `json:"instructions,omitempty" dynamodbav:"instructions,omitempty"`
+	Inventory       int    `json:"inventory" dynamodbav:"inventory"`
+	UnitsPerDose    int    `json:"unitsPerDose" dynamodbav:"units_per_dose"`
+	RefillThreshold int    `json:"refillThreshold" dynamodbav:"refill_threshold"`
+	CreatedBy       string `json:"createdBy" dynamodbav:"created_by"`
+	CreatedAt       string `json:"createdAt" dynamodbav:"created_at"`
+	UpdatedAt       string `json:"updatedAt" dynamodbav:"updated_at"`
+}
+
+// Details are the fields a caregiver edits. A nil Inventory leaves the count on hand
+// as it is.
+type Details struct {
+	Name            string
+	Dose            string
+	Route           string
+	Prescriber      string
+	Instructions    string
+	Inventory       *int
+	UnitsPerDose    int
+	RefillThreshold int
+}
+
+func NewMedication(rid, createdBy string, d Details) *Medication {
+	now := time.Now().UTC().Format(time.RFC3339)
+	m := &Medication{
+		ReceiverID:   rid,
+		MedicationID: fmt.Sprintf("%s#%s", DBPrefix, uuid.NewString()),
+		CreatedBy:    createdBy,
+		CreatedAt:    now,
+	}
+	m.Apply(d)
+	return m
+}
+
+// Apply replaces the editable fields, defaulting UnitsPerDose to a single unit. The
+// inventory is only replaced when d sets it.
+func (m *Medication) Apply(d Details) {
+	if d.UnitsPerDose == 0 {
+		d.UnitsPerDose = 1
+	}
+
+	m.Name = d.Name
+	m.Dose = d.Dose
+	m.Route = d.Route
+	m.Prescriber = d.Prescriber
+	m.Instructions = d.Instructions
+	if d.Inventory != nil {
+		m.Inventory = *d.Inventory
+	}
+	m.UnitsPerDose = d.UnitsPerDose
+	m.RefillThreshold = d.RefillThreshold
+	m.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+}
+
+func (m Medication) NeedsRefill() bool {
+	return m.Inventory <= m.RefillThreshold
+}
+
+// DoseData describes a dose as structured event data so logged doses can be traced back
+// to the medication they came from.
+func (m Medication) DoseData(quantity int) []event.DataPoint {
+	data := []event.DataPoint{
+		{Name: DataPointMedicationID, Value: m.MedicationID},
+		{Name: DataPointName, Value: m.Name},
+		{Name: DataPointDose, Value: m.Dose},
+		{Name: DataPointQuantity, Value: strconv.Itoa(quantity)},
+	}
+	if m.Route != "" {
+		data = append(data, event.DataPoint{Name: DataPointRoute, Value: m.Route})
+	}
+	return data
+}
@@ -0,0 +1,52 @@
+package medication
+
+import (
+	"testing"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMedication(t *testing.T) {
+	inventory := 30
+	m := NewMedication("Receiver#123", "User#123", Details{
+		Name:            "Lisinopril",
+		Dose:            "10mg",
+		Inventory:       &inventory,
+		RefillThreshold: 5,
+	})
+
+	assert.Regexp(t, "^Medication#", m.MedicationID)
+	assert.Equal(t, "Receiver#123", m.ReceiverID)
+	assert.Equal(t, 1, m.UnitsPerDose)
+	assert.Equal(t, m.CreatedAt, m.UpdatedAt)
+	assert.False(t, m.NeedsRefill())
+
+	m.Inventory = 5
+	assert.True(t, m.NeedsRefill())
+}
+
+func TestApply(t *testing.T) {
+	m := Medication{Name: "Lisinopril", Dose: "10mg", Inventory: 12, UnitsPerDose: 2}
+
+	m.Apply(Details{Name: "Lisinopril", Dose: "20mg"})
+	assert.Equal(t, "20mg", m.Dose)
+	assert.Equal(t, 12, m.Inventory)
+	assert.Equal(t, 1, m.UnitsPerDose)
+
+	restocked := 90
+	m.Apply(Details{Name: "Lisinopril", Dose: "20mg", Inventory: &restocked})
+	assert.Equal(t, 90, m.Inventory)
+}
+
+func TestDoseData(t *testing.T) {
+	m := Medication{MedicationID: "Medication#123", Name: "Insulin", Dose: "4 units", Route: "subcutaneous"}
+
+	assert.Equal(t, []event.DataPoint{
+		{Name: DataPointMedicationID, Value: "Medication#123"},
+		{Name: DataPointName, Value: "Insulin"},
+		{Name: DataPointDose, Value: "4 units"},
+		{Name: DataPointQuantity, Value: "2"},
+		{Name: DataPointRoute, Value: "subcutaneous"},
+	}, m.DoseData(2))
+}
@@ -0,0 +1,236 @@
+package medication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrNotFound              = errors.New("medication not found")
+	ErrInsufficientInventory = errors.New("not enough medication on hand")
+)
+
+type RepositoryProvider interface {
+	AddMedication(m *Medication) error
+	GetMedication(rid, mid string) (*Medication, error)
+	GetMedications(rid string) ([]Medication, error)
+	UpdateMedication(rid, mid string, d Details) (*Medication, error)
+	AdjustInventory(rid, mid string, delta int) (*Medication, error)
+}
+
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+func (r *Repository) AddMedication(m *Medication) error {
+	item, err := attributevalue.MarshalMap(m)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.PutItem(r.Ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.TableName),
+		Item:      item,
+	})
+	if err != nil {
+		r.logger.Error("error adding medication", zap.String(log.ReceiverIDLogKey, m.ReceiverID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *Repository) GetMedication(rid, mid string) (*Medication, error) {
+	result, err := r.Client.GetItem(r.Ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.TableName),
+		Key:       key(rid, mid),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Item == nil {
+		return nil, ErrNotFound
+	}
+
+	var m Medication
+	err = attributevalue.UnmarshalMap(result.Item, &m)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *Repository) GetMedications(rid string) ([]Medication, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.TableName),
+		KeyConditionExpression: aws.String("receiver_id = :rid"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":rid": &types.AttributeValueMemberS{Value: rid},
+		},
+	}
+
+	medications := []Medication{}
+	paginator := dynamodb.NewQueryPaginator(r.Client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(r.Ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageMedications []Medication
+		err = attributevalue.UnmarshalListOfMaps(page.Items, &pageMedications)
+		if err != nil {
+			return nil, err
+		}
+		medications = append(medications, pageMedications...)
+	}
+	return medications, nil
+}
+
+// UpdateMedication writes only the editable fields and returns the medication as stored.
+// The inventory is left alone unless d sets it, so an edit never undoes doses taken out
+// through AdjustInventory since the medication was read.
+func (r *Repository) UpdateMedication(rid, mid string, d Details) (*Medication, error) {
+	var m Medication
+	m.Apply(d)
+
+	set := []string{
+		"#name = :name",
+		"dose = :dose",
+		"units_per_dose = :unitsPerDose",
+		"refill_threshold = :refillThreshold",
+		"updated_at = :now",
+	}
+	remove := []string{}
+	values := map[string]types.AttributeValue{
+		":name":            &types.AttributeValueMemberS{Value: m.Name},
+		":dose":            &types.AttributeValueMemberS{Value: m.Dose},
+		":unitsPerDose":    &types.AttributeValueMemberN{Value: strconv.Itoa(m.UnitsPerDose)},
+		":refillThreshold": &types.AttributeValueMemberN{Value: strconv.Itoa(m.RefillThreshold)},
+		":now":             &types.AttributeValueMemberS{Value: m.UpdatedAt},
+	}
+
+	// Optional fields are stored with omitempty, so clearing one removes the attribute.
+	for _, field := range []struct{ attr, value string }{
+		{"route", m.Route},
+		{"prescriber", m.Prescriber},
+		{"instructions", m.Instructions},
+	} {
+		if field.value == "" {
+			remove = append(remove, field.attr)
+			continue
+		}
+		set = append(set, fmt.Sprintf("%s = :%s", field.attr, field.attr))
+		values[":"+field.attr] = &types.AttributeValueMemberS{Value: field.value}
+	}
+
+	if d.Inventory != nil {
+		set = append(set, "inventory = :inventory")
+		values[":inventory"] = &types.AttributeValueMemberN{Value: strconv.Itoa(m.Inventory)}
+	}
+
+	update := "SET " + strings.Join(set, ", ")
+	if len(remove) > 0 {
+		update += " REMOVE " + strings.Join(remove, ", ")
+	}
+
+	result, err := r.Client.UpdateItem(r.Ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.TableName),
+		Key:                       key(rid, mid),
+		UpdateExpression:          aws.String(update),
+		ConditionExpression:       aws.String("attribute_exists(medication_id)"),
+		ExpressionAttributeNames:  map[string]string{"#name": "name"},
+		ExpressionAttributeValues: values,
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return nil, ErrNotFound
+		}
+		r.logger.Error("error updating medication", zap.String(log.ReceiverIDLogKey, rid), zap.Error(err))
+		return nil, err
+	}
+
+	var updated Medication
+	err = attributevalue.UnmarshalMap(result.Attributes, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// AdjustInventory atomically adds delta to the inventory and returns the updated
+// medication. A decrement that would take the inventory below zero is rejected with
+// ErrInsufficientInventory so concurrent doses cannot oversell what is on hand.
+func (r *Repository) AdjustInventory(rid, mid string, delta int) (*Medication, error) {
+	condition := "attribute_exists(medication_id)"
+	values := map[string]types.AttributeValue{
+		":delta": &types.AttributeValueMemberN{Value: strconv.Itoa(delta)},
+		":now":   &types.AttributeValueMemberS{Value: time.Now().UTC().Format(time.RFC3339)},
+	}
+	if delta < 0 {
+		condition += " AND inventory >= :needed"
+		values[":needed"] = &types.AttributeValueMemberN{Value: strconv.Itoa(-delta)}
+	}
+
+	result, err := r.Client.UpdateItem(r.Ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.TableName),
+		Key:                       key(rid, mid),
+		UpdateExpression:          aws.String("SET inventory = inventory + :delta, updated_at = :now"),
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeValues: values,
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			if delta < 0 {
+				if _, getErr := r.GetMedication(rid, mid); errors.Is(getErr, ErrNotFound) {
+					return nil, ErrNotFound
+				}
+				return nil, ErrInsufficientInventory
+			}
+			return nil, ErrNotFound
+		}
+		r.logger.Error("error adjusting medication inventory", zap.String(log.ReceiverIDLogKey, rid), zap.Error(err))
+		return nil, err
+	}
+
+	var m Medication
+	err = attributevalue.UnmarshalMap(result.Attributes, &m)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func key(rid, mid string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"receiver_id":   &types.AttributeValueMemberS{Value: rid},
+		"medication_id": &types.AttributeValueMemberS{Value: mid},
+	}
+}
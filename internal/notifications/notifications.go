@@ -0,0 +1,98 @@
+package notifications
+
+import (
+	"github.com/care-giver-app/care-giver-api/internal/alert"
+	"github.com/care-giver-app/care-giver-api/internal/digest"
+)
+
+type NotificationType string
+
+const (
+	TypeFeedback       NotificationType = "feedback"
+	TypeAlert          NotificationType = "alert"
+	TypeActivityDigest NotificationType = "activity_digest"
+)
+
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+)
+
+// Message attribute names the notifications service routes on without parsing the body.
+const (
+	AttributeNotificationType = "notification_type"
+	AttributeChannel          = "channel"
+)
+
+// Notification is the envelope the notifications service consumes. ExecutionData holds
+// one of the payload types below, matching NotificationType.
+type Notification struct {
+	NotificationType NotificationType `json:"notification_type"`
+	Channel          []Channel        `json:"channel"`
+	ExecutionData    any              `json:"execution_data"`
+}
+
+// FeedbackPayload is addressed to Email; UserID and UserEmail identify who sent it.
+type FeedbackPayload struct {
+	Email      string `json:"email"`
+	FeedbackID string `json:"feedbackId"`
+	Message    string `json:"message"`
+	Category   string `json:"category,omitempty"`
+	Rating     int    `json:"rating,omitempty"`
+	AppVersion string `json:"appVersion,omitempty"`
+	Platform   string `json:"platform,omitempty"`
+	UserID     string `json:"userId,omitempty"`
+	UserEmail  string `json:"userEmail,omitempty"`
+}
+
+type AlertPayload struct {
+	Email      string         `json:"email"`
+	ReceiverID string         `json:"receiverId"`
+	EventID    string         `json:"eventId"`
+	EventType  string         `json:"eventType"`
+	StartTime  string         `json:"startTime"`
+	LoggedBy   string         `json:"loggedBy"`
+	Breaches   []alert.Breach `json:"breaches"`
+}
+
+type ActivityDigestPayload struct {
+	Email      string        `json:"email"`
+	ReceiverID string        `json:"receiverId"`
+	Events     []digest.Item `json:"events"`
+}
+
+func NewFeedback(p FeedbackPayload) Notification {
+	return newEmail(TypeFeedback, p)
+}
+
+func NewAlert(p AlertPayload) Notification {
+	return newEmail(TypeAlert, p)
+}
+
+func NewActivityDigest(p ActivityDigestPayload) Notification {
+	return newEmail(TypeActivityDigest, p)
+}
+
+func newEmail(t NotificationType, payload any) Notification {
+	return Notification{
+		NotificationType: t,
+		Channel:          []Channel{ChannelEmail},
+		ExecutionData:    payload,
+	}
+}
+
+// Attributes returns the routing attributes sent alongside the message body.
+func (n Notification) Attributes() map[string]string {
+	attributes := map[string]string{
+		AttributeNotificationType: string(n.NotificationType),
+	}
+	if len(n.Channel) > 0 {
+		channels := string(n.Channel[0])
+		for _, c := range n.Channel[1:] {
+			channels += "," + string(c)
+		}
+		attributes[AttributeChannel] = channels
+	}
+	return attributes
+}
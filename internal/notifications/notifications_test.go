@@ -0,0 +1,118 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeSQS struct {
+	failures int
+	calls    int
+	inputs   []*sqs.SendMessageInput
+}
+
+func (f *fakeSQS) SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+	f.calls++
+	f.inputs = append(f.inputs, params)
+	if f.calls <= f.failures {
+		return nil, errors.New("throttled")
+	}
+	return &sqs.SendMessageOutput{}, nil
+}
+
+func TestConstructors(t *testing.T) {
+	n := NewFeedback(FeedbackPayload{Email: "support@test.com", FeedbackID: "Feedback#123", Message: "Love it"})
+	assert.Equal(t, TypeFeedback, n.NotificationType)
+	assert.Equal(t, []Channel{ChannelEmail}, n.Channel)
+
+	body, err := json.Marshal(n)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"notification_type":"feedback","channel":["email"],"execution_data":{"email":"support@test.com","feedbackId":"Feedback#123","message":"Love it"}}`, string(body))
+
+	assert.Equal(t, TypeAlert, NewAlert(AlertPayload{}).NotificationType)
+	assert.Equal(t, TypeActivityDigest, NewActivityDigest(ActivityDigestPayload{}).NotificationType)
+
+	assert.Equal(t, map[string]string{
+		AttributeNotificationType: "feedback",
+		AttributeChannel:          "email",
+	}, n.Attributes())
+}
+
+func TestSQSPublisher(t *testing.T) {
+	tests := map[string]struct {
+		queueURL      string
+		failures      int
+		expectedCalls int
+		expectError   bool
+	}{
+		"Happy Path - First Attempt": {
+			queueURL:      "https://sqs.test/queue",
+			expectedCalls: 1,
+		},
+		"Happy Path - Retried": {
+			queueURL:      "https://sqs.test/queue",
+			failures:      2,
+			expectedCalls: 3,
+		},
+		"Sad Path - Retries Exhausted": {
+			queueURL:      "https://sqs.test/queue",
+			failures:      maxAttempts,
+			expectedCalls: maxAttempts,
+			expectError:   true,
+		},
+		"Sad Path - Queue Not Configured": {
+			expectedCalls: 0,
+			expectError:   true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &fakeSQS{failures: tc.failures}
+			p := &SQSPublisher{client: client, queueURL: tc.queueURL, backoff: time.Millisecond, logger: zap.NewNop()}
+
+			err := p.Publish(context.Background(), NewFeedback(FeedbackPayload{Message: "hi"}))
+			assert.Equal(t, tc.expectError, err != nil)
+			assert.Equal(t, tc.expectedCalls, client.calls)
+
+			if tc.expectedCalls > 0 {
+				input := client.inputs[0]
+				assert.Equal(t, tc.queueURL, aws.ToString(input.QueueUrl))
+				assert.Equal(t, "feedback", aws.ToString(input.MessageAttributes[AttributeNotificationType].StringValue))
+			}
+		})
+	}
+}
+
+func TestSQSPublisherStopsWhenContextDone(t *testing.T) {
+	client := &fakeSQS{failures: maxAttempts}
+	p := &SQSPublisher{client: client, queueURL: "https://sqs.test/queue", backoff: time.Hour, logger: zap.NewNop()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := p.Publish(ctx, NewFeedback(FeedbackPayload{Message: "hi"}))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestMemoryPublisher(t *testing.T) {
+	p := NewMemoryPublisher()
+	assert.Nil(t, p.Publish(context.Background(), NewFeedback(FeedbackPayload{Message: "hi"})))
+	assert.Nil(t, p.Publish(context.Background(), NewAlert(AlertPayload{ReceiverID: "Receiver#123"})))
+
+	published := p.Notifications()
+	assert.Len(t, published, 2)
+	assert.Equal(t, TypeAlert, published[1].NotificationType)
+
+	p.Reset()
+	assert.Empty(t, p.Notifications())
+}
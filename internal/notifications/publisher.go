@@ -0,0 +1,131 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"go.uber.org/zap"
+)
+
+const (
+	maxAttempts    = 3
+	initialBackoff = 100 * time.Millisecond
+)
+
+var ErrQueueNotConfigured = errors.New("notification queue URL not configured")
+
+type Publisher interface {
+	Publish(ctx context.Context, n Notification) error
+}
+
+type sqsAPI interface {
+	SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+}
+
+// SQSPublisher sends notifications to the notifications queue. Create one per process and
+// share it; the underlying client is safe for concurrent use.
+type SQSPublisher struct {
+	client   sqsAPI
+	queueURL string
+	backoff  time.Duration
+	logger   *zap.Logger
+}
+
+func NewSQSPublisher(cfg aws.Config, queueURL string, logger *zap.Logger) *SQSPublisher {
+	return &SQSPublisher{
+		client:   sqs.NewFromConfig(cfg),
+		queueURL: queueURL,
+		backoff:  initialBackoff,
+		logger:   logger.With(zap.String("queue", queueURL)),
+	}
+}
+
+// Publish sends the notification, retrying failed sends with exponential backoff. It gives
+// up early if ctx is done.
+func (p *SQSPublisher) Publish(ctx context.Context, n Notification) error {
+	if p.queueURL == "" {
+		return ErrQueueNotConfigured
+	}
+
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	input := &sqs.SendMessageInput{
+		QueueUrl:          aws.String(p.queueURL),
+		MessageBody:       aws.String(string(body)),
+		MessageAttributes: messageAttributes(n),
+	}
+
+	backoff := p.backoff
+	for attempt := 1; ; attempt++ {
+		_, err = p.client.SendMessage(ctx, input)
+		if err == nil {
+			return nil
+		}
+
+		if attempt == maxAttempts {
+			return err
+		}
+
+		p.logger.Warn("error sending notification, retrying", zap.String(AttributeNotificationType, string(n.NotificationType)), zap.Int("attempt", attempt), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func messageAttributes(n Notification) map[string]types.MessageAttributeValue {
+	attributes := map[string]types.MessageAttributeValue{}
+	for name, value := range n.Attributes() {
+		attributes[name] = types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(value),
+		}
+	}
+	return attributes
+}
+
+// MemoryPublisher keeps notifications in memory instead of sending them. It is used in
+// local mode and by tests that need to see what was published.
+type MemoryPublisher struct {
+	mu            sync.Mutex
+	notifications []Notification
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, n Notification) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.notifications = append(p.notifications, n)
+	return nil
+}
+
+// Notifications returns a copy of everything published so far.
+func (p *MemoryPublisher) Notifications() []Notification {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Notification(nil), p.notifications...)
+}
+
+func (p *MemoryPublisher) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.notifications = nil
+}
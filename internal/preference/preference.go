@@ -0,0 +1,160 @@
+package preference
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	// Lambda's provided runtime does not guarantee a zoneinfo database.
+	_ "time/tzdata"
+)
+
+const (
+	DefaultDigestMinutes = 15
+	MaxDigestMinutes     = 240
+
+	clockFormat = "15:04"
+)
+
+// Preferences controls which event activity a caregiver is emailed about for one receiver.
+// They sit alongside the relationship's EmailNotifications switch, which still has to be on
+// for any activity email to go out.
+type Preferences struct {
+	UserID          string   `json:"userId" dynamodbav:"user_id"`
+	ReceiverID      string   `json:"receiverId" dynamodbav:"receiver_id"`
+	EventTypes      []string `json:"eventTypes,omitempty" dynamodbav:"event_types,omitempty"`
+	QuietHoursStart string   `json:"quietHoursStart,omitempty" dynamodbav:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   string   `json:"quietHoursEnd,omitempty" dynamodbav:"quiet_hours_end,omitempty"`
+	Timezone        string   `json:"timezone,omitempty" dynamodbav:"timezone,omitempty"`
+	DigestMinutes   int      `json:"digestMinutes" dynamodbav:"digest_minutes"`
+	UpdatedAt       string   `json:"updatedAt,omitempty" dynamodbav:"updated_at,omitempty"`
+}
+
+// Default is used until a caregiver saves their own preferences: every event type, no quiet
+// hours and the default digest window.
+func Default(uid, rid string) Preferences {
+	return Preferences{
+		UserID:        uid,
+		ReceiverID:    rid,
+		DigestMinutes: DefaultDigestMinutes,
+	}
+}
+
+// ValidationError names the field of the preferences request that Validate rejected.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// Validate checks the preferences and returns a *ValidationError for the first bad field.
+func (p Preferences) Validate() error {
+	if p.DigestMinutes < 1 || p.DigestMinutes > MaxDigestMinutes {
+		return &ValidationError{Field: "digestMinutes", Message: fmt.Sprintf("must be between 1 and %d", MaxDigestMinutes)}
+	}
+
+	if p.QuietHoursStart == "" && p.QuietHoursEnd != "" {
+		return &ValidationError{Field: "quietHoursStart", Message: "is required when quietHoursEnd is set"}
+	}
+	if p.QuietHoursEnd == "" && p.QuietHoursStart != "" {
+		return &ValidationError{Field: "quietHoursEnd", Message: "is required when quietHoursStart is set"}
+	}
+	for _, clock := range []struct{ field, value string }{
+		{field: "quietHoursStart", value: p.QuietHoursStart},
+		{field: "quietHoursEnd", value: p.QuietHoursEnd},
+	} {
+		if clock.value == "" {
+			continue
+		}
+		if _, err := time.Parse(clockFormat, clock.value); err != nil {
+			return &ValidationError{Field: clock.field, Message: "must be a time of day as HH:MM"}
+		}
+	}
+
+	if p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			return &ValidationError{Field: "timezone", Message: "must be an IANA time zone such as America/Chicago"}
+		}
+	}
+	return nil
+}
+
+// Wants reports whether activity of eventType should be sent. No event types means all of them.
+func (p Preferences) Wants(eventType string) bool {
+	return len(p.EventTypes) == 0 || slices.Contains(p.EventTypes, eventType)
+}
+
+// InQuietHours reports whether t falls inside the quiet hours. Windows that cross midnight,
+// such as 22:00 to 07:00, are supported.
+func (p Preferences) InQuietHours(t time.Time) bool {
+	start, end, ok := p.quietHours()
+	if !ok {
+		return false
+	}
+
+	local := t.In(p.location())
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// FlushAt is when a digest opened at now should be sent: after the digest window, pushed
+// back to the end of quiet hours if the window closes inside them.
+func (p Preferences) FlushAt(now time.Time) time.Time {
+	flushAt := now.Add(time.Duration(p.DigestMinutes) * time.Minute)
+	if p.InQuietHours(flushAt) {
+		return p.QuietHoursEndAfter(flushAt)
+	}
+	return flushAt
+}
+
+// QuietHoursEndAfter returns the first end of quiet hours after t.
+func (p Preferences) QuietHoursEndAfter(t time.Time) time.Time {
+	_, end, ok := p.quietHours()
+	if !ok {
+		return t
+	}
+
+	local := t.In(p.location())
+	next := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, local.Location())
+	if !next.After(local) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+func (p Preferences) quietHours() (int, int, bool) {
+	if p.QuietHoursStart == "" || p.QuietHoursEnd == "" {
+		return 0, 0, false
+	}
+
+	start, err := time.Parse(clockFormat, p.QuietHoursStart)
+	if err != nil {
+		return 0, 0, false
+	}
+	end, err := time.Parse(clockFormat, p.QuietHoursEnd)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+	return startMinute, endMinute, startMinute != endMinute
+}
+
+func (p Preferences) location() *time.Location {
+	if p.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
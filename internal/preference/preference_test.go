@@ -0,0 +1,86 @@
+package preference
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Default("User#123", "Receiver#123").Validate())
+
+	tests := map[string]struct {
+		preferences   Preferences
+		expectedField string
+	}{
+		"Digest Too Short":    {preferences: Preferences{DigestMinutes: 0}, expectedField: "digestMinutes"},
+		"Digest Too Long":     {preferences: Preferences{DigestMinutes: MaxDigestMinutes + 1}, expectedField: "digestMinutes"},
+		"Missing Quiet End":   {preferences: Preferences{DigestMinutes: 15, QuietHoursStart: "22:00"}, expectedField: "quietHoursEnd"},
+		"Missing Quiet Start": {preferences: Preferences{DigestMinutes: 15, QuietHoursEnd: "07:00"}, expectedField: "quietHoursStart"},
+		"Bad Quiet Start":     {preferences: Preferences{DigestMinutes: 15, QuietHoursStart: "10pm", QuietHoursEnd: "07:00"}, expectedField: "quietHoursStart"},
+		"Bad Quiet End":       {preferences: Preferences{DigestMinutes: 15, QuietHoursStart: "22:00", QuietHoursEnd: "7am"}, expectedField: "quietHoursEnd"},
+		"Unknown Timezone":    {preferences: Preferences{DigestMinutes: 15, Timezone: "Mars/Olympus_Mons"}, expectedField: "timezone"},
+		"Hour Out Of Range":   {preferences: Preferences{DigestMinutes: 15, QuietHoursStart: "25:00", QuietHoursEnd: "07:00"}, expectedField: "quietHoursStart"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.preferences.Validate()
+			var validationErr *ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tc.expectedField, validationErr.Field)
+		})
+	}
+}
+
+func TestWants(t *testing.T) {
+	assert.True(t, Default("User#123", "Receiver#123").Wants("Shower"))
+
+	p := Preferences{EventTypes: []string{"Medication"}}
+	assert.True(t, p.Wants("Medication"))
+	assert.False(t, p.Wants("Shower"))
+}
+
+func TestQuietHours(t *testing.T) {
+	p := Preferences{
+		QuietHoursStart: "22:00",
+		QuietHoursEnd:   "07:00",
+		Timezone:        "America/Chicago",
+		DigestMinutes:   15,
+	}
+
+	tests := map[string]struct {
+		now             string
+		expectedQuiet   bool
+		expectedFlushAt string
+	}{
+		"Daytime": {
+			now:             "2026-05-06T15:00:00-05:00",
+			expectedQuiet:   false,
+			expectedFlushAt: "2026-05-06T15:15:00-05:00",
+		},
+		"Window Closes Inside Quiet Hours": {
+			now:             "2026-05-06T21:50:00-05:00",
+			expectedQuiet:   false,
+			expectedFlushAt: "2026-05-07T07:00:00-05:00",
+		},
+		"After Midnight": {
+			now:             "2026-05-07T02:00:00-05:00",
+			expectedQuiet:   true,
+			expectedFlushAt: "2026-05-07T07:00:00-05:00",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			now, err := time.Parse(time.RFC3339, tc.now)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedQuiet, p.InQuietHours(now))
+			assert.Equal(t, tc.expectedFlushAt, p.FlushAt(now).Format(time.RFC3339))
+		})
+	}
+
+	daytime := Preferences{QuietHoursStart: "09:00", QuietHoursEnd: "17:00", DigestMinutes: 15}
+	assert.True(t, daytime.InQuietHours(time.Date(2026, 5, 6, 12, 0, 0, 0, time.UTC)))
+	assert.False(t, daytime.InQuietHours(time.Date(2026, 5, 6, 17, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,77 @@
+package preference
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"go.uber.org/zap"
+)
+
+var ErrNotFound = errors.New("notification preferences not found")
+
+type RepositoryProvider interface {
+	GetPreferences(uid, rid string) (Preferences, error)
+	PutPreferences(p Preferences) error
+}
+
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+func (r *Repository) GetPreferences(uid, rid string) (Preferences, error) {
+	result, err := r.Client.GetItem(r.Ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.TableName),
+		Key: map[string]types.AttributeValue{
+			"user_id":     &types.AttributeValueMemberS{Value: uid},
+			"receiver_id": &types.AttributeValueMemberS{Value: rid},
+		},
+	})
+	if err != nil {
+		return Preferences{}, err
+	}
+
+	if result.Item == nil {
+		return Preferences{}, ErrNotFound
+	}
+
+	var p Preferences
+	err = attributevalue.UnmarshalMap(result.Item, &p)
+	if err != nil {
+		return Preferences{}, err
+	}
+	return p, nil
+}
+
+func (r *Repository) PutPreferences(p Preferences) error {
+	item, err := attributevalue.MarshalMap(p)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.PutItem(r.Ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.TableName),
+		Item:      item,
+	})
+	if err != nil {
+		r.logger.Error("error saving notification preferences", zap.String(log.UserIDLogKey, p.UserID), zap.String(log.ReceiverIDLogKey, p.ReceiverID), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	// Lambda's provided runtime does not guarantee a zoneinfo database.
+	_ "time/tzdata"
+)
+
+const (
+	AnonymizedFirstName = "Former"
+	AnonymizedLastName  = "Caregiver"
+)
+
+type NotificationPreferences struct {
+	Email bool `json:"email" dynamodbav:"email"`
+	SMS   bool `json:"sms" dynamodbav:"sms"`
+	Push  bool `json:"push" dynamodbav:"push"`
+}
+
+// Profile holds the editable parts of a user. It lives on the user's item in the user
+// table alongside the fields owned by user.User.
+type Profile struct {
+	FirstName               string                  `json:"firstName" dynamodbav:"first_name"`
+	LastName                string                  `json:"lastName" dynamodbav:"last_name"`
+	Phone                   string                  `json:"phone,omitempty" dynamodbav:"phone,omitempty"`
+	Timezone                string                  `json:"timezone,omitempty" dynamodbav:"timezone,omitempty"`
+	NotificationPreferences NotificationPreferences `json:"notificationPreferences" dynamodbav:"notification_preferences"`
+}
+
+func (p Profile) Validate() error {
+	if p.FirstName == "" || p.LastName == "" {
+		return errors.New("first and last name are required")
+	}
+
+	if p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			return fmt.Errorf("unknown timezone %s", p.Timezone)
+		}
+	}
+
+	return nil
+}
+
+// Location returns the profile's timezone, falling back to UTC when none is set.
+func (p Profile) Location() *time.Location {
+	if p.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
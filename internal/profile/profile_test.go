@@ -0,0 +1,46 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		profile   Profile
+		expectErr bool
+	}{
+		"Happy Path - Names Only": {
+			profile: Profile{FirstName: "Jane", LastName: "Smith"},
+		},
+		"Happy Path - With Timezone": {
+			profile: Profile{FirstName: "Jane", LastName: "Smith", Timezone: "America/Chicago"},
+		},
+		"Sad Path - Missing Name": {
+			profile:   Profile{FirstName: "Jane"},
+			expectErr: true,
+		},
+		"Sad Path - Unknown Timezone": {
+			profile:   Profile{FirstName: "Jane", LastName: "Smith", Timezone: "Mars/Olympus"},
+			expectErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.profile.Validate()
+			if tc.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestLocation(t *testing.T) {
+	assert.Equal(t, time.UTC, Profile{}.Location())
+	assert.Equal(t, time.UTC, Profile{Timezone: "Mars/Olympus"}.Location())
+	assert.Equal(t, "America/Chicago", Profile{Timezone: "America/Chicago"}.Location().String())
+}
@@ -0,0 +1,176 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"go.uber.org/zap"
+)
+
+const (
+	userIDAttribute = "user_id"
+)
+
+// timezone is a DynamoDB reserved word, so expressions refer to it as #tz.
+var timezoneName = map[string]string{"#tz": "timezone"}
+
+var ErrUserNotFound = errors.New("user not found")
+
+type RepositoryProvider interface {
+	GetProfile(uid string) (Profile, error)
+	UpdateProfile(uid string, p Profile) error
+	AnonymizeUser(uid string) error
+	ListUserEmails() ([]UserEmail, error)
+}
+
+// UserEmail pairs a user with the email stored on their item, as it was written.
+type UserEmail struct {
+	UserID string `dynamodbav:"user_id"`
+	Email  string `dynamodbav:"email"`
+}
+
+// Repository reads and writes profile fields on items in the user table.
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+func (r *Repository) GetProfile(uid string) (Profile, error) {
+	result, err := r.Client.GetItem(r.Ctx, getProfileInput(r.TableName, uid))
+	if err != nil {
+		return Profile{}, err
+	}
+
+	if result.Item == nil {
+		return Profile{}, ErrUserNotFound
+	}
+
+	var p Profile
+	err = attributevalue.UnmarshalMap(result.Item, &p)
+	return p, err
+}
+
+func (r *Repository) UpdateProfile(uid string, p Profile) error {
+	prefs, err := attributevalue.Marshal(p.NotificationPreferences)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.UpdateItem(r.Ctx, updateProfileInput(r.TableName, uid, p, prefs))
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrUserNotFound
+		}
+		r.logger.Error("error updating user profile", zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// AnonymizeUser strips a user's personal data while keeping the item, so events they
+// logged still resolve to an author.
+func (r *Repository) AnonymizeUser(uid string) error {
+	_, err := r.Client.UpdateItem(r.Ctx, anonymizeUserInput(r.TableName, uid, time.Now()))
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrUserNotFound
+		}
+		r.logger.Error("error anonymizing user", zap.String(log.UserIDLogKey, uid), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListUserEmails returns the email of every user that still has one. It scans the whole
+// table and is only meant for one-off migrations.
+func (r *Repository) ListUserEmails() ([]UserEmail, error) {
+	input := &dynamodb.ScanInput{
+		TableName:            aws.String(r.TableName),
+		FilterExpression:     aws.String("attribute_exists(email)"),
+		ProjectionExpression: aws.String("user_id, email"),
+	}
+
+	emails := []UserEmail{}
+	paginator := dynamodb.NewScanPaginator(r.Client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(r.Ctx)
+		if err != nil {
+			r.logger.Error("error scanning user emails", zap.Error(err))
+			return nil, err
+		}
+
+		var pageEmails []UserEmail
+		err = attributevalue.UnmarshalListOfMaps(page.Items, &pageEmails)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, pageEmails...)
+	}
+	return emails, nil
+}
+
+func getProfileInput(tableName, uid string) *dynamodb.GetItemInput {
+	return &dynamodb.GetItemInput{
+		TableName:                aws.String(tableName),
+		Key:                      key(uid),
+		ProjectionExpression:     aws.String("first_name, last_name, phone, #tz, notification_preferences"),
+		ExpressionAttributeNames: timezoneName,
+	}
+}
+
+func updateProfileInput(tableName, uid string, p Profile, prefs types.AttributeValue) *dynamodb.UpdateItemInput {
+	return &dynamodb.UpdateItemInput{
+		TableName:                aws.String(tableName),
+		Key:                      key(uid),
+		ConditionExpression:      aws.String("attribute_exists(user_id)"),
+		UpdateExpression:         aws.String("SET first_name = :first, last_name = :last, phone = :phone, #tz = :tz, notification_preferences = :prefs"),
+		ExpressionAttributeNames: timezoneName,
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":first": &types.AttributeValueMemberS{Value: p.FirstName},
+			":last":  &types.AttributeValueMemberS{Value: p.LastName},
+			":phone": &types.AttributeValueMemberS{Value: p.Phone},
+			":tz":    &types.AttributeValueMemberS{Value: p.Timezone},
+			":prefs": prefs,
+		},
+	}
+}
+
+func anonymizeUserInput(tableName, uid string, now time.Time) *dynamodb.UpdateItemInput {
+	return &dynamodb.UpdateItemInput{
+		TableName:                aws.String(tableName),
+		Key:                      key(uid),
+		ConditionExpression:      aws.String("attribute_exists(user_id)"),
+		UpdateExpression:         aws.String("SET first_name = :first, last_name = :last, deleted_at = :now REMOVE email, phone, #tz, notification_preferences"),
+		ExpressionAttributeNames: timezoneName,
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":first": &types.AttributeValueMemberS{Value: AnonymizedFirstName},
+			":last":  &types.AttributeValueMemberS{Value: AnonymizedLastName},
+			":now":   &types.AttributeValueMemberS{Value: now.UTC().Format(time.RFC3339)},
+		},
+	}
+}
+
+func key(uid string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		userIDAttribute: &types.AttributeValueMemberS{Value: uid},
+	}
+}
@@ -0,0 +1,45 @@
+package profile
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// bareTimezone finds timezone used as a name rather than through #tz, which DynamoDB
+// rejects because it is a reserved word.
+var bareTimezone = regexp.MustCompile(`(^|[\s,])timezone($|[\s,=])`)
+
+func TestGetProfileInput(t *testing.T) {
+	input := getProfileInput("user-table", "User#123")
+	assert.Equal(t, "user-table", aws.ToString(input.TableName))
+	assert.Equal(t, key("User#123"), input.Key)
+	assert.Equal(t, "first_name, last_name, phone, #tz, notification_preferences", aws.ToString(input.ProjectionExpression))
+	assert.Equal(t, map[string]string{"#tz": "timezone"}, input.ExpressionAttributeNames)
+	assert.False(t, bareTimezone.MatchString(aws.ToString(input.ProjectionExpression)))
+}
+
+func TestUpdateProfileInput(t *testing.T) {
+	prefs := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{}}
+	input := updateProfileInput("user-table", "User#123", Profile{FirstName: "Jane", Timezone: "America/Chicago"}, prefs)
+	assert.Equal(t, key("User#123"), input.Key)
+	assert.Equal(t, map[string]string{"#tz": "timezone"}, input.ExpressionAttributeNames)
+	assert.Contains(t, aws.ToString(input.UpdateExpression), "#tz = :tz")
+	assert.False(t, bareTimezone.MatchString(aws.ToString(input.UpdateExpression)))
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "America/Chicago"}, input.ExpressionAttributeValues[":tz"])
+	assert.Equal(t, prefs, input.ExpressionAttributeValues[":prefs"])
+}
+
+func TestAnonymizeUserInput(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.FixedZone("CST", -6*60*60))
+	input := anonymizeUserInput("user-table", "User#123", now)
+	assert.Equal(t, key("User#123"), input.Key)
+	assert.Equal(t, map[string]string{"#tz": "timezone"}, input.ExpressionAttributeNames)
+	assert.Contains(t, aws.ToString(input.UpdateExpression), "REMOVE email, phone, #tz, notification_preferences")
+	assert.False(t, bareTimezone.MatchString(aws.ToString(input.UpdateExpression)))
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "2025-03-10T15:00:00Z"}, input.ExpressionAttributeValues[":now"])
+}
@@ -0,0 +1,136 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+// maxWriteAttempts is how many times a bucket update is retried when another instance
+// updated the same bucket between our read and write.
+const maxWriteAttempts = 3
+
+type dynamoAPI interface {
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+}
+
+type record struct {
+	BucketKey string  `dynamodbav:"bucket_key"`
+	Tokens    float64 `dynamodbav:"tokens"`
+	UpdatedAt string  `dynamodbav:"updated_at"`
+	ExpiresAt int64   `dynamodbav:"expires_at"`
+}
+
+// DynamoLimiter keeps buckets in DynamoDB so every Lambda instance enforces the same limit.
+// Updates are conditional on the bucket being unchanged since it was read. When DynamoDB
+// can't be reached the limiter falls back to a per-process MemoryLimiter; a bucket that
+// keeps changing under us is busy, so that request is denied instead.
+type DynamoLimiter struct {
+	client    dynamoAPI
+	tableName string
+	fallback  *MemoryLimiter
+	now       func() time.Time
+	logger    *zap.Logger
+}
+
+func NewDynamoLimiter(tableName string, client *dynamodb.Client, logger *zap.Logger) *DynamoLimiter {
+	return &DynamoLimiter{
+		client:    client,
+		tableName: tableName,
+		fallback:  NewMemoryLimiter(),
+		now:       time.Now,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+func (d *DynamoLimiter) Allow(ctx context.Context, key string, limit Limit) Decision {
+	for attempt := 1; attempt <= maxWriteAttempts; attempt++ {
+		decision, err := d.allow(ctx, key, limit)
+		if err == nil {
+			return decision
+		}
+
+		var conditionErr *types.ConditionalCheckFailedException
+		if !errors.As(err, &conditionErr) {
+			d.logger.Warn("error updating rate limit bucket, using in-memory limiter", zap.String("key", key), zap.Error(err))
+			return d.fallback.Allow(ctx, key, limit)
+		}
+	}
+
+	// Every attempt lost to a concurrent request for the same key, which only happens when
+	// the caller is sending requests as fast as it can.
+	d.logger.Warn("rate limit bucket is contended, denying request", zap.String("key", key))
+	return Decision{Allowed: false, RetryAfter: limit.Period / time.Duration(limit.Capacity)}
+}
+
+func (d *DynamoLimiter) allow(ctx context.Context, key string, limit Limit) (Decision, error) {
+	out, err := d.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"bucket_key": &types.AttributeValueMemberS{Value: key},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return Decision{}, err
+	}
+
+	var existing record
+	if len(out.Item) > 0 {
+		err = attributevalue.UnmarshalMap(out.Item, &existing)
+		if err != nil {
+			return Decision{}, err
+		}
+	}
+
+	var current Bucket
+	if existing.UpdatedAt != "" {
+		updatedAt, err := time.Parse(time.RFC3339Nano, existing.UpdatedAt)
+		if err != nil {
+			return Decision{}, err
+		}
+		current = Bucket{Tokens: existing.Tokens, UpdatedAt: updatedAt}
+	}
+
+	now := d.now()
+	bucket, decision := Take(current, limit, now)
+	if !decision.Allowed {
+		// Nothing was spent, so there's nothing to write.
+		return decision, nil
+	}
+
+	item, err := attributevalue.MarshalMap(record{
+		BucketKey: key,
+		Tokens:    bucket.Tokens,
+		UpdatedAt: bucket.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		ExpiresAt: now.Add(2 * limit.Period).Unix(),
+	})
+	if err != nil {
+		return Decision{}, err
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName:           aws.String(d.tableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(bucket_key)"),
+	}
+	if existing.UpdatedAt != "" {
+		input.ConditionExpression = aws.String("updated_at = :updatedAt")
+		input.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":updatedAt": &types.AttributeValueMemberS{Value: existing.UpdatedAt},
+		}
+	}
+
+	_, err = d.client.PutItem(ctx, input)
+	if err != nil {
+		return Decision{}, err
+	}
+	return decision, nil
+}
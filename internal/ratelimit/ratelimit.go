@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// maxMemoryBuckets bounds the in-memory limiter; idle buckets are pruned once it is reached.
+const maxMemoryBuckets = 10000
+
+// Limit is a token bucket holding up to Capacity tokens that refills completely every Period.
+type Limit struct {
+	Capacity int
+	Period   time.Duration
+}
+
+func PerMinute(requests int) Limit {
+	return Limit{Capacity: requests, Period: time.Minute}
+}
+
+// refillRate is the number of tokens added per second.
+func (l Limit) refillRate() float64 {
+	return float64(l.Capacity) / l.Period.Seconds()
+}
+
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+type Decision struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Take refills the bucket for the time elapsed since it was last updated and spends a single
+// token if one is available. A zero Bucket starts full.
+func Take(b Bucket, limit Limit, now time.Time) (Bucket, Decision) {
+	capacity := float64(limit.Capacity)
+	tokens := capacity
+	if !b.UpdatedAt.IsZero() {
+		elapsed := now.Sub(b.UpdatedAt).Seconds()
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		tokens = math.Min(capacity, b.Tokens+elapsed*limit.refillRate())
+	}
+
+	if tokens >= 1 {
+		tokens--
+		return Bucket{Tokens: tokens, UpdatedAt: now}, Decision{
+			Allowed:   true,
+			Remaining: int(tokens),
+		}
+	}
+
+	wait := (1 - tokens) / limit.refillRate()
+	return Bucket{Tokens: tokens, UpdatedAt: now}, Decision{
+		RetryAfter: time.Duration(math.Ceil(wait * float64(time.Second))),
+	}
+}
+
+// Limiter decides whether the caller identified by key may make another request.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) Decision
+}
+
+type memoryBucket struct {
+	bucket    Bucket
+	expiresAt time.Time
+}
+
+// MemoryLimiter enforces limits within a single process. It backs local runs and stands in
+// for the DynamoDB limiter when the table can't be reached.
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]memoryBucket
+	now     func() time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets: map[string]memoryBucket{},
+		now:     time.Now,
+	}
+}
+
+func (m *MemoryLimiter) Allow(ctx context.Context, key string, limit Limit) Decision {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if len(m.buckets) >= maxMemoryBuckets {
+		m.prune(now)
+	}
+
+	bucket, decision := Take(m.buckets[key].bucket, limit, now)
+	m.buckets[key] = memoryBucket{
+		bucket:    bucket,
+		expiresAt: now.Add(limit.Period),
+	}
+	return decision
+}
+
+// prune drops buckets that have been idle long enough to have refilled completely.
+func (m *MemoryLimiter) prune(now time.Time) {
+	for key, b := range m.buckets {
+		if !now.Before(b.expiresAt) {
+			delete(m.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+var testNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestTake(t *testing.T) {
+	limit := PerMinute(60)
+
+	tests := map[string]struct {
+		bucket           Bucket
+		now              time.Time
+		expectedBucket   Bucket
+		expectedDecision Decision
+	}{
+		"New Bucket Starts Full": {
+			now:              testNow,
+			expectedBucket:   Bucket{Tokens: 59, UpdatedAt: testNow},
+			expectedDecision: Decision{Allowed: true, Remaining: 59},
+		},
+		"Refills For Elapsed Time": {
+			bucket:           Bucket{Tokens: 0, UpdatedAt: testNow},
+			now:              testNow.Add(10 * time.Second),
+			expectedBucket:   Bucket{Tokens: 9, UpdatedAt: testNow.Add(10 * time.Second)},
+			expectedDecision: Decision{Allowed: true, Remaining: 9},
+		},
+		"Refill Capped At Capacity": {
+			bucket:           Bucket{Tokens: 30, UpdatedAt: testNow},
+			now:              testNow.Add(time.Hour),
+			expectedBucket:   Bucket{Tokens: 59, UpdatedAt: testNow.Add(time.Hour)},
+			expectedDecision: Decision{Allowed: true, Remaining: 59},
+		},
+		"Empty Bucket Denied": {
+			bucket:           Bucket{Tokens: 0.25, UpdatedAt: testNow},
+			now:              testNow,
+			expectedBucket:   Bucket{Tokens: 0.25, UpdatedAt: testNow},
+			expectedDecision: Decision{RetryAfter: 750 * time.Millisecond},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			bucket, decision := Take(tc.bucket, limit, tc.now)
+			assert.Equal(t, tc.expectedBucket, bucket)
+			assert.Equal(t, tc.expectedDecision, decision)
+		})
+	}
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	now := testNow
+	m := NewMemoryLimiter()
+	m.now = func() time.Time { return now }
+	limit := PerMinute(2)
+
+	assert.True(t, m.Allow(context.Background(), "User#123|POST /feedback", limit).Allowed)
+	assert.True(t, m.Allow(context.Background(), "User#123|POST /feedback", limit).Allowed)
+
+	denied := m.Allow(context.Background(), "User#123|POST /feedback", limit)
+	assert.False(t, denied.Allowed)
+	assert.Equal(t, 30*time.Second, denied.RetryAfter)
+
+	assert.True(t, m.Allow(context.Background(), "User#456|POST /feedback", limit).Allowed)
+
+	now = now.Add(30 * time.Second)
+	assert.True(t, m.Allow(context.Background(), "User#123|POST /feedback", limit).Allowed)
+
+	now = now.Add(time.Hour)
+	m.prune(now)
+	assert.Empty(t, m.buckets)
+}
+
+type fakeDynamo struct {
+	item      map[string]types.AttributeValue
+	getErr    error
+	conflicts int
+	puts      int
+}
+
+func (f *fakeDynamo) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	return &dynamodb.GetItemOutput{Item: f.item}, nil
+}
+
+func (f *fakeDynamo) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	f.puts++
+	if f.conflicts > 0 {
+		f.conflicts--
+		return nil, &types.ConditionalCheckFailedException{}
+	}
+	f.item = params.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func newTestDynamoLimiter(client dynamoAPI) *DynamoLimiter {
+	return &DynamoLimiter{
+		client:    client,
+		tableName: "rate-limit-table-local",
+		fallback:  NewMemoryLimiter(),
+		now:       func() time.Time { return testNow },
+		logger:    zap.NewNop(),
+	}
+}
+
+func TestDynamoLimiter(t *testing.T) {
+	limit := PerMinute(1)
+
+	t.Run("Stores Bucket And Denies When Empty", func(t *testing.T) {
+		client := &fakeDynamo{}
+		d := newTestDynamoLimiter(client)
+
+		assert.True(t, d.Allow(context.Background(), "User#123|POST /event", limit).Allowed)
+
+		var stored record
+		assert.Nil(t, attributevalue.UnmarshalMap(client.item, &stored))
+		assert.Equal(t, "User#123|POST /event", stored.BucketKey)
+		assert.Equal(t, 0.0, stored.Tokens)
+		assert.Equal(t, testNow.Add(2*time.Minute).Unix(), stored.ExpiresAt)
+
+		denied := d.Allow(context.Background(), "User#123|POST /event", limit)
+		assert.False(t, denied.Allowed)
+		assert.Equal(t, time.Minute, denied.RetryAfter)
+		assert.Equal(t, 1, client.puts)
+	})
+
+	t.Run("Retries On Conflicting Update", func(t *testing.T) {
+		client := &fakeDynamo{conflicts: 1}
+		d := newTestDynamoLimiter(client)
+
+		assert.True(t, d.Allow(context.Background(), "User#123|POST /event", limit).Allowed)
+		assert.Equal(t, 2, client.puts)
+	})
+
+	t.Run("Denies When Every Update Conflicts", func(t *testing.T) {
+		client := &fakeDynamo{conflicts: maxWriteAttempts}
+		d := newTestDynamoLimiter(client)
+
+		denied := d.Allow(context.Background(), "User#123|POST /event", PerMinute(60))
+		assert.False(t, denied.Allowed)
+		assert.Equal(t, time.Second, denied.RetryAfter)
+		assert.Equal(t, maxWriteAttempts, client.puts)
+		assert.Empty(t, d.fallback.buckets)
+	})
+
+	t.Run("Falls Back To Memory When DynamoDB Fails", func(t *testing.T) {
+		client := &fakeDynamo{getErr: errors.New("service unavailable")}
+		d := newTestDynamoLimiter(client)
+
+		assert.True(t, d.Allow(context.Background(), "User#123|POST /event", limit).Allowed)
+		assert.False(t, d.Allow(context.Background(), "User#123|POST /event", limit).Allowed)
+		assert.Equal(t, 0, client.puts)
+	})
+}
@@ -0,0 +1,32 @@
+package response
+
+import (
+	"encoding/base64"
+	"mime"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// FormatAttachmentResponse returns a file download rather than JSON. Text bodies are passed
+// through as is; anything else is base64 encoded so API Gateway hands the bytes over intact.
+func FormatAttachmentResponse(body []byte, contentType, filename string) events.APIGatewayProxyResponse {
+	headers := defaultHeaders()
+	headers["Content-Type"] = contentType
+	headers["Content-Disposition"] = mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+	headers["ETag"] = ETag(string(body))
+
+	resp := events.APIGatewayProxyResponse{
+		Headers:    headers,
+		StatusCode: http.StatusOK,
+	}
+	if utf8.Valid(body) {
+		resp.Body = string(body)
+		return resp
+	}
+
+	resp.Body = base64.StdEncoding.EncodeToString(body)
+	resp.IsBase64Encoded = true
+	return resp
+}
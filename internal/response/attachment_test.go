@@ -0,0 +1,46 @@
+package response
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAttachmentResponse(t *testing.T) {
+	tests := map[string]struct {
+		body           []byte
+		contentType    string
+		filename       string
+		expectedBody   string
+		expectedBase64 bool
+	}{
+		"Happy Path - Text Body": {
+			body:         []byte("Event ID,Type\n"),
+			contentType:  "text/csv; charset=utf-8",
+			filename:     "events.csv",
+			expectedBody: "Event ID,Type\n",
+		},
+		"Happy Path - Binary Body": {
+			body:           []byte{0x25, 0x50, 0x44, 0x46, 0xe2, 0xe3, 0xcf, 0xd3},
+			contentType:    "application/pdf",
+			filename:       "report.pdf",
+			expectedBody:   base64.StdEncoding.EncodeToString([]byte{0x25, 0x50, 0x44, 0x46, 0xe2, 0xe3, 0xcf, 0xd3}),
+			expectedBase64: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := FormatAttachmentResponse(tc.body, tc.contentType, tc.filename)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, tc.expectedBody, resp.Body)
+			assert.Equal(t, tc.expectedBase64, resp.IsBase64Encoded)
+			assert.Equal(t, tc.contentType, resp.Headers["Content-Type"])
+			assert.Equal(t, "attachment; filename="+tc.filename, resp.Headers["Content-Disposition"])
+			assert.Equal(t, ETag(string(tc.body)), resp.Headers["ETag"])
+			assert.Equal(t, "nosniff", resp.Headers["X-Content-Type-Options"])
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package response
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const gzipEncoding = "gzip"
+
+// Compress gzips bodies of at least minBytes when the client's Accept-Encoding allows it.
+// API Gateway needs binary bodies base64 encoded. The gzip body is a different
+// representation, so it gets its own ETag; ETagMatches accepts either form.
+func Compress(resp events.APIGatewayProxyResponse, acceptEncoding string, minBytes int) events.APIGatewayProxyResponse {
+	if minBytes <= 0 || len(resp.Body) < minBytes || resp.IsBase64Encoded {
+		return resp
+	}
+	if resp.Headers == nil {
+		resp.Headers = map[string]string{}
+	}
+	if _, encoded := resp.Headers["Content-Encoding"]; encoded {
+		return resp
+	}
+
+	addVary(resp.Headers, "Accept-Encoding")
+	if !acceptsGzip(acceptEncoding) {
+		return resp
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(resp.Body)); err != nil {
+		return resp
+	}
+	if err := zw.Close(); err != nil {
+		return resp
+	}
+	if buf.Len() >= len(resp.Body) {
+		return resp
+	}
+
+	resp.Headers["Content-Encoding"] = gzipEncoding
+	if etag, ok := resp.Headers["ETag"]; ok {
+		resp.Headers["ETag"] = encodedETag(etag, gzipEncoding)
+	}
+	resp.Body = base64.StdEncoding.EncodeToString(buf.Bytes())
+	resp.IsBase64Encoded = true
+	return resp
+}
+
+// acceptsGzip reads an Accept-Encoding header, honouring q=0 as a refusal.
+func acceptsGzip(acceptEncoding string) bool {
+	accepted := false
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(fields[0]))
+		if coding != gzipEncoding && coding != "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.EqualFold(name, "q") {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+
+		// An explicit gzip entry wins over the wildcard.
+		if coding == gzipEncoding {
+			return q > 0
+		}
+		accepted = q > 0
+	}
+	return accepted
+}
+
+// addVary appends field to the Vary header without duplicating it.
+func addVary(headers map[string]string, field string) {
+	existing := headers["Vary"]
+	for _, value := range strings.Split(existing, ",") {
+		if strings.EqualFold(strings.TrimSpace(value), field) {
+			return
+		}
+	}
+
+	if existing == "" {
+		headers["Vary"] = field
+		return
+	}
+	headers["Vary"] = existing + ", " + field
+}
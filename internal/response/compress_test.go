@@ -0,0 +1,101 @@
+package response
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompress(t *testing.T) {
+	large := FormatResponse(map[string]string{"note": strings.Repeat("Took a shower. ", 200)}, http.StatusOK)
+	small := FormatResponse(map[string]string{"note": "Took a shower."}, http.StatusOK)
+
+	tests := map[string]struct {
+		acceptEncoding     string
+		minBytes           int
+		original           string
+		expectedCompressed bool
+		expectedVary       string
+	}{
+		"Happy Path - Gzip Accepted": {
+			acceptEncoding:     "gzip, deflate, br",
+			minBytes:           1024,
+			original:           large.Body,
+			expectedCompressed: true,
+			expectedVary:       "Accept-Encoding",
+		},
+		"Happy Path - Wildcard Accepted": {
+			acceptEncoding:     "*",
+			minBytes:           1024,
+			original:           large.Body,
+			expectedCompressed: true,
+			expectedVary:       "Accept-Encoding",
+		},
+		"Sad Path - Gzip Refused": {
+			acceptEncoding: "gzip;q=0, *",
+			minBytes:       1024,
+			original:       large.Body,
+			expectedVary:   "Accept-Encoding",
+		},
+		"Sad Path - No Accept-Encoding": {
+			minBytes:     1024,
+			original:     large.Body,
+			expectedVary: "Accept-Encoding",
+		},
+		"Sad Path - Below Threshold": {
+			acceptEncoding: "gzip",
+			minBytes:       1024,
+			original:       small.Body,
+		},
+		"Sad Path - Compression Disabled": {
+			acceptEncoding: "gzip",
+			original:       large.Body,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := FormatResponse(nil, http.StatusOK)
+			resp.Body = tc.original
+			identity := ETag(tc.original)
+			resp.Headers["ETag"] = identity
+			resp = Compress(resp, tc.acceptEncoding, tc.minBytes)
+
+			assert.Equal(t, tc.expectedCompressed, resp.IsBase64Encoded)
+			assert.Equal(t, tc.expectedVary, resp.Headers["Vary"])
+			if !tc.expectedCompressed {
+				assert.Equal(t, tc.original, resp.Body)
+				assert.NotContains(t, resp.Headers, "Content-Encoding")
+				assert.Equal(t, identity, resp.Headers["ETag"])
+				return
+			}
+
+			assert.Equal(t, "gzip", resp.Headers["Content-Encoding"])
+			assert.NotEqual(t, identity, resp.Headers["ETag"])
+			assert.True(t, ETagMatches(resp.Headers["ETag"], identity, false))
+			compressed, err := base64.StdEncoding.DecodeString(resp.Body)
+			assert.Nil(t, err)
+			assert.Less(t, len(compressed), len(tc.original))
+
+			zr, err := gzip.NewReader(bytes.NewReader(compressed))
+			assert.Nil(t, err)
+			body, err := io.ReadAll(zr)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.original, string(body))
+		})
+	}
+}
+
+func TestAddVary(t *testing.T) {
+	headers := map[string]string{}
+	addVary(headers, "Accept-Encoding")
+	addVary(headers, "Origin")
+	addVary(headers, "accept-encoding")
+	assert.Equal(t, "Accept-Encoding, Origin", headers["Vary"])
+}
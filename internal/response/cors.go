@@ -0,0 +1,72 @@
+package response
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const (
+	wildcardOrigin  = "*"
+	preflightMaxAge = "600"
+)
+
+var (
+	allowedHeaders = []string{"Content-Type", "Authorization", "X-Amz-Date", "X-Api-Key", "X-Amz-Security-Token", "If-Match", "If-None-Match", "Accept-Version"}
+	exposedHeaders = []string{"Retry-After", "ETag", "Deprecation", "Link"}
+)
+
+// CORS decides which browser origins may read API responses. An AllowedOrigins entry of "*"
+// allows every origin.
+type CORS struct {
+	AllowedOrigins []string
+}
+
+// Apply adds the CORS headers for the request's Origin. Responses to origins that aren't
+// allowed are returned without them, which makes the browser block the read.
+func (c CORS) Apply(resp events.APIGatewayProxyResponse, origin string) events.APIGatewayProxyResponse {
+	if resp.Headers == nil {
+		resp.Headers = map[string]string{}
+	}
+	addVary(resp.Headers, "Origin")
+
+	allowed, ok := c.allowOrigin(origin)
+	if !ok {
+		return resp
+	}
+
+	resp.Headers["Access-Control-Allow-Origin"] = allowed
+	resp.Headers["Access-Control-Allow-Headers"] = strings.Join(allowedHeaders, ", ")
+	resp.Headers["Access-Control-Expose-Headers"] = strings.Join(exposedHeaders, ", ")
+	return resp
+}
+
+func (c CORS) allowOrigin(origin string) (string, bool) {
+	if origin == "" {
+		return "", false
+	}
+
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == wildcardOrigin {
+			return wildcardOrigin, true
+		}
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return origin, true
+		}
+	}
+	return "", false
+}
+
+// CreatePreflightResponse answers an OPTIONS request for a path served by the given methods.
+// The origin headers are added by CORS.Apply.
+func CreatePreflightResponse(methods []string) events.APIGatewayProxyResponse {
+	headers := defaultHeaders()
+	headers["Access-Control-Allow-Methods"] = strings.Join(append(append([]string{}, methods...), http.MethodOptions), ", ")
+	headers["Access-Control-Max-Age"] = preflightMaxAge
+
+	return events.APIGatewayProxyResponse{
+		Headers:    headers,
+		StatusCode: http.StatusNoContent,
+	}
+}
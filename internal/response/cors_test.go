@@ -0,0 +1,64 @@
+package response
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORSApply(t *testing.T) {
+	tests := map[string]struct {
+		cors           CORS
+		origin         string
+		expectedOrigin string
+	}{
+		"Happy Path - Listed Origin": {
+			cors:           CORS{AllowedOrigins: []string{"https://app.test.com/"}},
+			origin:         "https://app.test.com",
+			expectedOrigin: "https://app.test.com",
+		},
+		"Happy Path - Wildcard": {
+			cors:           CORS{AllowedOrigins: []string{"*"}},
+			origin:         "https://anything.test.com",
+			expectedOrigin: "*",
+		},
+		"Sad Path - Origin Not Listed": {
+			cors:   CORS{AllowedOrigins: []string{"https://app.test.com"}},
+			origin: "https://evil.test.com",
+		},
+		"Sad Path - No Origin": {
+			cors: CORS{AllowedOrigins: []string{"*"}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := tc.cors.Apply(FormatResponse(struct{}{}, http.StatusOK), tc.origin)
+			assert.Equal(t, "Origin", resp.Headers["Vary"])
+			assert.Equal(t, "application/json", resp.Headers["Content-Type"])
+
+			origin, ok := resp.Headers["Access-Control-Allow-Origin"]
+			assert.Equal(t, tc.expectedOrigin != "", ok)
+			assert.Equal(t, tc.expectedOrigin, origin)
+			if ok {
+				assert.Equal(t, "Retry-After, ETag, Deprecation, Link", resp.Headers["Access-Control-Expose-Headers"])
+			}
+		})
+	}
+
+	resp := CORS{AllowedOrigins: []string{"*"}}.Apply(events.APIGatewayProxyResponse{}, "https://app.test.com")
+	assert.Equal(t, "*", resp.Headers["Access-Control-Allow-Origin"])
+}
+
+func TestCreatePreflightResponse(t *testing.T) {
+	methods := []string{http.MethodGet, http.MethodPut}
+	resp := CreatePreflightResponse(methods)
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "", resp.Body)
+	assert.Equal(t, "GET, PUT, OPTIONS", resp.Headers["Access-Control-Allow-Methods"])
+	assert.Equal(t, "600", resp.Headers["Access-Control-Max-Age"])
+	assert.Equal(t, []string{http.MethodGet, http.MethodPut}, methods)
+}
@@ -0,0 +1,85 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const anyETag = "*"
+
+// ETag is a strong validator for a response body.
+func ETag(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// encodedETag derives the validator for a content-coded copy of the representation tagged
+// etag, e.g. "abc" becomes "abc-gzip".
+func encodedETag(etag, coding string) string {
+	if !strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+	return strings.TrimSuffix(etag, `"`) + "-" + coding + `"`
+}
+
+// identityETag strips the content coding encodedETag adds, so a client holding the tag of
+// the gzip body still matches the tag of the JSON document.
+func identityETag(etag string) string {
+	return strings.Replace(etag, "-"+gzipEncoding+`"`, `"`, 1)
+}
+
+// ETagMatches reports whether any entity tag in an If-Match or If-None-Match header matches
+// etag, in either its identity or its gzip form. If-None-Match uses the weak comparison, so
+// a W/ prefix on either side is ignored when weak is set.
+func ETagMatches(header, etag string, weak bool) bool {
+	header = strings.TrimSpace(header)
+	if header == "" || etag == "" {
+		return false
+	}
+	if header == anyETag {
+		return true
+	}
+
+	etag = identityETag(etag)
+	if weak {
+		etag = strings.TrimPrefix(etag, "W/")
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = identityETag(strings.TrimSpace(candidate))
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateNotModifiedResponse turns a successful response into a bodiless 304 that keeps the
+// validator and the other headers.
+func CreateNotModifiedResponse(resp events.APIGatewayProxyResponse) events.APIGatewayProxyResponse {
+	headers := map[string]string{}
+	for key, value := range resp.Headers {
+		if key != "Content-Type" && key != "Content-Encoding" {
+			headers[key] = value
+		}
+	}
+
+	return events.APIGatewayProxyResponse{
+		Headers:    headers,
+		StatusCode: http.StatusNotModified,
+	}
+}
+
+func CreatePreconditionFailedResponse() events.APIGatewayProxyResponse {
+	resp := &ErrorResponse{
+		Status: "Precondition Failed",
+	}
+
+	return FormatResponse(resp, http.StatusPreconditionFailed)
+}
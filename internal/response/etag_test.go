@@ -0,0 +1,60 @@
+package response
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	assert.Equal(t, ETag("{}"), ETag("{}"))
+	assert.NotEqual(t, ETag("{}"), ETag("[]"))
+	assert.Len(t, ETag("{}"), 34)
+
+	assert.NotContains(t, CreateBadRequestResponse().Headers, "ETag")
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `"abc"`
+
+	tests := map[string]struct {
+		header   string
+		weak     bool
+		expected bool
+	}{
+		"Exact Match":                {header: `"abc"`, expected: true},
+		"Match In List":              {header: `"xyz", "abc"`, expected: true},
+		"Wildcard":                   {header: "*", expected: true},
+		"No Match":                   {header: `"xyz"`, expected: false},
+		"Empty Header":               {header: "", expected: false},
+		"Weak Tag With Weak Match":   {header: `W/"abc"`, weak: true, expected: true},
+		"Weak Tag With Strong Match": {header: `W/"abc"`, expected: false},
+		"Gzip Tag":                   {header: `"abc-gzip"`, expected: true},
+		"Weak Gzip Tag":              {header: `W/"abc-gzip"`, weak: true, expected: true},
+		"Other Gzip Tag":             {header: `"xyz-gzip"`, expected: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ETagMatches(tc.header, etag, tc.weak))
+		})
+	}
+}
+
+func TestCreateNotModifiedResponse(t *testing.T) {
+	original := FormatResponse(struct{}{}, http.StatusOK)
+	resp := CreateNotModifiedResponse(original)
+
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, "", resp.Body)
+	assert.Equal(t, original.Headers["ETag"], resp.Headers["ETag"])
+	assert.NotContains(t, resp.Headers, "Content-Type")
+	assert.Equal(t, "application/json", original.Headers["Content-Type"])
+}
+
+func TestCreatePreconditionFailedResponse(t *testing.T) {
+	resp := CreatePreconditionFailedResponse()
+	assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	assert.Equal(t, "{\"status\":\"Precondition Failed\"}", resp.Body)
+}
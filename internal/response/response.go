@@ -2,7 +2,10 @@ package response
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 )
@@ -10,20 +13,46 @@ import (
 const Success = "Success"
 
 type ErrorResponse struct {
-	DeveloperText string `json:"developerText,omitempty"`
-	Status        string `json:"status"`
+	DeveloperText string       `json:"developerText,omitempty"`
+	Status        string       `json:"status"`
+	Errors        []FieldError `json:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// defaultHeaders are set on every response. The API only ever returns JSON, so the security
+// headers lock browsers out of rendering or framing it.
+func defaultHeaders() map[string]string {
+	return map[string]string{
+		"Content-Type":              "application/json",
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           "DENY",
+		"Strict-Transport-Security": "max-age=63072000; includeSubDomains",
+		"Content-Security-Policy":   "default-src 'none'; frame-ancestors 'none'",
+		"Referrer-Policy":           "no-referrer",
+	}
 }
 
 func FormatResponse(resp interface{}, statusCode int) events.APIGatewayProxyResponse {
 	respJson, err := json.Marshal(resp)
 	if err != nil {
 		return events.APIGatewayProxyResponse{
+			Headers:    defaultHeaders(),
 			Body:       "Failed to create response body",
 			StatusCode: statusCode,
 		}
 	}
 
+	headers := defaultHeaders()
+	if statusCode == http.StatusOK {
+		headers["ETag"] = ETag(string(respJson))
+	}
+
 	return events.APIGatewayProxyResponse{
+		Headers:    headers,
 		Body:       string(respJson),
 		StatusCode: statusCode,
 	}
@@ -37,6 +66,15 @@ func CreateBadRequestResponse() events.APIGatewayProxyResponse {
 	return FormatResponse(resp, http.StatusBadRequest)
 }
 
+func CreateValidationErrorResponse(fieldErrors []FieldError) events.APIGatewayProxyResponse {
+	resp := &ErrorResponse{
+		Status: "Bad Request",
+		Errors: fieldErrors,
+	}
+
+	return FormatResponse(resp, http.StatusBadRequest)
+}
+
 func CreateResourceNotFoundResponse() events.APIGatewayProxyResponse {
 	resp := &ErrorResponse{
 		Status: "Resource Not Found",
@@ -59,3 +97,15 @@ func CreateAccessDeniedResponse() events.APIGatewayProxyResponse {
 
 	return FormatResponse(resp, http.StatusForbidden)
 }
+
+// CreateTooManyRequestsResponse tells the caller to back off, with Retry-After rounded up to
+// whole seconds.
+func CreateTooManyRequestsResponse(retryAfter time.Duration) events.APIGatewayProxyResponse {
+	resp := FormatResponse(&ErrorResponse{
+		Status: "Too Many Requests",
+	}, http.StatusTooManyRequests)
+
+	seconds := int(math.Max(1, math.Ceil(retryAfter.Seconds())))
+	resp.Headers["Retry-After"] = strconv.Itoa(seconds)
+	return resp
+}
@@ -3,6 +3,7 @@ package response
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/stretchr/testify/assert"
@@ -17,7 +18,9 @@ func TestFormatResponse(t *testing.T) {
 	expectedResponse := events.APIGatewayProxyResponse{
 		Body:       "{\"body\":\"MyTestBody\",\"status\":\"Success\"}",
 		StatusCode: http.StatusOK,
+		Headers:    defaultHeaders(),
 	}
+	expectedResponse.Headers["ETag"] = "\"4d17757410933b54e636d5ec1f88b902\""
 
 	respStruct := &TestResponseStruct{
 		Body:   "MyTestBody",
@@ -28,6 +31,19 @@ func TestFormatResponse(t *testing.T) {
 	assert.Equal(t, expectedResponse, resp)
 }
 
+func TestCreateValidationErrorResponse(t *testing.T) {
+	expectedResponse := events.APIGatewayProxyResponse{
+		Body:       "{\"status\":\"Bad Request\",\"errors\":[{\"field\":\"email\",\"message\":\"is required\"}]}",
+		StatusCode: http.StatusBadRequest,
+		Headers:    defaultHeaders(),
+	}
+
+	resp := CreateValidationErrorResponse([]FieldError{
+		{Field: "email", Message: "is required"},
+	})
+	assert.Equal(t, expectedResponse, resp)
+}
+
 func TestResponses(t *testing.T) {
 	tests := map[string]struct {
 		function         func() events.APIGatewayProxyResponse
@@ -38,6 +54,7 @@ func TestResponses(t *testing.T) {
 			expectedResponse: events.APIGatewayProxyResponse{
 				Body:       "{\"status\":\"Bad Request\"}",
 				StatusCode: http.StatusBadRequest,
+				Headers:    defaultHeaders(),
 			},
 		},
 		"Internal Server Error": {
@@ -45,6 +62,7 @@ func TestResponses(t *testing.T) {
 			expectedResponse: events.APIGatewayProxyResponse{
 				Body:       "{\"status\":\"Internal Server Error\"}",
 				StatusCode: http.StatusInternalServerError,
+				Headers:    defaultHeaders(),
 			},
 		},
 		"Resource Not Found": {
@@ -52,6 +70,7 @@ func TestResponses(t *testing.T) {
 			expectedResponse: events.APIGatewayProxyResponse{
 				Body:       "{\"status\":\"Resource Not Found\"}",
 				StatusCode: http.StatusNotFound,
+				Headers:    defaultHeaders(),
 			},
 		},
 		"Access Denied": {
@@ -59,6 +78,7 @@ func TestResponses(t *testing.T) {
 			expectedResponse: events.APIGatewayProxyResponse{
 				Body:       "{\"status\":\"Access Denied\"}",
 				StatusCode: http.StatusForbidden,
+				Headers:    defaultHeaders(),
 			},
 		},
 	}
@@ -69,3 +89,15 @@ func TestResponses(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateTooManyRequestsResponse(t *testing.T) {
+	expectedResponse := events.APIGatewayProxyResponse{
+		Body:       "{\"status\":\"Too Many Requests\"}",
+		StatusCode: http.StatusTooManyRequests,
+		Headers:    defaultHeaders(),
+	}
+	expectedResponse.Headers["Retry-After"] = "3"
+
+	assert.Equal(t, expectedResponse, CreateTooManyRequestsResponse(2100*time.Millisecond))
+	assert.Equal(t, "1", CreateTooManyRequestsResponse(0).Headers["Retry-After"])
+}
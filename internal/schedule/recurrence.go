@@ -0,0 +1,230 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Recurrence is the subset of an RFC 5545 RRULE that care schedules need. Rules are
+// written like "FREQ=DAILY;BYHOUR=8,20;BYMINUTE=0" or "FREQ=WEEKLY;BYDAY=SA".
+type Recurrence struct {
+	Frequency  Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	ByHour     []int
+	ByMinute   []int
+	Count      int
+	Until      time.Time
+}
+
+func ParseRecurrence(rule string) (Recurrence, error) {
+	r := Recurrence{Interval: 1}
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return r, errors.New("recurrence rule is empty")
+	}
+
+	for _, part := range strings.Split(rule, ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found || value == "" {
+			return r, fmt.Errorf("malformed rule part %q", part)
+		}
+
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Frequency = Frequency(strings.ToUpper(value))
+			if r.Frequency != FrequencyDaily && r.Frequency != FrequencyWeekly && r.Frequency != FrequencyMonthly {
+				return r, fmt.Errorf("unsupported frequency %s", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = parseBoundedInt(value, 1, 365)
+		case "COUNT":
+			r.Count, err = parseBoundedInt(value, 1, 10000)
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseWeekdays(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseIntList(value, 1, 31)
+		case "BYHOUR":
+			r.ByHour, err = parseIntList(value, 0, 23)
+		case "BYMINUTE":
+			r.ByMinute, err = parseIntList(value, 0, 59)
+		default:
+			return r, fmt.Errorf("unsupported rule part %s", name)
+		}
+		if err != nil {
+			return r, fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+
+	if r.Frequency == "" {
+		return r, errors.New("FREQ is required")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return r, errors.New("COUNT and UNTIL cannot both be set")
+	}
+	return r, nil
+}
+
+// Expand returns the occurrence times of the rule starting at dtstart that fall within
+// [from, to]. Dates are evaluated in dtstart's location so "8am daily" stays at 8am
+// across daylight saving changes.
+func (r Recurrence) Expand(dtstart, from, to time.Time) []time.Time {
+	loc := dtstart.Location()
+	startDay := time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), 0, 0, 0, 0, loc)
+
+	hours := r.ByHour
+	if len(hours) == 0 {
+		hours = []int{dtstart.Hour()}
+	}
+	minutes := r.ByMinute
+	if len(minutes) == 0 {
+		minutes = []int{dtstart.Minute()}
+	}
+
+	var occurrences []time.Time
+	count := 0
+	for day := startDay; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if !r.matchesDay(startDay, day, dtstart) {
+			continue
+		}
+
+		for _, hour := range hours {
+			for _, minute := range minutes {
+				t := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+				if t.Before(dtstart) {
+					continue
+				}
+				if !r.Until.IsZero() && t.After(r.Until) {
+					return occurrences
+				}
+
+				count++
+				if r.Count > 0 && count > r.Count {
+					return occurrences
+				}
+				if !t.Before(from) && !t.After(to) {
+					occurrences = append(occurrences, t)
+				}
+			}
+		}
+	}
+	return occurrences
+}
+
+func (r Recurrence) matchesDay(startDay, day, dtstart time.Time) bool {
+	if len(r.ByDay) > 0 && !slices.Contains(r.ByDay, day.Weekday()) {
+		return false
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		return daysBetween(startDay, day)%r.Interval == 0
+	case FrequencyWeekly:
+		if len(r.ByDay) == 0 && day.Weekday() != dtstart.Weekday() {
+			return false
+		}
+		weeks := daysBetween(weekStart(startDay), weekStart(day)) / 7
+		return weeks%r.Interval == 0
+	case FrequencyMonthly:
+		monthDays := r.ByMonthDay
+		if len(monthDays) == 0 && len(r.ByDay) == 0 {
+			monthDays = []int{dtstart.Day()}
+		}
+		if len(monthDays) > 0 && !slices.Contains(monthDays, day.Day()) {
+			return false
+		}
+		months := (day.Year()-startDay.Year())*12 + int(day.Month()) - int(startDay.Month())
+		return months%r.Interval == 0
+	}
+	return false
+}
+
+// daysBetween counts calendar days, which is not the same as elapsed hours / 24 when a
+// daylight saving change falls in between.
+func daysBetween(a, b time.Time) int {
+	ua := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	ub := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(ub.Sub(ua).Hours() / 24)
+}
+
+func weekStart(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func parseBoundedInt(value string, lower, upper int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if n < lower || n > upper {
+		return 0, fmt.Errorf("%d is outside %d-%d", n, lower, upper)
+	}
+	return n, nil
+}
+
+func parseIntList(value string, lower, upper int) ([]int, error) {
+	var list []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := parseBoundedInt(item, lower, upper)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, n)
+	}
+	slices.Sort(list)
+	return slices.Compact(list), nil
+}
+
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, item := range strings.Split(strings.ToUpper(value), ",") {
+		day, found := weekdays[item]
+		if !found {
+			return nil, fmt.Errorf("unknown weekday %s", item)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	// A date on its own includes the whole of that day.
+	t, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unrecognized timestamp %s", value)
+	}
+	return t.Add(24*time.Hour - time.Nanosecond), nil
+}
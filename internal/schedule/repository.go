@@ -0,0 +1,101 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"go.uber.org/zap"
+)
+
+var ErrNotFound = errors.New("schedule not found")
+
+type RepositoryProvider interface {
+	AddSchedule(s *Schedule) error
+	GetSchedules(rid string) ([]Schedule, error)
+	DeleteSchedule(rid, sid string) error
+}
+
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+func (r *Repository) AddSchedule(s *Schedule) error {
+	item, err := attributevalue.MarshalMap(s)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.PutItem(r.Ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.TableName),
+		Item:      item,
+	})
+	if err != nil {
+		r.logger.Error("error adding schedule", zap.String(log.ReceiverIDLogKey, s.ReceiverID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *Repository) GetSchedules(rid string) ([]Schedule, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.TableName),
+		KeyConditionExpression: aws.String("receiver_id = :rid"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":rid": &types.AttributeValueMemberS{Value: rid},
+		},
+	}
+
+	schedules := []Schedule{}
+	paginator := dynamodb.NewQueryPaginator(r.Client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(r.Ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageSchedules []Schedule
+		err = attributevalue.UnmarshalListOfMaps(page.Items, &pageSchedules)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, pageSchedules...)
+	}
+	return schedules, nil
+}
+
+func (r *Repository) DeleteSchedule(rid, sid string) error {
+	_, err := r.Client.DeleteItem(r.Ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.TableName),
+		Key: map[string]types.AttributeValue{
+			"receiver_id": &types.AttributeValueMemberS{Value: rid},
+			"schedule_id": &types.AttributeValueMemberS{Value: sid},
+		},
+		ConditionExpression: aws.String("attribute_exists(schedule_id)"),
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrNotFound
+		}
+		r.logger.Error("error deleting schedule", zap.String(log.ReceiverIDLogKey, rid), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/google/uuid"
+
+	// Lambda's provided runtime does not guarantee a zoneinfo database.
+	_ "time/tzdata"
+)
+
+const (
+	ParamID  = "scheduleId"
+	DBPrefix = "Schedule"
+
+	DefaultMatchWindowMinutes = 60
+)
+
+type OccurrenceStatus string
+
+const (
+	StatusCompleted OccurrenceStatus = "completed"
+	StatusMissed    OccurrenceStatus = "missed"
+	StatusUpcoming  OccurrenceStatus = "upcoming"
+)
+
+// Schedule is a recurring care task for a receiver. Type is one of the event config
+// types, so logged events of that type can be matched back to occurrences.
+type Schedule struct {
+	ReceiverID         string `json:"receiverId" dynamodbav:"receiver_id"`
+	ScheduleID         string `json:"scheduleId" dynamodbav:"schedule_id"`
+	Type               string `json:"type" dynamodbav:"type"`
+	Title              string `json:"title,omitempty" dynamodbav:"title,omitempty"`
+	RRule              string `json:"rrule" dynamodbav:"rrule"`
+	StartTime          string `json:"startTime" dynamodbav:"start_time"`
+	Timezone           string `json:"timezone" dynamodbav:"timezone"`
+	MatchWindowMinutes int    `json:"matchWindowMinutes" dynamodbav:"match_window_minutes"`
+	CreatedBy          string `json:"createdBy" dynamodbav:"created_by"`
+	CreatedAt          string `json:"createdAt" dynamodbav:"created_at"`
+}
+
+type Occurrence struct {
+	ScheduleID string           `json:"scheduleId"`
+	Type       string           `json:"type"`
+	Title      string           `json:"title,omitempty"`
+	Time       string           `json:"time"`
+	Status     OccurrenceStatus `json:"status"`
+	EventID    string           `json:"eventId,omitempty"`
+}
+
+func NewSchedule(rid, createdBy, eventType, title, rrule, startTime, timezone string, matchWindowMinutes int) (*Schedule, error) {
+	if matchWindowMinutes == 0 {
+		matchWindowMinutes = DefaultMatchWindowMinutes
+	}
+	if timezone == "" {
+		timezone = time.UTC.String()
+	}
+
+	s := &Schedule{
+		ReceiverID:         rid,
+		ScheduleID:         fmt.Sprintf("%s#%s", DBPrefix, uuid.NewString()),
+		Type:               eventType,
+		Title:              title,
+		RRule:              rrule,
+		StartTime:          startTime,
+		Timezone:           timezone,
+		MatchWindowMinutes: matchWindowMinutes,
+		CreatedBy:          createdBy,
+		CreatedAt:          time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if _, _, err := s.parse(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s Schedule) MatchWindow() time.Duration {
+	return time.Duration(s.MatchWindowMinutes) * time.Minute
+}
+
+// Occurrences expands the schedule over [from, to], returned in time order.
+func (s Schedule) Occurrences(from, to time.Time) ([]Occurrence, error) {
+	recurrence, dtstart, err := s.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	times := recurrence.Expand(dtstart, from, to)
+	occurrences := make([]Occurrence, 0, len(times))
+	for _, t := range times {
+		occurrences = append(occurrences, Occurrence{
+			ScheduleID: s.ScheduleID,
+			Type:       s.Type,
+			Title:      s.Title,
+			Time:       t.Format(time.RFC3339),
+			Status:     StatusUpcoming,
+		})
+	}
+	return occurrences, nil
+}
+
+func (s Schedule) parse() (Recurrence, time.Time, error) {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return Recurrence{}, time.Time{}, fmt.Errorf("unknown timezone %s", s.Timezone)
+	}
+
+	dtstart, err := time.Parse(time.RFC3339, s.StartTime)
+	if err != nil {
+		return Recurrence{}, time.Time{}, fmt.Errorf("failed to RFC3339 parse start time of %s", s.StartTime)
+	}
+
+	recurrence, err := ParseRecurrence(s.RRule)
+	if err != nil {
+		return Recurrence{}, time.Time{}, err
+	}
+	return recurrence, dtstart.In(loc), nil
+}
+
+// Match marks occurrences as completed when an event of the same type was logged within
+// window of them. Each event completes at most one occurrence, and each occurrence takes
+// the closest unused event. Unmatched occurrences are missed once their window has passed.
+func Match(occurrences []Occurrence, events []event.Entry, window time.Duration, now time.Time) []Occurrence {
+	type candidate struct {
+		occurrence int
+		event      int
+		distance   time.Duration
+	}
+
+	var candidates []candidate
+	for i, o := range occurrences {
+		ot, err := time.Parse(time.RFC3339, o.Time)
+		if err != nil {
+			continue
+		}
+
+		for j, e := range events {
+			if e.Type != o.Type {
+				continue
+			}
+
+			et, err := time.Parse(time.RFC3339, e.StartTime)
+			if err != nil {
+				continue
+			}
+
+			distance := et.Sub(ot).Abs()
+			if distance <= window {
+				candidates = append(candidates, candidate{occurrence: i, event: j, distance: distance})
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	matched := make([]Occurrence, len(occurrences))
+	copy(matched, occurrences)
+	usedEvents := map[int]bool{}
+	for _, c := range candidates {
+		if usedEvents[c.event] || matched[c.occurrence].EventID != "" {
+			continue
+		}
+		usedEvents[c.event] = true
+		matched[c.occurrence].EventID = events[c.event].EventID
+		matched[c.occurrence].Status = StatusCompleted
+	}
+
+	for i, o := range matched {
+		if o.Status == StatusCompleted {
+			continue
+		}
+
+		ot, err := time.Parse(time.RFC3339, o.Time)
+		if err == nil && now.After(ot.Add(window)) {
+			matched[i].Status = StatusMissed
+		}
+	}
+	return matched
+}
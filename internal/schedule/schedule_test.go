@@ -0,0 +1,155 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	r, err := ParseRecurrence("RRULE:FREQ=weekly;INTERVAL=2;BYDAY=MO,FR;BYHOUR=20,8;BYMINUTE=30")
+	assert.Nil(t, err)
+	assert.Equal(t, FrequencyWeekly, r.Frequency)
+	assert.Equal(t, 2, r.Interval)
+	assert.Equal(t, []time.Weekday{time.Monday, time.Friday}, r.ByDay)
+	assert.Equal(t, []int{8, 20}, r.ByHour)
+	assert.Equal(t, []int{30}, r.ByMinute)
+
+	badRules := map[string]string{
+		"Empty":             "",
+		"Missing Frequency": "BYHOUR=8",
+		"Yearly":            "FREQ=YEARLY",
+		"Bad Weekday":       "FREQ=WEEKLY;BYDAY=XX",
+		"Bad Hour":          "FREQ=DAILY;BYHOUR=24",
+		"Unknown Part":      "FREQ=DAILY;BYSETPOS=1",
+		"Count And Until":   "FREQ=DAILY;COUNT=2;UNTIL=20260101",
+		"Malformed":         "FREQ",
+	}
+	for name, rule := range badRules {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseRecurrence(rule)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+
+	tests := map[string]struct {
+		rule     string
+		dtstart  time.Time
+		from     time.Time
+		to       time.Time
+		expected []string
+	}{
+		"Twice Daily": {
+			rule:    "FREQ=DAILY;BYHOUR=8,20;BYMINUTE=0",
+			dtstart: time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC),
+			from:    time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC),
+			to:      time.Date(2026, 5, 3, 12, 0, 0, 0, time.UTC),
+			expected: []string{
+				"2026-05-02T08:00:00Z", "2026-05-02T20:00:00Z", "2026-05-03T08:00:00Z",
+			},
+		},
+		"Weekly Defaults To Start Weekday": {
+			rule:    "FREQ=WEEKLY",
+			dtstart: time.Date(2026, 5, 2, 10, 0, 0, 0, time.UTC),
+			from:    time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+			to:      time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC),
+			expected: []string{
+				"2026-05-02T10:00:00Z", "2026-05-09T10:00:00Z", "2026-05-16T10:00:00Z",
+			},
+		},
+		"Every Other Week": {
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			dtstart: time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC),
+			from:    time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+			to:      time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC),
+			expected: []string{
+				"2026-05-04T09:00:00Z", "2026-05-07T09:00:00Z", "2026-05-18T09:00:00Z", "2026-05-21T09:00:00Z",
+			},
+		},
+		"Monthly Skips Short Months": {
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=31",
+			dtstart: time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC),
+			from:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:      time.Date(2026, 5, 31, 23, 0, 0, 0, time.UTC),
+			expected: []string{
+				"2026-01-31T09:00:00Z", "2026-03-31T09:00:00Z", "2026-05-31T09:00:00Z",
+			},
+		},
+		"Count Counts From Start": {
+			rule:     "FREQ=DAILY;COUNT=3",
+			dtstart:  time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC),
+			from:     time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC),
+			expected: []string{"2026-05-02T09:00:00Z", "2026-05-03T09:00:00Z"},
+		},
+		"Until Date Is Inclusive": {
+			rule:     "FREQ=DAILY;UNTIL=20260502",
+			dtstart:  time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC),
+			from:     time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC),
+			expected: []string{"2026-05-01T09:00:00Z", "2026-05-02T09:00:00Z"},
+		},
+		"Wall Clock Kept Across Daylight Saving": {
+			rule:     "FREQ=DAILY",
+			dtstart:  time.Date(2026, 3, 7, 8, 0, 0, 0, newYork),
+			from:     time.Date(2026, 3, 7, 0, 0, 0, 0, newYork),
+			to:       time.Date(2026, 3, 9, 0, 0, 0, 0, newYork),
+			expected: []string{"2026-03-07T08:00:00-05:00", "2026-03-08T08:00:00-04:00"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r, err := ParseRecurrence(tc.rule)
+			assert.Nil(t, err)
+
+			var actual []string
+			for _, o := range r.Expand(tc.dtstart, tc.from, tc.to) {
+				actual = append(actual, o.Format(time.RFC3339))
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestNewSchedule(t *testing.T) {
+	s, err := NewSchedule("Receiver#123", "User#123", "Medication", "Morning pills", "FREQ=DAILY", "2026-05-01T08:00:00Z", "", 0)
+	assert.Nil(t, err)
+	assert.Regexp(t, "^Schedule#", s.ScheduleID)
+	assert.Equal(t, "UTC", s.Timezone)
+	assert.Equal(t, DefaultMatchWindowMinutes, s.MatchWindowMinutes)
+
+	_, err = NewSchedule("Receiver#123", "User#123", "Medication", "", "FREQ=DAILY", "2026-05-01T08:00:00Z", "Mars/Olympus", 0)
+	assert.NotNil(t, err)
+
+	_, err = NewSchedule("Receiver#123", "User#123", "Medication", "", "FREQ=HOURLY", "2026-05-01T08:00:00Z", "", 0)
+	assert.NotNil(t, err)
+}
+
+func TestMatch(t *testing.T) {
+	occurrences := []Occurrence{
+		{ScheduleID: "Schedule#1", Type: "Medication", Time: "2026-05-01T08:00:00Z", Status: StatusUpcoming},
+		{ScheduleID: "Schedule#1", Type: "Medication", Time: "2026-05-01T20:00:00Z", Status: StatusUpcoming},
+		{ScheduleID: "Schedule#1", Type: "Medication", Time: "2026-05-02T08:00:00Z", Status: StatusUpcoming},
+	}
+	events := []event.Entry{
+		{EventID: "Event#Early", Type: "Medication", StartTime: "2026-05-01T07:40:00Z"},
+		{EventID: "Event#Close", Type: "Medication", StartTime: "2026-05-01T08:05:00Z"},
+		{EventID: "Event#Shower", Type: "Shower", StartTime: "2026-05-01T20:00:00Z"},
+	}
+	now := time.Date(2026, 5, 2, 7, 0, 0, 0, time.UTC)
+
+	matched := Match(occurrences, events, time.Hour, now)
+	assert.Equal(t, StatusCompleted, matched[0].Status)
+	assert.Equal(t, "Event#Close", matched[0].EventID)
+	assert.Equal(t, StatusMissed, matched[1].Status)
+	assert.Equal(t, StatusUpcoming, matched[2].Status)
+	assert.Equal(t, StatusUpcoming, occurrences[0].Status)
+}
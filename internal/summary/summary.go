@@ -0,0 +1,173 @@
+package summary
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+)
+
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+
+	DateFormat = "2006-01-02"
+)
+
+// MetricSummary aggregates the numeric values recorded under one DataPoint name.
+type MetricSummary struct {
+	Count   int     `json:"count"`
+	Total   float64 `json:"total"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Average float64 `json:"average"`
+}
+
+type TypeSummary struct {
+	Type                   string                   `json:"type"`
+	Count                  int                      `json:"count"`
+	TotalDurationMinutes   float64                  `json:"totalDurationMinutes"`
+	AverageDurationMinutes float64                  `json:"averageDurationMinutes"`
+	Metrics                map[string]MetricSummary `json:"metrics,omitempty"`
+}
+
+type Contributor struct {
+	UserID string `json:"userId"`
+	Name   string `json:"name,omitempty"`
+	Count  int    `json:"count"`
+}
+
+func ParsePeriod(value string) (Period, error) {
+	switch p := Period(strings.ToLower(value)); p {
+	case PeriodDay, PeriodWeek, PeriodMonth:
+		return p, nil
+	}
+	return "", fmt.Errorf("unsupported period %q", value)
+}
+
+// Window returns the calendar day, week (starting Monday) or month in loc that contains
+// date, as a half open range [from, to).
+func Window(period Period, date time.Time, loc *time.Location) (time.Time, time.Time) {
+	date = date.In(loc)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+
+	switch period {
+	case PeriodWeek:
+		from := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return from, from.AddDate(0, 0, 7)
+	case PeriodMonth:
+		from := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, loc)
+		return from, from.AddDate(0, 1, 0)
+	}
+	return day, day.AddDate(0, 0, 1)
+}
+
+// Aggregate groups events by type. DataPoint values that parse as numbers are rolled up
+// into metrics; anything else is ignored since it cannot be averaged.
+func Aggregate(events []event.Entry) []TypeSummary {
+	byType := map[string]*TypeSummary{}
+	for _, e := range events {
+		ts, found := byType[e.Type]
+		if !found {
+			ts = &TypeSummary{Type: e.Type}
+			byType[e.Type] = ts
+		}
+
+		ts.Count++
+		ts.TotalDurationMinutes += durationMinutes(e)
+
+		for _, dp := range e.Data {
+			value, ok := parseNumber(dp.Value)
+			if !ok {
+				continue
+			}
+
+			if ts.Metrics == nil {
+				ts.Metrics = map[string]MetricSummary{}
+			}
+			ts.Metrics[dp.Name] = addValue(ts.Metrics[dp.Name], value)
+		}
+	}
+
+	summaries := make([]TypeSummary, 0, len(byType))
+	for _, ts := range byType {
+		ts.TotalDurationMinutes = round(ts.TotalDurationMinutes)
+		ts.AverageDurationMinutes = round(ts.TotalDurationMinutes / float64(ts.Count))
+		for name, m := range ts.Metrics {
+			m.Average = round(m.Total / float64(m.Count))
+			m.Total = round(m.Total)
+			ts.Metrics[name] = m
+		}
+		summaries = append(summaries, *ts)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Type < summaries[j].Type
+	})
+	return summaries
+}
+
+// Contributors counts events per user, most active first.
+func Contributors(events []event.Entry) []Contributor {
+	counts := map[string]int{}
+	for _, e := range events {
+		counts[e.UserID]++
+	}
+
+	contributors := make([]Contributor, 0, len(counts))
+	for uid, count := range counts {
+		contributors = append(contributors, Contributor{UserID: uid, Count: count})
+	}
+
+	sort.Slice(contributors, func(i, j int) bool {
+		if contributors[i].Count != contributors[j].Count {
+			return contributors[i].Count > contributors[j].Count
+		}
+		return contributors[i].UserID < contributors[j].UserID
+	})
+	return contributors
+}
+
+func addValue(m MetricSummary, value float64) MetricSummary {
+	if m.Count == 0 || value < m.Min {
+		m.Min = value
+	}
+	if m.Count == 0 || value > m.Max {
+		m.Max = value
+	}
+	m.Count++
+	m.Total += value
+	return m
+}
+
+func parseNumber(value string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+func durationMinutes(e event.Entry) float64 {
+	start, err := time.Parse(time.RFC3339, e.StartTime)
+	if err != nil {
+		return 0
+	}
+
+	end, err := time.Parse(time.RFC3339, e.EndTime)
+	if err != nil || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start).Minutes()
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,115 @@
+package summary
+
+import (
+	"testing"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePeriod(t *testing.T) {
+	p, err := ParsePeriod("Week")
+	assert.Nil(t, err)
+	assert.Equal(t, PeriodWeek, p)
+
+	_, err = ParsePeriod("year")
+	assert.NotNil(t, err)
+}
+
+func TestWindow(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	assert.Nil(t, err)
+
+	// 2026-05-07 02:00 UTC is still Wednesday 2026-05-06 in Chicago.
+	date := time.Date(2026, 5, 7, 2, 0, 0, 0, time.UTC)
+
+	tests := map[Period][2]string{
+		PeriodDay:   {"2026-05-06T00:00:00-05:00", "2026-05-07T00:00:00-05:00"},
+		PeriodWeek:  {"2026-05-04T00:00:00-05:00", "2026-05-11T00:00:00-05:00"},
+		PeriodMonth: {"2026-05-01T00:00:00-05:00", "2026-06-01T00:00:00-05:00"},
+	}
+	for period, expected := range tests {
+		t.Run(string(period), func(t *testing.T) {
+			from, to := Window(period, date, chicago)
+			assert.Equal(t, expected[0], from.Format(time.RFC3339))
+			assert.Equal(t, expected[1], to.Format(time.RFC3339))
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	events := []event.Entry{
+		{Type: "Weight", UserID: "User#1", StartTime: "2026-05-01T08:00:00Z", EndTime: "2026-05-01T08:00:00Z", Data: []event.DataPoint{{Name: "weight", Value: "150.5"}}},
+		{Type: "Weight", UserID: "User#2", StartTime: "2026-05-02T08:00:00Z", EndTime: "2026-05-02T08:00:00Z", Data: []event.DataPoint{{Name: "weight", Value: "149"}, {Name: "scale", Value: "bathroom"}}},
+		{Type: "Shower", UserID: "User#1", StartTime: "2026-05-01T09:00:00Z", EndTime: "2026-05-01T09:20:00Z"},
+		{Type: "Shower", UserID: "User#1", StartTime: "2026-05-03T09:00:00Z", EndTime: "2026-05-03T09:10:00Z"},
+	}
+
+	assert.Equal(t, []TypeSummary{
+		{Type: "Shower", Count: 2, TotalDurationMinutes: 30, AverageDurationMinutes: 15},
+		{Type: "Weight", Count: 2, Metrics: map[string]MetricSummary{
+			"weight": {Count: 2, Total: 299.5, Min: 149, Max: 150.5, Average: 149.75},
+		}},
+	}, Aggregate(events))
+
+	assert.Equal(t, []Contributor{
+		{UserID: "User#1", Count: 3},
+		{UserID: "User#2", Count: 1},
+	}, Contributors(events))
+}
+
+func TestSeries(t *testing.T) {
+	events := []event.Entry{
+		{Type: "Weight", StartTime: "2026-05-01T08:00:00Z", Data: []event.DataPoint{{Name: "weight", Value: "150"}}},
+		{Type: "Weight", StartTime: "2026-05-01T20:00:00Z", Data: []event.DataPoint{{Name: "weight", Value: "152"}}},
+		{Type: "Weight", StartTime: "2026-05-03T08:00:00Z", Data: []event.DataPoint{{Name: "weight", Value: "149"}}},
+		{Type: "Weight", StartTime: "2026-05-03T09:00:00Z", Data: []event.DataPoint{{Name: "weight", Value: "n/a"}}},
+		{Type: "Shower", StartTime: "2026-05-01T08:00:00Z", Data: []event.DataPoint{{Name: "weight", Value: "1"}}},
+		{Type: "Weight", StartTime: "2026-05-04T08:00:00Z", Data: []event.DataPoint{{Name: "weight", Value: "148"}}},
+	}
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)
+
+	value := func(v float64) *float64 { return &v }
+
+	tests := map[Aggregation][]*float64{
+		AggregationAvg:   {value(151), nil, value(149)},
+		AggregationMin:   {value(150), nil, value(149)},
+		AggregationMax:   {value(152), nil, value(149)},
+		AggregationSum:   {value(302), nil, value(149)},
+		AggregationCount: {value(2), value(0), value(1)},
+		AggregationLast:  {value(152), nil, value(149)},
+	}
+	for agg, expected := range tests {
+		t.Run(string(agg), func(t *testing.T) {
+			points := Series(events, "Weight", "weight", BucketDaily, agg, from, to, time.UTC)
+			assert.Len(t, points, 3)
+			for i, p := range points {
+				assert.Equal(t, expected[i], p.Value)
+			}
+			assert.Equal(t, "2026-05-02T00:00:00Z", points[1].StartTime)
+			assert.Equal(t, "2026-05-03T00:00:00Z", points[1].EndTime)
+			assert.Equal(t, 2, points[0].Count)
+		})
+	}
+
+	_, err := ParseAggregation("median")
+	assert.NotNil(t, err)
+	_, err = ParseBucket("monthly")
+	assert.NotNil(t, err)
+}
+
+func TestBucketCount(t *testing.T) {
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 14*24, BucketCount(BucketHourly, from, to, time.UTC, 1000))
+	assert.Equal(t, 14, BucketCount(BucketDaily, from, to, time.UTC, 1000))
+	// 2026-05-01 is a Friday, so the first week starts on 2026-04-27.
+	assert.Equal(t, 3, BucketCount(BucketWeekly, from, to, time.UTC, 1000))
+
+	// A range of centuries stops one bucket past the limit instead of counting them all.
+	far := time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 11, BucketCount(BucketHourly, from, far, time.UTC, 10))
+}
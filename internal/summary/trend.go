@@ -0,0 +1,175 @@
+package summary
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+)
+
+type Bucket string
+
+const (
+	BucketHourly Bucket = "hourly"
+	BucketDaily  Bucket = "daily"
+	BucketWeekly Bucket = "weekly"
+)
+
+type Aggregation string
+
+const (
+	AggregationAvg   Aggregation = "avg"
+	AggregationMin   Aggregation = "min"
+	AggregationMax   Aggregation = "max"
+	AggregationSum   Aggregation = "sum"
+	AggregationCount Aggregation = "count"
+	AggregationLast  Aggregation = "last"
+)
+
+// Point is one bucket of a trend series. Value is nil when the bucket holds no readings so
+// charts can show a break rather than a zero.
+type Point struct {
+	StartTime string   `json:"startTime"`
+	EndTime   string   `json:"endTime"`
+	Value     *float64 `json:"value"`
+	Count     int      `json:"count"`
+}
+
+func ParseBucket(value string) (Bucket, error) {
+	switch b := Bucket(strings.ToLower(value)); b {
+	case BucketHourly, BucketDaily, BucketWeekly:
+		return b, nil
+	}
+	return "", fmt.Errorf("unsupported bucket %q", value)
+}
+
+func ParseAggregation(value string) (Aggregation, error) {
+	switch a := Aggregation(strings.ToLower(value)); a {
+	case AggregationAvg, AggregationMin, AggregationMax, AggregationSum, AggregationCount, AggregationLast:
+		return a, nil
+	}
+	return "", fmt.Errorf("unsupported aggregation %q", value)
+}
+
+// BucketStart truncates t to the start of its hour, day or week (starting Monday) in loc.
+func BucketStart(bucket Bucket, t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	switch bucket {
+	case BucketHourly:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+	case BucketWeekly:
+		from, _ := Window(PeriodWeek, t, loc)
+		return from
+	}
+	from, _ := Window(PeriodDay, t, loc)
+	return from
+}
+
+func nextBucket(bucket Bucket, start time.Time) time.Time {
+	switch bucket {
+	case BucketHourly:
+		return start.Add(time.Hour)
+	case BucketWeekly:
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// BucketCount is how many buckets Series would return for [from, to). It stops counting
+// once it passes limit, so rejecting a huge range does not walk every bucket in it.
+func BucketCount(bucket Bucket, from, to time.Time, loc *time.Location, limit int) int {
+	count := 0
+	for start := BucketStart(bucket, from, loc); start.Before(to) && count <= limit; start = nextBucket(bucket, start) {
+		count++
+	}
+	return count
+}
+
+type reading struct {
+	at    time.Time
+	value float64
+}
+
+// Series buckets the numeric values recorded under dataPoint on events of eventType between
+// from and to, and reduces each bucket with agg. Every bucket in the range is returned, empty
+// or not, so the series lines up with a chart's x axis.
+func Series(events []event.Entry, eventType, dataPoint string, bucket Bucket, agg Aggregation, from, to time.Time, loc *time.Location) []Point {
+	readings := map[time.Time][]reading{}
+	for _, e := range events {
+		if e.Type != eventType {
+			continue
+		}
+
+		at, err := time.Parse(time.RFC3339, e.StartTime)
+		if err != nil || at.Before(from) || !at.Before(to) {
+			continue
+		}
+
+		for _, dp := range e.Data {
+			if dp.Name != dataPoint {
+				continue
+			}
+
+			value, ok := parseNumber(dp.Value)
+			if !ok {
+				continue
+			}
+
+			start := BucketStart(bucket, at, loc)
+			readings[start] = append(readings[start], reading{at: at, value: value})
+		}
+	}
+
+	points := []Point{}
+	for start := BucketStart(bucket, from, loc); start.Before(to); start = nextBucket(bucket, start) {
+		end := nextBucket(bucket, start)
+		points = append(points, Point{
+			StartTime: start.Format(time.RFC3339),
+			EndTime:   end.Format(time.RFC3339),
+			Value:     reduce(readings[start], agg),
+			Count:     len(readings[start]),
+		})
+	}
+	return points
+}
+
+func reduce(readings []reading, agg Aggregation) *float64 {
+	if agg == AggregationCount {
+		value := float64(len(readings))
+		return &value
+	}
+
+	if len(readings) == 0 {
+		return nil
+	}
+
+	var value float64
+	switch agg {
+	case AggregationLast:
+		sort.SliceStable(readings, func(i, j int) bool {
+			return readings[i].at.Before(readings[j].at)
+		})
+		value = readings[len(readings)-1].value
+	default:
+		m := MetricSummary{}
+		for _, r := range readings {
+			m = addValue(m, r.value)
+		}
+
+		switch agg {
+		case AggregationMin:
+			value = m.Min
+		case AggregationMax:
+			value = m.Max
+		case AggregationSum:
+			value = m.Total
+		default:
+			value = m.Total / float64(m.Count)
+		}
+	}
+
+	value = round(value)
+	return &value
+}
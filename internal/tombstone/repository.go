@@ -0,0 +1,126 @@
+package tombstone
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
+	"go.uber.org/zap"
+)
+
+var ErrNotFound = errors.New("tombstone not found")
+
+type RepositoryProvider interface {
+	AddTombstone(t *Tombstone) error
+	GetTombstone(rid, eid string) (*Tombstone, error)
+	GetTombstones(rid string) ([]Tombstone, error)
+	DeleteTombstone(rid, eid string) error
+}
+
+type Repository struct {
+	Ctx       context.Context
+	Client    *dynamodb.Client
+	TableName string
+	logger    *zap.Logger
+}
+
+func NewRepository(ctx context.Context, tableName string, client *dynamodb.Client, logger *zap.Logger) *Repository {
+	return &Repository{
+		Ctx:       ctx,
+		Client:    client,
+		TableName: tableName,
+		logger:    logger.With(zap.String("table", tableName)),
+	}
+}
+
+func (r *Repository) AddTombstone(t *Tombstone) error {
+	item, err := attributevalue.MarshalMap(t)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.PutItem(r.Ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.TableName),
+		Item:      item,
+	})
+	if err != nil {
+		r.logger.Error("error adding tombstone", zap.String(log.ReceiverIDLogKey, t.ReceiverID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *Repository) GetTombstone(rid, eid string) (*Tombstone, error) {
+	result, err := r.Client.GetItem(r.Ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.TableName),
+		Key:       key(rid, eid),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Item == nil {
+		return nil, ErrNotFound
+	}
+
+	var t Tombstone
+	err = attributevalue.UnmarshalMap(result.Item, &t)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.IsExpired(time.Now()) {
+		return nil, ErrNotFound
+	}
+	return &t, nil
+}
+
+func (r *Repository) GetTombstones(rid string) ([]Tombstone, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.TableName),
+		KeyConditionExpression: aws.String("receiver_id = :rid"),
+		FilterExpression:       aws.String("expires_at > :now"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":rid": &types.AttributeValueMemberS{Value: rid},
+			":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Unix(), 10)},
+		},
+	}
+
+	tombstones := []Tombstone{}
+	paginator := dynamodb.NewQueryPaginator(r.Client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(r.Ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageTombstones []Tombstone
+		err = attributevalue.UnmarshalListOfMaps(page.Items, &pageTombstones)
+		if err != nil {
+			return nil, err
+		}
+		tombstones = append(tombstones, pageTombstones...)
+	}
+	return tombstones, nil
+}
+
+func (r *Repository) DeleteTombstone(rid, eid string) error {
+	_, err := r.Client.DeleteItem(r.Ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.TableName),
+		Key:       key(rid, eid),
+	})
+	return err
+}
+
+func key(rid, eid string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"receiver_id": &types.AttributeValueMemberS{Value: rid},
+		"event_id":    &types.AttributeValueMemberS{Value: eid},
+	}
+}
@@ -0,0 +1,35 @@
+package tombstone
+
+import (
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+)
+
+// Tombstone holds a deleted event until it is restored or its retention window passes.
+type Tombstone struct {
+	ReceiverID string      `json:"receiverId" dynamodbav:"receiver_id"`
+	EventID    string      `json:"eventId" dynamodbav:"event_id"`
+	Event      event.Entry `json:"event" dynamodbav:"event"`
+	DeletedBy  string      `json:"deletedBy" dynamodbav:"deleted_by"`
+	DeletedAt  string      `json:"deletedAt" dynamodbav:"deleted_at"`
+	ExpiresAt  int64       `json:"expiresAt" dynamodbav:"expires_at"`
+}
+
+func NewTombstone(e event.Entry, deletedBy string, retention time.Duration) *Tombstone {
+	now := time.Now().UTC()
+	return &Tombstone{
+		ReceiverID: e.ReceiverID,
+		EventID:    e.EventID,
+		Event:      e,
+		DeletedBy:  deletedBy,
+		DeletedAt:  now.Format(time.RFC3339),
+		ExpiresAt:  now.Add(retention).Unix(),
+	}
+}
+
+// IsExpired reports whether the retention window has passed. DynamoDB TTL deletes
+// lazily, so expired items can still be read for a while after they expire.
+func (t Tombstone) IsExpired(now time.Time) bool {
+	return now.Unix() >= t.ExpiresAt
+}
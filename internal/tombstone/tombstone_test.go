@@ -0,0 +1,49 @@
+package tombstone
+
+import (
+	"testing"
+	"time"
+
+	"github.com/care-giver-app/care-giver-golang-common/pkg/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTombstone(t *testing.T) {
+	e := event.Entry{
+		EventID:    "Event#123",
+		ReceiverID: "Receiver#123",
+	}
+
+	ts := NewTombstone(e, "User#123", time.Hour)
+	assert.Equal(t, "Receiver#123", ts.ReceiverID)
+	assert.Equal(t, "Event#123", ts.EventID)
+	assert.Equal(t, e, ts.Event)
+	assert.Equal(t, "User#123", ts.DeletedBy)
+
+	deletedAt, err := time.Parse(time.RFC3339, ts.DeletedAt)
+	assert.Nil(t, err)
+	assert.Equal(t, deletedAt.Add(time.Hour).Unix(), ts.ExpiresAt)
+}
+
+func TestIsExpired(t *testing.T) {
+	tests := map[string]struct {
+		retention time.Duration
+		expected  bool
+	}{
+		"Within Retention Window": {
+			retention: time.Hour,
+			expected:  false,
+		},
+		"Past Retention Window": {
+			retention: -time.Hour,
+			expected:  true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ts := NewTombstone(event.Entry{}, "User#123", tc.retention)
+			assert.Equal(t, tc.expected, ts.IsExpired(time.Now()))
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	EmailTag      = "emailaddress"
+	PersonNameTag = "personname"
+	FreeTextTag   = "freetext"
+)
+
+var validate = newValidator()
+
+// newValidator builds the validator shared by every request in the API. Field names
+// in errors come from json tags so they match what the client sent.
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	// Registration only fails for empty tags or nil functions, so a panic here is a programming error.
+	if err := v.RegisterValidation(EmailTag, isEmailAddress); err != nil {
+		panic(err)
+	}
+	if err := v.RegisterValidation(PersonNameTag, isPersonName); err != nil {
+		panic(err)
+	}
+	if err := v.RegisterValidation(FreeTextTag, isFreeText); err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func Struct(s interface{}) error {
+	return validate.Struct(s)
+}
+
+// isEmailAddress accepts a bare address, ignoring surrounding whitespace since handlers
+// normalize emails before storing them.
+func isEmailAddress(fl validator.FieldLevel) bool {
+	email := strings.TrimSpace(fl.Field().String())
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return false
+	}
+	return addr.Name == "" && addr.Address == email && strings.Contains(email[strings.LastIndex(email, "@"):], ".")
+}
+
+// isPersonName allows letters from any script plus the punctuation found in real names.
+func isPersonName(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	if strings.TrimSpace(name) != name {
+		return false
+	}
+
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsMark(r) {
+			continue
+		}
+		switch r {
+		case ' ', '-', '\'', '.', '’':
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// isFreeText rejects control characters other than line breaks and tabs.
+func isFreeText(fl validator.FieldLevel) bool {
+	for _, r := range fl.Field().String() {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// FieldErrors converts validation failures into messages that are safe to return to
+// the client. It returns nil for any other kind of error.
+func FieldErrors(err error) []response.FieldError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	fieldErrors := make([]response.FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		fieldErrors = append(fieldErrors, response.FieldError{
+			Field:   fe.Field(),
+			Message: message(fe),
+		})
+	}
+	return fieldErrors
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email", EmailTag:
+		return "must be a valid email address"
+	case "max":
+		return fmt.Sprintf("must be at most %s characters", fe.Param())
+	case "min":
+		return fmt.Sprintf("must be at least %s characters", fe.Param())
+	case PersonNameTag:
+		return "may only contain letters, spaces, hyphens, apostrophes and periods"
+	case FreeTextTag:
+		return "must not contain control characters"
+	}
+	return fmt.Sprintf("failed %s validation", fe.Tag())
+}
@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRequest struct {
+	Email string `json:"email" validate:"required,max=254,emailaddress"`
+	Name  string `json:"name" validate:"required,max=100,personname"`
+	Note  string `json:"note" validate:"omitempty,max=20,freetext"`
+}
+
+func TestStruct(t *testing.T) {
+	tests := map[string]struct {
+		request        testRequest
+		expectedErrors []response.FieldError
+	}{
+		"Happy Path - Valid Request": {
+			request: testRequest{Email: "good@test.com", Name: "Mary-Jane O'Neil", Note: "line one\nline two"},
+		},
+		"Happy Path - Non Latin Name": {
+			request: testRequest{Email: "good@test.com", Name: "José Müller"},
+		},
+		"Sad Path - Bad Email": {
+			request: testRequest{Email: "bob", Name: "Bob"},
+			expectedErrors: []response.FieldError{
+				{Field: "email", Message: "must be a valid email address"},
+			},
+		},
+		"Happy Path - Email With Surrounding Space": {
+			request: testRequest{Email: "  Good@Test.COM ", Name: "Bob"},
+		},
+		"Sad Path - Email With Display Name": {
+			request: testRequest{Email: "Bob <bob@test.com>", Name: "Bob"},
+			expectedErrors: []response.FieldError{
+				{Field: "email", Message: "must be a valid email address"},
+			},
+		},
+		"Sad Path - Missing Fields": {
+			request: testRequest{},
+			expectedErrors: []response.FieldError{
+				{Field: "email", Message: "is required"},
+				{Field: "name", Message: "is required"},
+			},
+		},
+		"Sad Path - Name With Control Characters": {
+			request: testRequest{Email: "good@test.com", Name: "Bob\x00"},
+			expectedErrors: []response.FieldError{
+				{Field: "name", Message: "may only contain letters, spaces, hyphens, apostrophes and periods"},
+			},
+		},
+		"Sad Path - Name With Surrounding Space": {
+			request: testRequest{Email: "good@test.com", Name: " Bob"},
+			expectedErrors: []response.FieldError{
+				{Field: "name", Message: "may only contain letters, spaces, hyphens, apostrophes and periods"},
+			},
+		},
+		"Sad Path - Name Too Long": {
+			request: testRequest{Email: "good@test.com", Name: strings.Repeat("a", 101)},
+			expectedErrors: []response.FieldError{
+				{Field: "name", Message: "must be at most 100 characters"},
+			},
+		},
+		"Sad Path - Note Too Long": {
+			request: testRequest{Email: "good@test.com", Name: "Bob", Note: strings.Repeat("a", 21)},
+			expectedErrors: []response.FieldError{
+				{Field: "note", Message: "must be at most 20 characters"},
+			},
+		},
+		"Sad Path - Note With Control Characters": {
+			request: testRequest{Email: "good@test.com", Name: "Bob", Note: "bell\a"},
+			expectedErrors: []response.FieldError{
+				{Field: "note", Message: "must not contain control characters"},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Struct(tc.request)
+			if tc.expectedErrors == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, tc.expectedErrors, FieldErrors(err))
+			}
+		})
+	}
+}
+
+func TestFieldErrorsIgnoresOtherErrors(t *testing.T) {
+	assert.Nil(t, FieldErrors(errors.New("not a validation error")))
+}
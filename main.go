@@ -2,13 +2,31 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/care-giver-app/care-giver-api/internal/alert"
 	"github.com/care-giver-app/care-giver-api/internal/appconfig"
+	"github.com/care-giver-app/care-giver-api/internal/audit"
+	"github.com/care-giver-app/care-giver-api/internal/calendarfeed"
+	"github.com/care-giver-app/care-giver-api/internal/changelog"
+	"github.com/care-giver-app/care-giver-api/internal/digest"
+	"github.com/care-giver-app/care-giver-api/internal/emailclaim"
+	"github.com/care-giver-app/care-giver-api/internal/eventbatch"
+	"github.com/care-giver-app/care-giver-api/internal/feedback"
+	"github.com/care-giver-app/care-giver-api/internal/fhir"
 	"github.com/care-giver-app/care-giver-api/internal/handlers"
+	"github.com/care-giver-app/care-giver-api/internal/medication"
+	"github.com/care-giver-app/care-giver-api/internal/notifications"
+	"github.com/care-giver-app/care-giver-api/internal/preference"
+	"github.com/care-giver-app/care-giver-api/internal/profile"
+	"github.com/care-giver-app/care-giver-api/internal/ratelimit"
 	"github.com/care-giver-app/care-giver-api/internal/response"
+	"github.com/care-giver-app/care-giver-api/internal/schedule"
+	"github.com/care-giver-app/care-giver-api/internal/tombstone"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/awsconfig"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/dynamo"
 	"github.com/care-giver-app/care-giver-golang-common/pkg/log"
@@ -27,7 +45,24 @@ var (
 	receiverRepo     *repository.ReceiverRepository
 	eventRepo        *repository.EventRepository
 	relationshipRepo *repository.RelationshipRepository
+	tombstoneRepo    *tombstone.Repository
+	auditRepo        *audit.Repository
+	profileRepo      *profile.Repository
+	emailClaimRepo   *emailclaim.Repository
+	eventBatchRepo   *eventbatch.Repository
+	changeLogRepo    *changelog.Repository
+	scheduleRepo     *schedule.Repository
+	medicationRepo   *medication.Repository
+	alertRuleRepo    *alert.Repository
+	preferenceRepo   *preference.Repository
+	digestRepo       *digest.Repository
+	feedbackRepo     *feedback.Repository
+	calendarFeedRepo *calendarfeed.Repository
+	publisher        notifications.Publisher
+	rateLimiter      ratelimit.Limiter
 	handlerRegistry  handlers.RegistryProvider
+
+	observationMappings []fhir.Mapping
 )
 
 func init() {
@@ -55,8 +90,88 @@ func init() {
 	appCfg.Logger.Info("initializing relationship repository")
 	relationshipRepo = repository.NewRelationshipRepository(context.TODO(), appCfg.RelationshipTableName, dynamoClient, appCfg.Logger)
 
+	appCfg.Logger.Info("initializing tombstone repository")
+	tombstoneRepo = tombstone.NewRepository(context.TODO(), appCfg.TombstoneTableName, dynamoClient, appCfg.Logger)
+
+	appCfg.Logger.Info("initializing audit repository")
+	auditRepo = audit.NewRepository(context.TODO(), appCfg.AuditTableName, dynamoClient, appCfg.Logger)
+
+	appCfg.Logger.Info("initializing profile repository")
+	profileRepo = profile.NewRepository(context.TODO(), appCfg.UserTableName, dynamoClient, appCfg.Logger)
+
+	appCfg.Logger.Info("initializing email claim repository")
+	emailClaimRepo = emailclaim.NewRepository(context.TODO(), appCfg.EmailClaimTableName, dynamoClient, appCfg.Logger)
+
+	appCfg.Logger.Info("initializing event batch repository")
+	eventBatchRepo = eventbatch.NewRepository(context.TODO(), appCfg.EventTableName, dynamoClient, appCfg.Logger)
+
+	appCfg.Logger.Info("initializing change log repository")
+	changeLogRepo = changelog.NewRepository(context.TODO(), appCfg.ChangeLogTableName, dynamoClient, appCfg.Logger)
+
+	appCfg.Logger.Info("initializing schedule repository")
+	scheduleRepo = schedule.NewRepository(context.TODO(), appCfg.ScheduleTableName, dynamoClient, appCfg.Logger)
+
+	appCfg.Logger.Info("initializing medication repository")
+	medicationRepo = medication.NewRepository(context.TODO(), appCfg.MedicationTableName, dynamoClient, appCfg.Logger)
+
+	appCfg.Logger.Info("initializing alert rule repository")
+	alertRuleRepo = alert.NewRepository(context.TODO(), appCfg.AlertRuleTableName, dynamoClient, appCfg.Logger)
+
+	appCfg.Logger.Info("initializing notification preference repository")
+	preferenceRepo = preference.NewRepository(context.TODO(), appCfg.PreferenceTableName, dynamoClient, appCfg.Logger)
+
+	appCfg.Logger.Info("initializing activity digest repository")
+	digestRepo = digest.NewRepository(context.TODO(), appCfg.DigestTableName, dynamoClient, appCfg.Logger)
+
+	appCfg.Logger.Info("initializing feedback repository")
+	feedbackRepo = feedback.NewRepository(context.TODO(), appCfg.FeedbackTableName, dynamoClient, appCfg.Logger)
+
+	appCfg.Logger.Info("initializing calendar feed repository")
+	calendarFeedRepo = calendarfeed.NewRepository(context.TODO(), appCfg.CalendarFeedTableName, dynamoClient, appCfg.Logger)
+
+	if appCfg.Env == appconfig.LocalEnv {
+		appCfg.Logger.Info("initializing in-memory notification publisher")
+		publisher = notifications.NewMemoryPublisher()
+	} else {
+		appCfg.Logger.Info("initializing sqs notification publisher")
+		publisher = notifications.NewSQSPublisher(appCfg.AWSConfig, appCfg.NotificationQueueURL, appCfg.Logger)
+	}
+
+	if appCfg.Env == appconfig.LocalEnv {
+		appCfg.Logger.Info("initializing in-memory rate limiter")
+		rateLimiter = ratelimit.NewMemoryLimiter()
+	} else {
+		appCfg.Logger.Info("initializing dynamodb rate limiter")
+		rateLimiter = ratelimit.NewDynamoLimiter(appCfg.RateLimitTableName, dynamoClient, appCfg.Logger)
+	}
+
+	if appCfg.FHIRObservationMappings != "" {
+		appCfg.Logger.Info("parsing fhir observation mappings")
+		observationMappings, err = fhir.ParseMappings(appCfg.FHIRObservationMappings)
+		if err != nil {
+			appCfg.Logger.Sugar().Fatalf("Unable to parse FHIR observation mappings: %v", err)
+		}
+	}
+
 	appCfg.Logger.Info("initializing handler registry")
-	handlerRegistry = handlers.NewRegistry(appCfg, userRepo, receiverRepo, eventRepo, relationshipRepo)
+	handlerRegistry = handlers.NewRegistry(appCfg, userRepo, receiverRepo, eventRepo, relationshipRepo,
+		handlers.WithTombstoneRepo(tombstoneRepo),
+		handlers.WithAuditRepo(auditRepo),
+		handlers.WithProfileRepo(profileRepo),
+		handlers.WithEmailClaimRepo(emailClaimRepo),
+		handlers.WithEventBatchRepo(eventBatchRepo),
+		handlers.WithChangeLogRepo(changeLogRepo),
+		handlers.WithScheduleRepo(scheduleRepo),
+		handlers.WithMedicationRepo(medicationRepo),
+		handlers.WithAlertRuleRepo(alertRuleRepo),
+		handlers.WithPreferenceRepo(preferenceRepo),
+		handlers.WithDigestRepo(digestRepo),
+		handlers.WithFeedbackRepo(feedbackRepo),
+		handlers.WithCalendarFeedRepo(calendarFeedRepo),
+		handlers.WithPublisher(publisher),
+		handlers.WithRateLimiter(rateLimiter),
+		handlers.WithObservationMappings(observationMappings),
+	)
 }
 
 func handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -70,6 +185,36 @@ func handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.API
 	return response.CreateBadRequestResponse(), nil
 }
 
+// ScheduledTask is the payload the scheduled rules in template.yaml invoke the function with.
+type ScheduledTask struct {
+	Task string `json:"task"`
+}
+
+func taskHandler(ctx context.Context, st ScheduledTask) error {
+	appCfg.Logger.Info("recieved scheduled task", zap.String("task", st.Task))
+
+	if task, ok := handlerRegistry.GetTask(st.Task); ok {
+		return handlerRegistry.RunTask(ctx, task)
+	}
+
+	appCfg.Logger.Error("unsupported scheduled task", zap.String("task", st.Task))
+	return fmt.Errorf("unsupported scheduled task %q", st.Task)
+}
+
+// invoke routes a raw invocation to the API handler or, for scheduled rules, the task handler.
+func invoke(ctx context.Context, payload json.RawMessage) (any, error) {
+	var st ScheduledTask
+	if err := json.Unmarshal(payload, &st); err == nil && st.Task != "" {
+		return nil, taskHandler(ctx, st)
+	}
+
+	var req events.APIGatewayProxyRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
 func main() {
-	lambda.Start(handler)
+	lambda.Start(invoke)
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -34,6 +35,24 @@ func (m *MockRegistry) RunHandler(ctx context.Context, handler handlers.HandlerF
 	return events.APIGatewayProxyResponse{}, errors.New("handler not supported")
 }
 
+func (m *MockRegistry) GetTask(name string) (handlers.TaskFunc, bool) {
+	switch name {
+	case "good-task":
+		return func(ctx context.Context, params handlers.HandlerParams) error {
+			return nil
+		}, true
+	case "failing-task":
+		return func(ctx context.Context, params handlers.HandlerParams) error {
+			return errors.New("task failed")
+		}, true
+	}
+	return nil, false
+}
+
+func (m *MockRegistry) RunTask(ctx context.Context, task handlers.TaskFunc) error {
+	return task(ctx, handlers.HandlerParams{})
+}
+
 func TestHandler(t *testing.T) {
 	tests := map[string]struct {
 		request          events.APIGatewayProxyRequest
@@ -71,3 +90,49 @@ func TestHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestInvoke(t *testing.T) {
+	tests := map[string]struct {
+		payload          string
+		expectedResponse any
+		expectError      bool
+	}{
+		"Happy Path - API Request": {
+			payload: `{"httpMethod":"POST","requestContext":{"resourcePath":"/good/path"}}`,
+			expectedResponse: events.APIGatewayProxyResponse{
+				Body: "Handler One",
+			},
+		},
+		"Happy Path - Scheduled Task": {
+			payload: `{"task":"good-task"}`,
+		},
+		"Sad Path - Failing Task": {
+			payload:     `{"task":"failing-task"}`,
+			expectError: true,
+		},
+		"Sad Path - Unknown Task": {
+			payload:     `{"task":"bad-task"}`,
+			expectError: true,
+		},
+		"Sad Path - Malformed Payload": {
+			payload:     `[]`,
+			expectError: true,
+		},
+	}
+
+	handlerRegistry = &MockRegistry{}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := invoke(context.Background(), json.RawMessage(tc.payload))
+			if tc.expectError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			if tc.expectedResponse != nil {
+				assert.Equal(t, tc.expectedResponse, resp)
+			}
+		})
+	}
+}